@@ -2,7 +2,9 @@ package configs
 
 import (
 	"log"
+	"net"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,3 +18,22 @@ func LoadEnv() {
 func GetJWTSecret() string {
 	return os.Getenv("JWT_SECRET")
 }
+
+// GetTrustedProxies membaca TRUSTED_PROXIES (daftar CIDR dipisah koma) milik reverse proxy/load balancer
+// yang boleh menentukan IP klien lewat X-Forwarded-For. Entri yang tidak valid diabaikan.
+func GetTrustedProxies() []*net.IPNet {
+	var ranges []*net.IPNet
+	for _, cidr := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Printf("Warning: invalid TRUSTED_PROXIES entry %q", cidr)
+			continue
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges
+}
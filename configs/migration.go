@@ -2,6 +2,7 @@ package configs
 
 import (
 	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"gorm.io/gorm"
 )
@@ -12,15 +13,26 @@ func RunMigrations(db *gorm.DB) error {
 		return err
 	}
 
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
+		&models.Permission{},
 		&models.Role{},
 		&models.Class{},
 		&models.User{},
+		&models.ClassTeacher{},
+		&models.GuardianStudent{},
+		&models.UserSession{},
+		&models.AdminInvite{},
+		&models.APIKey{},
+		&models.LoginLockout{},
+		&models.AuditLog{},
 		&models.Image{},
 		&models.Materials{},
 		&models.MaterialImages{},
 		&models.Video{},
 		&models.ToootBrushLog{},
+		&models.Alarm{},
+		&models.ToothBrushSession{},
+		&models.ToothBrushSessionOverride{},
 		&models.QuizType{},
 		&models.Quiz{},
 		&models.Question{},
@@ -30,6 +42,7 @@ func RunMigrations(db *gorm.DB) error {
 		&models.QuizHistory{},
 		&models.QuestionHistory{},
 		&models.AnswerHistory{},
+		&models.QuizPair{},
 		&models.StimulatedSaliva{},
 		&models.RestingSaliva{},
 		&models.SalivaOption{},
@@ -39,7 +52,77 @@ func RunMigrations(db *gorm.DB) error {
 		&models.ConfidenceDetail{},
 		&models.Prediction{},
 		&models.PredictHistory{},
-	)
+		&models.Questionnaire{},
+		&models.QuestionnaireItem{},
+		&models.Respondents{},
+		&models.RespondentAnswer{},
+	); err != nil {
+		return err
+	}
+
+	if err := SeedRolesAndPermissions(db); err != nil {
+		return err
+	}
+
+	return SeedToothBrushSessions(db)
+}
+
+// SeedRolesAndPermissions memastikan role dasar (admin, teacher, student, guardian) ada dan mendaftarkan permission
+// dari katalog. Hanya permission atau role yang baru dibuat yang otomatis mendapat hak default-nya,
+// sehingga permission yang sengaja dicabut admin tidak dikembalikan setiap kali aplikasi start.
+func SeedRolesAndPermissions(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		roles := map[string]*models.Role{}
+		newRoles := map[string]bool{}
+		for _, name := range []string{"admin", "teacher", "student", "guardian"} {
+			var role models.Role
+			result := tx.Where("name = ?", name).FirstOrCreate(&role, models.Role{Name: name})
+			if result.Error != nil {
+				return result.Error
+			}
+			roles[name] = &role
+			newRoles[name] = result.RowsAffected > 0
+		}
+
+		for _, def := range permission.Catalog {
+			var perm models.Permission
+			result := tx.Where("code = ?", def.Code).Limit(1).Find(&perm)
+			if result.Error != nil {
+				return result.Error
+			}
+			created := result.RowsAffected == 0
+			if created {
+				perm = models.Permission{Code: def.Code, Description: def.Description}
+				if err := tx.Create(&perm).Error; err != nil {
+					return err
+				}
+			}
+
+			for _, roleName := range def.DefaultRoles {
+				role, ok := roles[roleName]
+				if !ok || (!created && !newRoles[roleName]) {
+					continue
+				}
+				if err := tx.Model(role).Association("Permissions").Append(&perm); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// SeedToothBrushSessions mengisi sesi default (jendela waktu lama yang sebelumnya hard-code)
+// bila belum ada. Sesi yang sudah diubah admin tidak ditimpa.
+func SeedToothBrushSessions(db *gorm.DB) error {
+	return db.Exec(`
+		INSERT INTO tooth_brush_sessions (code, name, start_time, end_time, grace_minutes, is_active, created_at, updated_at)
+		VALUES
+			('MORNING', 'Pagi', '05:00', '07:00', 0, true, NOW(), NOW()),
+			('NIGHT', 'Malam', '17:00', '22:00', 0, true, NOW(), NOW())
+		ON CONFLICT (code) DO NOTHING;
+	`).Error
 }
 
 func CreateQuestionnaireEnum(db *gorm.DB) error {
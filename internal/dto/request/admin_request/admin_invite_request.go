@@ -0,0 +1,9 @@
+package adminrequest
+
+import "github.com/google/uuid"
+
+type CreateAdminInviteRequest struct {
+	RoleID         uuid.UUID `json:"role_id"`
+	Note           string    `json:"note"`
+	ExpiresInHours int       `json:"expires_in_hours"`
+}
@@ -3,12 +3,15 @@ package adminrequest
 import "mime/multipart"
 
 type RegisterAdminRequest struct {
-	Username string                `form:"username" json:"username"`
-	Password string                `form:"password" json:"password"`
-	Photo    *multipart.FileHeader `form:"photo" json:"photo"`
+	Username    string                `form:"username" json:"username"`
+	Password    string                `form:"password" json:"password"`
+	Photo       *multipart.FileHeader `form:"photo" json:"photo"`
+	InviteToken string                `form:"invite_token" json:"invite_token"`
 }
 
 type LoginAdminRequest struct {
-	Username string `form:"username" json:"username"`
-	Password string `form:"password" json:"password"`
+	Username  string `form:"username" json:"username"`
+	Password  string `form:"password" json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
@@ -0,0 +1,12 @@
+package alarmrequest
+
+type CreateAlarmRequest struct {
+	Morning string `form:"morning" json:"morning"`
+	Night   string `form:"night" json:"night"`
+}
+
+// UpdateAlarmRequest: field nil = tidak diubah, string kosong = alarm sesi tersebut dimatikan.
+type UpdateAlarmRequest struct {
+	Morning *string `form:"morning" json:"morning"`
+	Night   *string `form:"night" json:"night"`
+}
@@ -0,0 +1,17 @@
+package auditrequest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchAuditLogRequest diisi dari query string GET /audit-log; field kosong/nil berarti tanpa filter.
+type SearchAuditLogRequest struct {
+	ActorID    *uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	StartDate  *time.Time
+	EndDate    *time.Time
+}
@@ -1,5 +1,7 @@
 package classrequest
 
+import "github.com/google/uuid"
+
 type CreateClassRequest struct {
 	NameClass string `form:"name_class" json:"name_class"`
 	Grade     string `form:"grade" json:"grade"`
@@ -11,3 +13,8 @@ type UpdateClassRequest struct {
 	Grade     string `form:"grade" json:"grade"`
 	Teacher   string `form:"teacher" json:"teacher"`
 }
+
+// UpdateClassTeachersRequest mengganti seluruh daftar guru pengampu kelas; daftar kosong melepas semua guru.
+type UpdateClassTeachersRequest struct {
+	TeacherIds []uuid.UUID `json:"teacher_ids"`
+}
@@ -0,0 +1,25 @@
+package guardianrequest
+
+import "time"
+
+type RegisterGuardianRequest struct {
+	Name            string `form:"name" json:"name"`
+	Username        string `form:"username" json:"username"`
+	Password        string `form:"password" json:"password"`
+	ConfirmPassword string `form:"confirm_password" json:"confirm_password"`
+}
+
+type LoginGuardianRequest struct {
+	Username  string `form:"username" json:"username"`
+	Password  string `form:"password" json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+// LinkStudentRequest memverifikasi anak dengan NISN + tanggal lahir, atau dengan kode dari sekolah.
+type LinkStudentRequest struct {
+	Nisn        string    `form:"nisn" json:"nisn"`
+	DateOfBirth time.Time `form:"date_of_birth" json:"date_of_birth"`
+	Code        string    `form:"code" json:"code"`
+	IPAddress   string    `json:"-"`
+}
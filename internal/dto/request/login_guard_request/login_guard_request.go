@@ -0,0 +1,7 @@
+package loginguardrequest
+
+// UnlockLoginRequest membuka kunci login untuk username dan/atau alamat IP.
+type UnlockLoginRequest struct {
+	Username  string `form:"username" json:"username"`
+	IPAddress string `form:"ip_address" json:"ip_address"`
+}
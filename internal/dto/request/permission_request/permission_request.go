@@ -0,0 +1,18 @@
+package permissionrequest
+
+import "github.com/google/uuid"
+
+type CreatePermissionRequest struct {
+	Code        string `form:"code" json:"code"`
+	Description string `form:"description" json:"description"`
+}
+
+type UpdatePermissionRequest struct {
+	Code        string `form:"code" json:"code"`
+	Description string `form:"description" json:"description"`
+}
+
+// UpdateRolePermissionsRequest mengganti seluruh permission role dengan daftar ini.
+type UpdateRolePermissionsRequest struct {
+	PermissionIds []uuid.UUID `json:"permission_ids"`
+}
@@ -2,6 +2,7 @@ package questionrequest
 
 import (
 	answerrequest "giat-cerika-service/internal/dto/request/answer_request"
+	"giat-cerika-service/internal/models"
 	"mime/multipart"
 
 	"github.com/google/uuid"
@@ -10,6 +11,9 @@ import (
 type CreateQuestionRequest struct {
 	QuizId        uuid.UUID                           `json:"quiz_id" binding:"required,uuid"`
 	QuestionText  string                              `json:"question_text" binding:"required"`
+	QuestionType  models.QuestionType                 `json:"question_type"`
+	Tag           string                              `json:"tag"`
+	Difficulty    models.QuestionDifficulty           `json:"difficulty"`
 	QuestionImage *multipart.FileHeader               `form:"question_image" swaggerignore:"true"`
 	Answers       []answerrequest.CreateAnswerRequest `json:"answers" binding:"required,dive,required"`
 }
@@ -17,6 +21,9 @@ type CreateQuestionRequest struct {
 type UpdateQuestionRequest struct {
 	QuizId        uuid.UUID                           `json:"quiz_id" binding:"required,uuid"`
 	QuestionText  string                              `json:"question_text" binding:"required"`
+	QuestionType  models.QuestionType                 `json:"question_type"`
+	Tag           string                              `json:"tag"`
+	Difficulty    models.QuestionDifficulty           `json:"difficulty"`
 	QuestionImage *multipart.FileHeader               `form:"question_image" swaggerignore:"true"`
 	Answers       []answerrequest.CreateAnswerRequest `json:"answers" binding:"required,dive,required"`
 }
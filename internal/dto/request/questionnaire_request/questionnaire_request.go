@@ -1,21 +1,48 @@
 package questionnairerequest
 
+import "github.com/google/uuid"
+
 type CreateQuestionnaireRequest struct {
-	Title       string `form:"title" json:"title"`
-	Description string `form:"description" json:"description"`
-	Amount      string    `form:"amount" json:"amount"`
-	Code        string    `form:"code" json:"code"`
-	Status      string    `form:"status" json:"status"`
-	Type        string `form:"type" json:"type"`
-	Duration    string `form:"duration" json:"duration"`
+	Title        string `form:"title" json:"title"`
+	Description  string `form:"description" json:"description"`
+	Code         string `form:"code" json:"code"`
+	Type         string `form:"type" json:"type"`
+	ActivePeriod string `form:"active_period" json:"active_period"`
+	ScaleMin     int    `form:"scale_min" json:"scale_min"`
+	ScaleMax     int    `form:"scale_max" json:"scale_max"`
 }
 
 type UpdateQuestionnaireRequest struct {
-	Title       string `form:"title" json:"title"`
-	Description string `form:"description" json:"description"`
-	Amount      string    `form:"amount" json:"amount"`
-	Code        string    `form:"code" json:"code"`
-	Status      string    `form:"status" json:"status"`
-	Type        string `form:"type" json:"type"`
-	Duration    string `form:"duration" json:"duration"`
+	Title        string `form:"title" json:"title"`
+	Description  string `form:"description" json:"description"`
+	Code         string `form:"code" json:"code"`
+	Type         string `form:"type" json:"type"`
+	ActivePeriod string `form:"active_period" json:"active_period"`
+	ScaleMin     *int   `form:"scale_min" json:"scale_min"`
+	ScaleMax     *int   `form:"scale_max" json:"scale_max"`
+}
+
+type UpdateStatusQuestionnaireRequest struct {
+	Status int `form:"status" json:"status"`
+}
+
+type CreateQuestionnaireItemRequest struct {
+	Statement  string `form:"statement" json:"statement"`
+	ItemOrder  int    `form:"item_order" json:"item_order"`
+	IsReversed bool   `form:"is_reversed" json:"is_reversed"`
+}
+
+type UpdateQuestionnaireItemRequest struct {
+	Statement  string `form:"statement" json:"statement"`
+	ItemOrder  *int   `form:"item_order" json:"item_order"`
+	IsReversed *bool  `form:"is_reversed" json:"is_reversed"`
+}
+
+type SubmitQuestionnaireAnswer struct {
+	ItemID uuid.UUID `json:"item_id"`
+	Value  int       `json:"value"`
+}
+
+type SubmitQuestionnaireRequest struct {
+	Answers []SubmitQuestionnaireAnswer `json:"answers"`
 }
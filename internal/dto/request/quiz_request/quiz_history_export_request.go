@@ -0,0 +1,15 @@
+package quizrequest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportQuizHistoryRequest struct {
+	QuizID         *uuid.UUID
+	ClassID        *uuid.UUID
+	StartDate      *time.Time
+	EndDate        *time.Time
+	IncludeAnswers bool
+}
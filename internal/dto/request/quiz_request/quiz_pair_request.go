@@ -0,0 +1,17 @@
+package quizrequest
+
+import "github.com/google/uuid"
+
+type CreateQuizPairRequest struct {
+	Title       string    `form:"title" json:"title"`
+	Description string    `form:"description" json:"description"`
+	PreQuizID   uuid.UUID `form:"pre_quiz_id" json:"pre_quiz_id"`
+	PostQuizID  uuid.UUID `form:"post_quiz_id" json:"post_quiz_id"`
+}
+
+type UpdateQuizPairRequest struct {
+	Title       string    `form:"title" json:"title"`
+	Description string    `form:"description" json:"description"`
+	PreQuizID   uuid.UUID `form:"pre_quiz_id" json:"pre_quiz_id"`
+	PostQuizID  uuid.UUID `form:"post_quiz_id" json:"post_quiz_id"`
+}
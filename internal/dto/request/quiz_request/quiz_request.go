@@ -13,6 +13,8 @@ type CreateQuizRequest struct {
 	Description string    `form:"description" json:"description"`
 	StartDate   time.Time `form:"start_date" json:"start_date"`
 	EndDate     time.Time `form:"end_date" json:"end_date"`
+	// DurationMinutes 0 = tanpa batas per percobaan (mengikuti end_date).
+	DurationMinutes int `form:"duration_minutes" json:"duration_minutes"`
 }
 
 type UpdateQuizRequest struct {
@@ -22,6 +24,8 @@ type UpdateQuizRequest struct {
 	Description string    `form:"description" json:"description"`
 	StartDate   time.Time `form:"start_date" json:"start_date"`
 	EndDate     time.Time `form:"end_date" json:"end_date"`
+	// DurationMinutes nil = tidak diubah; 0 menghapus batas per percobaan.
+	DurationMinutes *int `form:"duration_minutes" json:"duration_minutes"`
 }
 
 type UpdateStatusQuizRequest struct {
@@ -31,3 +35,16 @@ type UpdateStatusQuizRequest struct {
 type UpdateQuestionOrderModeRequest struct {
 	QuestionOrderMode string `form:"question_order_mode" json:"question_order_mode"`
 }
+
+type UpdateCorrectAnswerVisibilityRequest struct {
+	CorrectAnswerVisibility string `form:"correct_answer_visibility" json:"correct_answer_visibility"`
+}
+
+type UpdateShuffleAnswersRequest struct {
+	ShuffleAnswers bool `form:"shuffle_answers" json:"shuffle_answers"`
+}
+
+type UpdateQuestionPoolRequest struct {
+	DrawCount      int    `form:"draw_count" json:"draw_count"`
+	DrawStratifyBy string `form:"draw_stratify_by" json:"draw_stratify_by"`
+}
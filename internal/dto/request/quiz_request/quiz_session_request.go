@@ -2,9 +2,20 @@ package quizrequest
 
 import "github.com/google/uuid"
 
+type SubmitMatchPairRequest struct {
+	AnswerID  uuid.UUID `json:"answer_id"`
+	MatchText string    `json:"match_text"`
+}
+
+// SubmitAnswerRequest: field yang diisi mengikuti tipe soal —
+// answer_id (single choice/true false), answer_ids (multi select),
+// text_answer (short answer), pairs (matching).
 type SubmitAnswerRequest struct {
-	QuestionID uuid.UUID `json:"question_id" binding:"required"`
-	AnswerID   uuid.UUID `json:"answer_id" binding:"required"`
+	QuestionID uuid.UUID                `json:"question_id" binding:"required"`
+	AnswerID   uuid.UUID                `json:"answer_id"`
+	AnswerIDs  []uuid.UUID              `json:"answer_ids"`
+	TextAnswer string                   `json:"text_answer"`
+	Pairs      []SubmitMatchPairRequest `json:"pairs"`
 }
 
 type SubmitQuizRequest struct {
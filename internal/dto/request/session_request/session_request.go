@@ -0,0 +1,7 @@
+package sessionrequest
+
+type RefreshTokenRequest struct {
+	RefreshToken string `form:"refresh_token" json:"refresh_token"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
+}
@@ -20,19 +20,29 @@ type RegisterStudentRequest struct {
 }
 
 type LoginStudentRequet struct {
-	Username string `form:"username" json:"username"`
-	Password string `form:"password" json:"password"`
+	Username  string `form:"username" json:"username"`
+	Password  string `form:"password" json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type CheckNisnAndDateOfBirth struct {
 	Nisn        string    `form:"nisn" json:"nisn"`
 	DateOfBirth time.Time `form:"date_of_birth" json:"date_of_birth"`
+	IPAddress   string    `json:"-"`
+}
+
+// VerifyResetCodeRequest menukar kode reset cetak dari admin dengan reset token.
+type VerifyResetCodeRequest struct {
+	Username  string `form:"username" json:"username"`
+	Code      string `form:"code" json:"code"`
+	IPAddress string `json:"-"`
 }
 
 type UpdatePassword struct {
-	StudentID       uuid.UUID `form:"student_id" json:"student_id"`
-	NewPassword     string    `form:"new_password" json:"new_password"`
-	ConfirmPassword string    `form:"confirm_password" json:"confirm_password"`
+	ResetToken      string `form:"reset_token" json:"reset_token"`
+	NewPassword     string `form:"new_password" json:"new_password"`
+	ConfirmPassword string `form:"confirm_password" json:"confirm_password"`
 }
 
 type UpdateProfileRequest struct {
@@ -45,5 +55,20 @@ type UpdateProfileRequest struct {
 }
 
 type CreateTootBrushRequest struct {
-	TimeType string `form:"time_type" json:"time_type"`
+	TimeType string                `form:"time_type" json:"time_type"`
+	Photo    *multipart.FileHeader `form:"photo" json:"photo"`
+}
+
+type UpdateStudentStatusRequest struct {
+	Status *int `form:"status" json:"status"`
+}
+
+type ImportStudentRequest struct {
+	File   *multipart.FileHeader `form:"file" json:"file"`
+	DryRun bool                  `form:"dry_run" json:"dry_run"`
+}
+
+type ReviewToothBrushRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
 }
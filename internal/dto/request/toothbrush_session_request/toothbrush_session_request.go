@@ -0,0 +1,28 @@
+package toothbrushsessionrequest
+
+import "github.com/google/uuid"
+
+type CreateToothBrushSessionRequest struct {
+	Code         string `form:"code" json:"code"`
+	Name         string `form:"name" json:"name"`
+	StartTime    string `form:"start_time" json:"start_time"`
+	EndTime      string `form:"end_time" json:"end_time"`
+	GraceMinutes *int   `form:"grace_minutes" json:"grace_minutes"`
+	IsActive     *bool  `form:"is_active" json:"is_active"`
+}
+
+type UpdateToothBrushSessionRequest struct {
+	Code         string `form:"code" json:"code"`
+	Name         string `form:"name" json:"name"`
+	StartTime    string `form:"start_time" json:"start_time"`
+	EndTime      string `form:"end_time" json:"end_time"`
+	GraceMinutes *int   `form:"grace_minutes" json:"grace_minutes"`
+	IsActive     *bool  `form:"is_active" json:"is_active"`
+}
+
+type UpsertClassOverrideRequest struct {
+	ClassID      uuid.UUID `form:"class_id" json:"class_id"`
+	StartTime    string    `form:"start_time" json:"start_time"`
+	EndTime      string    `form:"end_time" json:"end_time"`
+	GraceMinutes *int      `form:"grace_minutes" json:"grace_minutes"`
+}
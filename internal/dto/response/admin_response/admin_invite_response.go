@@ -0,0 +1,57 @@
+package adminresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdminInviteResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Role      string     `json:"role"`
+	Note      string     `json:"note"`
+	Status    string     `json:"status"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	UsedBy    *uuid.UUID `json:"used_by"`
+	ExpiresAt string     `json:"expires_at"`
+	UsedAt    string     `json:"used_at"`
+	RevokedAt string     `json:"revoked_at"`
+	CreatedAt string     `json:"created_at"`
+}
+
+// AdminInviteCreatedResponse hanya dikembalikan sekali saat undangan dibuat karena memuat token mentah.
+type AdminInviteCreatedResponse struct {
+	AdminInviteResponse
+	Token string `json:"token"`
+}
+
+// InviteStatus menurunkan status undangan dari timestamp-nya.
+func InviteStatus(invite models.AdminInvite, now time.Time) string {
+	switch {
+	case invite.UsedAt != nil:
+		return "used"
+	case invite.RevokedAt != nil:
+		return "revoked"
+	case !now.Before(invite.ExpiresAt):
+		return "expired"
+	default:
+		return "pending"
+	}
+}
+
+func ToAdminInviteResponse(invite models.AdminInvite) AdminInviteResponse {
+	return AdminInviteResponse{
+		ID:        invite.ID,
+		Role:      invite.Role.Name,
+		Note:      invite.Note,
+		Status:    InviteStatus(invite, time.Now()),
+		CreatedBy: invite.CreatedBy,
+		UsedBy:    invite.UsedBy,
+		ExpiresAt: utils.FormatDateTime(&invite.ExpiresAt),
+		UsedAt:    utils.FormatDateTime(invite.UsedAt),
+		RevokedAt: utils.FormatDateTime(invite.RevokedAt),
+		CreatedAt: utils.FormatDateTime(&invite.CreatedAt),
+	}
+}
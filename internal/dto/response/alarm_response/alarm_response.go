@@ -0,0 +1,26 @@
+package alarmresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type AlarmResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Morning   string    `json:"morning"`
+	Night     string    `json:"night"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+func ToAlarmResponse(alarm models.Alarm) AlarmResponse {
+	return AlarmResponse{
+		ID:        alarm.ID,
+		Morning:   alarm.Morning,
+		Night:     alarm.Night,
+		CreatedAt: utils.FormatDate(alarm.CreatedAt),
+		UpdatedAt: utils.FormatDate(alarm.UpdatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package apikeyresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	Scopes     []string  `json:"scopes"`
+	Status     string    `json:"status"`
+	CreatedBy  uuid.UUID `json:"created_by"`
+	ExpiresAt  string    `json:"expires_at"`
+	LastUsedAt string    `json:"last_used_at"`
+	RevokedAt  string    `json:"revoked_at"`
+	CreatedAt  string    `json:"created_at"`
+}
+
+// APIKeyCreatedResponse hanya dikembalikan sekali saat API key dibuat karena memuat key mentah.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyStatus menurunkan status API key dari timestamp-nya.
+func APIKeyStatus(key models.APIKey, now time.Time) string {
+	switch {
+	case key.RevokedAt != nil:
+		return "revoked"
+	case !now.Before(key.ExpiresAt):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+func ToAPIKeyResponse(key models.APIKey) APIKeyResponse {
+	scopes := []string(key.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		Status:     APIKeyStatus(key, time.Now()),
+		CreatedBy:  key.CreatedBy,
+		ExpiresAt:  utils.FormatDateTime(&key.ExpiresAt),
+		LastUsedAt: utils.FormatDateTime(key.LastUsedAt),
+		RevokedAt:  utils.FormatDateTime(key.RevokedAt),
+		CreatedAt:  utils.FormatDateTime(&key.CreatedAt),
+	}
+}
@@ -0,0 +1,44 @@
+package auditresponse
+
+import (
+	"encoding/json"
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	ActorID       *uuid.UUID      `json:"actor_id"`
+	ActorUsername string          `json:"actor_username"`
+	ActorRole     string          `json:"actor_role"`
+	Action        string          `json:"action"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	IPAddress     string          `json:"ip_address"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+func ToAuditLogResponse(log models.AuditLog) AuditLogResponse {
+	username := ""
+	if log.Actor != nil {
+		username = log.Actor.Username
+	}
+
+	return AuditLogResponse{
+		ID:            log.ID,
+		ActorID:       log.ActorID,
+		ActorUsername: username,
+		ActorRole:     log.ActorRole,
+		Action:        log.Action,
+		EntityType:    log.EntityType,
+		EntityID:      log.EntityID,
+		Before:        log.Before,
+		After:         log.After,
+		IPAddress:     log.IPAddress,
+		CreatedAt:     utils.FormatDateTime(&log.CreatedAt),
+	}
+}
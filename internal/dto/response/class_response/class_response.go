@@ -1,6 +1,7 @@
 package classresponse
 
 import (
+	toothbrushresponse "giat-cerika-service/internal/dto/response/toothbrush_response"
 	"giat-cerika-service/internal/models"
 	"giat-cerika-service/pkg/utils"
 
@@ -26,3 +27,69 @@ func ToClassResponse(class models.Class) ClassResponse {
 		UpdatedAt: utils.FormatDate(class.UpdatedAt),
 	}
 }
+
+type ClassTeacherResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Name     *string   `json:"name"`
+	Username string    `json:"username"`
+}
+
+func ToClassTeacherResponse(teacher models.User) ClassTeacherResponse {
+	return ClassTeacherResponse{
+		ID:       teacher.ID,
+		Name:     teacher.Name,
+		Username: teacher.Username,
+	}
+}
+
+type ClassComplianceResponse struct {
+	ClassID       uuid.UUID                                       `json:"class_id"`
+	NameClass     string                                          `json:"name_class"`
+	Grade         string                                          `json:"grade"`
+	TotalStudents int                                             `json:"total_students"`
+	Morning       toothbrushresponse.ToothBrushComplianceResponse `json:"morning"`
+	Night         toothbrushresponse.ToothBrushComplianceResponse `json:"night"`
+	Overall       toothbrushresponse.ToothBrushComplianceResponse `json:"overall"`
+}
+
+type StudentComplianceResponse struct {
+	StudentID        uuid.UUID                                       `json:"student_id"`
+	Name             string                                          `json:"name"`
+	Nisn             string                                          `json:"nisn"`
+	Class            string                                          `json:"class"`
+	Morning          toothbrushresponse.ToothBrushComplianceResponse `json:"morning"`
+	Night            toothbrushresponse.ToothBrushComplianceResponse `json:"night"`
+	Overall          toothbrushresponse.ToothBrushComplianceResponse `json:"overall"`
+	LongestMissedRun int                                             `json:"longest_missed_run"`
+	CurrentMissedRun int                                             `json:"current_missed_run"`
+	LastLogDate      string                                          `json:"last_log_date"`
+}
+
+type MissedStudentResponse struct {
+	StudentID        uuid.UUID `json:"student_id"`
+	Name             string    `json:"name"`
+	Nisn             string    `json:"nisn"`
+	Class            string    `json:"class"`
+	LongestMissedRun int       `json:"longest_missed_run"`
+	CurrentMissedRun int       `json:"current_missed_run"`
+	LastLogDate      string    `json:"last_log_date"`
+}
+
+type DailyComplianceTrendResponse struct {
+	Date           string  `json:"date"`
+	ActiveStudents int     `json:"active_students"`
+	MorningCount   int     `json:"morning_count"`
+	NightCount     int     `json:"night_count"`
+	Rate           float64 `json:"rate"`
+}
+
+type ClassToothBrushComplianceResponse struct {
+	StartDate      string                         `json:"start_date"`
+	EndDate        string                         `json:"end_date"`
+	TotalDays      int                            `json:"total_days"`
+	MissedDays     int                            `json:"missed_days"`
+	Classes        []ClassComplianceResponse      `json:"classes"`
+	Students       []StudentComplianceResponse    `json:"students"`
+	MissedStudents []MissedStudentResponse        `json:"missed_students"`
+	DailyTrend     []DailyComplianceTrendResponse `json:"daily_trend"`
+}
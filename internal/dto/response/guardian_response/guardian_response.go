@@ -0,0 +1,71 @@
+package guardianresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type GuardianResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+type LinkedStudentResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Nisn  string    `json:"nisn"`
+	Age   int       `json:"age"`
+	Photo string    `json:"photo"`
+	Class string    `json:"class"`
+}
+
+// GuardianLinkCodeResponse adalah kode tautan sekali pakai yang dibagikan sekolah kepada orang tua/wali.
+type GuardianLinkCodeResponse struct {
+	StudentID uuid.UUID `json:"student_id"`
+	Name      *string   `json:"name"`
+	Nisn      string    `json:"nisn"`
+	Class     string    `json:"class"`
+	Code      string    `json:"code"`
+	ExpiresAt string    `json:"expires_at"`
+}
+
+func ToGuardianResponse(guardian models.User) GuardianResponse {
+	var name string
+	if guardian.Name != nil {
+		name = *guardian.Name
+	}
+
+	return GuardianResponse{
+		ID:        guardian.ID,
+		Name:      name,
+		Username:  guardian.Username,
+		Role:      guardian.Role.Name,
+		CreatedAt: utils.FormatDate(guardian.CreatedAt),
+		UpdatedAt: utils.FormatDate(guardian.UpdatedAt),
+	}
+}
+
+func ToLinkedStudentResponse(student models.User) LinkedStudentResponse {
+	var name, nisn string
+	if student.Name != nil {
+		name = *student.Name
+	}
+	if student.Nisn != nil {
+		nisn = *student.Nisn
+	}
+
+	return LinkedStudentResponse{
+		ID:    student.ID,
+		Name:  name,
+		Nisn:  nisn,
+		Age:   student.Age,
+		Photo: student.Photo,
+		Class: student.Class.NameClass,
+	}
+}
@@ -0,0 +1,39 @@
+package loginguardresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginLockoutResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Scope          string     `json:"scope"`
+	Username       string     `json:"username"`
+	UserID         *uuid.UUID `json:"user_id"`
+	IPAddress      string     `json:"ip_address"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    string     `json:"locked_until"`
+	Active         bool       `json:"active"`
+	UnlockedAt     string     `json:"unlocked_at"`
+	UnlockedBy     *uuid.UUID `json:"unlocked_by"`
+	CreatedAt      string     `json:"created_at"`
+}
+
+func ToLoginLockoutResponse(lockout models.LoginLockout, now time.Time) LoginLockoutResponse {
+	return LoginLockoutResponse{
+		ID:             lockout.ID,
+		Scope:          lockout.Scope,
+		Username:       lockout.Username,
+		UserID:         lockout.UserID,
+		IPAddress:      lockout.IPAddress,
+		FailedAttempts: lockout.FailedAttempts,
+		LockedUntil:    utils.FormatDateTime(&lockout.LockedUntil),
+		Active:         lockout.UnlockedAt == nil && lockout.LockedUntil.After(now),
+		UnlockedAt:     utils.FormatDateTime(lockout.UnlockedAt),
+		UnlockedBy:     lockout.UnlockedBy,
+		CreatedAt:      utils.FormatDateTime(&lockout.CreatedAt),
+	}
+}
@@ -0,0 +1,45 @@
+package permissionresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type PermissionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   string    `json:"updated_at"`
+}
+
+type RolePermissionResponse struct {
+	RoleID      uuid.UUID            `json:"role_id"`
+	RoleName    string               `json:"role_name"`
+	Permissions []PermissionResponse `json:"permissions"`
+}
+
+func ToPermissionResponse(permission models.Permission) PermissionResponse {
+	return PermissionResponse{
+		ID:          permission.ID,
+		Code:        permission.Code,
+		Description: permission.Description,
+		CreatedAt:   utils.FormatDate(permission.CreatedAt),
+		UpdatedAt:   utils.FormatDate(permission.UpdatedAt),
+	}
+}
+
+func ToRolePermissionResponse(role models.Role) RolePermissionResponse {
+	permissions := make([]PermissionResponse, len(role.Permissions))
+	for i, p := range role.Permissions {
+		permissions[i] = ToPermissionResponse(p)
+	}
+
+	return RolePermissionResponse{
+		RoleID:      role.ID,
+		RoleName:    role.Name,
+		Permissions: permissions,
+	}
+}
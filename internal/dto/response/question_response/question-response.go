@@ -12,6 +12,9 @@ type QuestionResponse struct {
 	Quiz          string    `json:"quiz"`
 	QuestionText  string    `json:"question_text"`
 	QuestionImage string    `json:"question_image"`
+	QuestionType  string    `json:"question_type"`
+	Tag           string    `json:"tag"`
+	Difficulty    string    `json:"difficulty"`
 	Answers       []any     `json:"answers"`
 	CratedAt      string    `json:"created_at"`
 	UpdatedAt     string    `json:"updated_at"`
@@ -24,6 +27,7 @@ func ToQuestionResponse(question models.Question) QuestionResponse {
 			"answer_id":   answer.ID,
 			"answer_text": answer.AnswerText,
 			"score_value": answer.ScoreValue,
+			"match_text":  answer.MatchText,
 		})
 	}
 	return QuestionResponse{
@@ -31,6 +35,9 @@ func ToQuestionResponse(question models.Question) QuestionResponse {
 		Quiz:          question.Quiz.Title,
 		QuestionText:  question.QuestionText,
 		QuestionImage: question.QuestionImage,
+		QuestionType:  string(question.QuestionType),
+		Tag:           question.Tag,
+		Difficulty:    string(question.Difficulty),
 		Answers:       ans,
 		CratedAt:      utils.FormatDate(question.CreatedAt),
 		UpdatedAt:     utils.FormatDate(question.UpdatedAt),
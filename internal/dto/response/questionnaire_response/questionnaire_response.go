@@ -0,0 +1,153 @@
+package questionnaireresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type QuestionnaireResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Type         string    `json:"type"`
+	Status       int       `json:"status"`
+	ActivePeriod string    `json:"active_period"`
+	ScaleMin     int       `json:"scale_min"`
+	ScaleMax     int       `json:"scale_max"`
+	AmountItems  int       `json:"amount_items"`
+	CreatedAt    string    `json:"created_at"`
+	UpdatedAt    string    `json:"updated_at"`
+}
+
+type QuestionnaireItemResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Statement  string    `json:"statement"`
+	ItemOrder  int       `json:"item_order"`
+	IsReversed bool      `json:"is_reversed"`
+}
+
+type QuestionnaireDetailResponse struct {
+	QuestionnaireResponse
+	Items []QuestionnaireItemResponse `json:"items"`
+}
+
+// StudentQuestionnaireItemResponse tidak menampilkan IsReversed agar siswa tidak tahu item mana yang dibalik skornya.
+type StudentQuestionnaireItemResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Statement string    `json:"statement"`
+	ItemOrder int       `json:"item_order"`
+}
+
+type AvailableQuestionnaireResponse struct {
+	ID           uuid.UUID                          `json:"id"`
+	Code         string                             `json:"code"`
+	Title        string                             `json:"title"`
+	Description  string                             `json:"description"`
+	Type         string                             `json:"type"`
+	ActivePeriod string                             `json:"active_period"`
+	ScaleMin     int                                `json:"scale_min"`
+	ScaleMax     int                                `json:"scale_max"`
+	AmountItems  int                                `json:"amount_items"`
+	HasResponded bool                               `json:"has_responded"`
+	Items        []StudentQuestionnaireItemResponse `json:"items,omitempty"`
+}
+
+type LikertCountResponse struct {
+	Value int `json:"value"`
+	Count int `json:"count"`
+}
+
+type ItemResultResponse struct {
+	ItemID       uuid.UUID             `json:"item_id"`
+	Statement    string                `json:"statement"`
+	IsReversed   bool                  `json:"is_reversed"`
+	Mean         float64               `json:"mean"`
+	Distribution []LikertCountResponse `json:"distribution"`
+}
+
+type ClassQuestionnaireResultResponse struct {
+	ClassID           *uuid.UUID           `json:"class_id"`
+	NameClass         string               `json:"name_class"`
+	Grade             string               `json:"grade"`
+	Respondents       int                  `json:"respondents"`
+	AverageScore      float64              `json:"average_score"`
+	AverageTotalScore float64              `json:"average_total_score"`
+	Items             []ItemResultResponse `json:"items"`
+}
+
+type QuestionnaireResultResponse struct {
+	QuestionnaireID  uuid.UUID                          `json:"questionnaire_id"`
+	Title            string                             `json:"title"`
+	Type             string                             `json:"type"`
+	Period           string                             `json:"period"`
+	ScaleMin         int                                `json:"scale_min"`
+	ScaleMax         int                                `json:"scale_max"`
+	TotalRespondents int                                `json:"total_respondents"`
+	AverageScore     float64                            `json:"average_score"`
+	Classes          []ClassQuestionnaireResultResponse `json:"classes"`
+}
+
+func ToQuestionnaireResponse(questionnaire models.Questionnaire) QuestionnaireResponse {
+	return QuestionnaireResponse{
+		ID:           questionnaire.ID,
+		Code:         questionnaire.Code,
+		Title:        questionnaire.Title,
+		Description:  questionnaire.Description,
+		Type:         string(questionnaire.Type),
+		Status:       questionnaire.Status,
+		ActivePeriod: questionnaire.ActivePeriod,
+		ScaleMin:     questionnaire.ScaleMin,
+		ScaleMax:     questionnaire.ScaleMax,
+		AmountItems:  questionnaire.AmountItems,
+		CreatedAt:    utils.FormatDate(questionnaire.CreatedAt),
+		UpdatedAt:    utils.FormatDate(questionnaire.UpdatedAt),
+	}
+}
+
+func ToQuestionnaireDetailResponse(questionnaire models.Questionnaire) QuestionnaireDetailResponse {
+	items := make([]QuestionnaireItemResponse, len(questionnaire.Items))
+	for i, item := range questionnaire.Items {
+		items[i] = QuestionnaireItemResponse{
+			ID:         item.ID,
+			Statement:  item.Statement,
+			ItemOrder:  item.ItemOrder,
+			IsReversed: item.IsReversed,
+		}
+	}
+
+	return QuestionnaireDetailResponse{
+		QuestionnaireResponse: ToQuestionnaireResponse(questionnaire),
+		Items:                 items,
+	}
+}
+
+func ToAvailableQuestionnaireResponse(questionnaire models.Questionnaire, hasResponded bool, withItems bool) AvailableQuestionnaireResponse {
+	resp := AvailableQuestionnaireResponse{
+		ID:           questionnaire.ID,
+		Code:         questionnaire.Code,
+		Title:        questionnaire.Title,
+		Description:  questionnaire.Description,
+		Type:         string(questionnaire.Type),
+		ActivePeriod: questionnaire.ActivePeriod,
+		ScaleMin:     questionnaire.ScaleMin,
+		ScaleMax:     questionnaire.ScaleMax,
+		AmountItems:  questionnaire.AmountItems,
+		HasResponded: hasResponded,
+	}
+
+	if withItems {
+		resp.Items = make([]StudentQuestionnaireItemResponse, len(questionnaire.Items))
+		for i, item := range questionnaire.Items {
+			resp.Items[i] = StudentQuestionnaireItemResponse{
+				ID:        item.ID,
+				Statement: item.Statement,
+				ItemOrder: item.ItemOrder,
+			}
+		}
+	}
+
+	return resp
+}
@@ -53,20 +53,50 @@ type QuestionHistory struct {
 	QuestionID      uuid.UUID `json:"question_id"`
 	QuestionText    string    `json:"question_text"`
 	QuestionImage   string    `json:"question_image"`
+	QuestionType    string    `json:"question_type"`
+	SubmittedText   string    `json:"submitted_text,omitempty"`
+	ScoreEarned     int       `json:"score_earned"`
 	AnswerHistories any       `json:"answer_histories"`
 	CreatedAt       string    `json:"created_at"`
 	UpdatedAt       string    `json:"updated_at"`
 }
 
-func ToQuestionHistory(qh models.QuestionHistory) QuestionHistory {
+// ToQuestionHistory memetakan riwayat soal; kunci jawaban (score_value, match_text,
+// daftar jawaban short answer) maupun detail per opsi (score_earned, selected, submitted_match)
+// hanya disertakan bila showAnswerKey. Tanpa itu hanya skor per soal yang terlihat.
+func ToQuestionHistory(qh models.QuestionHistory, showAnswerKey bool) QuestionHistory {
+	questionType := qh.QuestionType
+	if questionType == "" {
+		questionType = models.QuestionSingleChoice
+	}
+
+	// riwayat lama belum menyimpan skor per soal
+	scoreEarned := qh.ScoreEarned
+	if qh.MaxScore == 0 {
+		for _, ans := range qh.AnswerHistory {
+			scoreEarned += ans.ScoreEarned
+		}
+	}
+
 	ansHistories := []any{}
 	for _, ans := range qh.AnswerHistory {
-		ansHistories = append(ansHistories, map[string]any{
-			"answer_id":    ans.AnswerID,
-			"answer_text":  ans.AnswerText,
-			"score_value":  ans.ScoreValue,
-			"score_earned": ans.ScoreEarned,
-		})
+		if questionType == models.QuestionShortAnswer && !showAnswerKey {
+			break
+		}
+		item := map[string]any{
+			"answer_id":   ans.AnswerID,
+			"answer_text": ans.AnswerText,
+		}
+		if showAnswerKey {
+			item["score_earned"] = ans.ScoreEarned
+			item["selected"] = ans.Selected
+			item["score_value"] = ans.ScoreValue
+			if questionType == models.QuestionMatching {
+				item["submitted_match"] = ans.SubmittedMatch
+				item["match_text"] = ans.MatchText
+			}
+		}
+		ansHistories = append(ansHistories, item)
 	}
 	return QuestionHistory{
 		ID:              qh.ID,
@@ -74,6 +104,9 @@ func ToQuestionHistory(qh models.QuestionHistory) QuestionHistory {
 		QuestionID:      qh.QuestionID,
 		QuestionText:    qh.QuestionText,
 		QuestionImage:   qh.QuestionImage,
+		QuestionType:    string(questionType),
+		SubmittedText:   qh.SubmittedText,
+		ScoreEarned:     scoreEarned,
 		AnswerHistories: ansHistories,
 		CreatedAt:       utils.FormatDate(qh.CreatedAt),
 		UpdatedAt:       utils.FormatDate(qh.UpdatedAt),
@@ -102,3 +135,39 @@ type QuizHistoryDetailAdminResponse struct {
 	CompletedAt    string    `json:"completed_at"`
 	CreatedAt      string    `json:"created_at"`
 }
+
+type OptionAnalysisResponse struct {
+	AnswerID   uuid.UUID `json:"answer_id"`
+	AnswerText string    `json:"answer_text"`
+	IsKey      bool      `json:"is_key"`
+	Selected   int       `json:"selected"`
+	Percentage float64   `json:"percentage"`
+	Upper      int       `json:"upper"`
+	Lower      int       `json:"lower"`
+}
+
+type ItemAnalysisResponse struct {
+	QuestionID     uuid.UUID                `json:"question_id"`
+	QuestionText   string                   `json:"question_text"`
+	Respondents    int                      `json:"respondents"`
+	Correct        int                      `json:"correct"`
+	Omitted        int                      `json:"omitted"`
+	Difficulty     float64                  `json:"difficulty"`
+	Discrimination *float64                 `json:"discrimination"`
+	Flags          []string                 `json:"flags"`
+	Options        []OptionAnalysisResponse `json:"options"`
+}
+
+type QuizItemAnalysisResponse struct {
+	QuizID       uuid.UUID              `json:"quiz_id"`
+	Title        string                 `json:"title"`
+	Participants int                    `json:"participants"`
+	GroupSize    int                    `json:"group_size"`
+	Items        []ItemAnalysisResponse `json:"items"`
+}
+
+// QuizHistoryExport adalah tabel siap tulis ke CSV/XLSX.
+type QuizHistoryExport struct {
+	Headers []string
+	Rows    [][]string
+}
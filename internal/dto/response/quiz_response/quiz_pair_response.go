@@ -0,0 +1,86 @@
+package quizresponse
+
+import (
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type QuizPairQuizResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Code  string    `json:"code"`
+	Title string    `json:"title"`
+}
+
+type QuizPairResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	PreQuiz     QuizPairQuizResponse `json:"pre_quiz"`
+	PostQuiz    QuizPairQuizResponse `json:"post_quiz"`
+	CreatedAt   string               `json:"created_at"`
+	UpdatedAt   string               `json:"updated_at"`
+}
+
+type GainDistributionResponse struct {
+	High      int `json:"high"`
+	Medium    int `json:"medium"`
+	Low       int `json:"low"`
+	Undefined int `json:"undefined"`
+}
+
+type StudentGainResponse struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Name           string    `json:"name"`
+	Nisn           string    `json:"nisn"`
+	NameClass      string    `json:"name_class"`
+	PrePercentage  float64   `json:"pre_percentage"`
+	PostPercentage float64   `json:"post_percentage"`
+	Gain           *float64  `json:"gain"`
+	Category       string    `json:"category"`
+}
+
+type ClassGainResponse struct {
+	ClassID             *uuid.UUID               `json:"class_id"`
+	NameClass           string                   `json:"name_class"`
+	Grade               string                   `json:"grade"`
+	Students            int                      `json:"students"`
+	AveragePre          float64                  `json:"average_pre"`
+	AveragePost         float64                  `json:"average_post"`
+	AverageGain         float64                  `json:"average_gain"`
+	ClassNormalizedGain *float64                 `json:"class_normalized_gain"`
+	Distribution        GainDistributionResponse `json:"distribution"`
+}
+
+type QuizPairGainResponse struct {
+	Pair         QuizPairResponse         `json:"pair"`
+	TotalPaired  int                      `json:"total_paired"`
+	OnlyPre      int                      `json:"only_pre"`
+	OnlyPost     int                      `json:"only_post"`
+	AveragePre   float64                  `json:"average_pre"`
+	AveragePost  float64                  `json:"average_post"`
+	AverageGain  float64                  `json:"average_gain"`
+	Distribution GainDistributionResponse `json:"distribution"`
+	Classes      []ClassGainResponse      `json:"classes"`
+	Students     []StudentGainResponse    `json:"students"`
+}
+
+func ToQuizPairResponse(pair models.QuizPair) QuizPairResponse {
+	return QuizPairResponse{
+		ID:          pair.ID,
+		Title:       pair.Title,
+		Description: pair.Description,
+		PreQuiz: QuizPairQuizResponse{
+			ID:    pair.PreQuiz.ID,
+			Code:  pair.PreQuiz.Code,
+			Title: pair.PreQuiz.Title,
+		},
+		PostQuiz: QuizPairQuizResponse{
+			ID:    pair.PostQuiz.ID,
+			Code:  pair.PostQuiz.Code,
+			Title: pair.PostQuiz.Title,
+		},
+		CreatedAt: pair.CreatedAt.Format("01-02-2006 15:04:05"),
+		UpdatedAt: pair.UpdatedAt.Format("01-02-2006 15:04:05"),
+	}
+}
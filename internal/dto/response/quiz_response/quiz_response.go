@@ -18,24 +18,37 @@ type QuizResponse struct {
 	AmountQuestions   int       `json:"amount_questions"`
 	AmountAssigned    int       `json:"amount_assigned"`
 	QuestionOrderMode string    `json:"question_order_mode"`
-	CreatedAt         string    `json:"created_at"`
-	UpdatedAt         string    `json:"updated_at"`
+	// CorrectAnswerVisibility: after_submit, after_close atau never.
+	CorrectAnswerVisibility string `json:"correct_answer_visibility"`
+	// DrawCount 0 berarti semua soal; DrawStratifyBy: kosong, tag atau difficulty.
+	DrawCount      int    `json:"draw_count"`
+	DrawStratifyBy string `json:"draw_stratify_by"`
+	ShuffleAnswers bool   `json:"shuffle_answers"`
+	// DurationMinutes 0 berarti waktu mengikuti end_date.
+	DurationMinutes int    `json:"duration_minutes"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 func ToQuizResponse(quiz models.Quiz) QuizResponse {
 	return QuizResponse{
-		ID:                quiz.ID,
-		QuizType:          quiz.QuizType.Name,
-		Code:              quiz.Code,
-		Title:             quiz.Title,
-		Description:       quiz.Description,
-		StartDate:         quiz.StartDate.Format("01-02-2006 15:04:05"),
-		EndDate:           quiz.EndDate.Format("01-02-2006 15:04:05"),
-		Status:            quiz.Status,
-		AmountQuestions:   quiz.AmountQuestions,
-		AmountAssigned:    quiz.AmountAssigned,
-		QuestionOrderMode: string(quiz.QuestionOrderMode),
-		CreatedAt:         quiz.CreatedAt.Format("01-02-2006 15:04:05"),
-		UpdatedAt:         quiz.UpdatedAt.Format("01-02-2006 15:04:05"),
+		ID:                      quiz.ID,
+		QuizType:                quiz.QuizType.Name,
+		Code:                    quiz.Code,
+		Title:                   quiz.Title,
+		Description:             quiz.Description,
+		StartDate:               quiz.StartDate.Format("01-02-2006 15:04:05"),
+		EndDate:                 quiz.EndDate.Format("01-02-2006 15:04:05"),
+		Status:                  quiz.Status,
+		AmountQuestions:         quiz.AmountQuestions,
+		AmountAssigned:          quiz.AmountAssigned,
+		QuestionOrderMode:       string(quiz.QuestionOrderMode),
+		CorrectAnswerVisibility: string(quiz.CorrectAnswerVisibility),
+		DrawCount:               quiz.DrawCount,
+		DrawStratifyBy:          string(quiz.DrawStratifyBy),
+		ShuffleAnswers:          quiz.ShuffleAnswers,
+		DurationMinutes:         quiz.DurationMinutes,
+		CreatedAt:               quiz.CreatedAt.Format("01-02-2006 15:04:05"),
+		UpdatedAt:               quiz.UpdatedAt.Format("01-02-2006 15:04:05"),
 	}
 }
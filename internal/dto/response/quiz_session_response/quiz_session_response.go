@@ -3,6 +3,7 @@ package quizsessionresponse
 import (
 	"giat-cerika-service/internal/models"
 	"giat-cerika-service/pkg/utils"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,22 +23,69 @@ type QuizSessionDurationResponse struct {
 	IsUnlimited      bool  `json:"is_unlimited"`      // True jika tidak ada batas waktu
 }
 
-type AnswerResponse struct {
+// QuizSessionSubmitResponse dikembalikan sama persis untuk submit pertama maupun retry.
+// Late berarti submit tiba setelah deadline: percobaan tetap tercatat selesai tetapi jawabannya tidak dinilai.
+type QuizSessionSubmitResponse struct {
+	QuizSessionID uuid.UUID `json:"quiz_session_id"`
+	Late          bool      `json:"late"`
+	Scored        bool      `json:"scored"`
+}
+
+// StudentAnswerResponse adalah opsi jawaban untuk siswa yang sedang mengerjakan quiz.
+// Sengaja tanpa score_value: kunci jawaban hanya ada di server.
+type StudentAnswerResponse struct {
 	ID         uuid.UUID `json:"id"`
 	AnswerText string    `json:"answer_text"`
-	ScoreValue int       `json:"score_value"`
 }
 
-type QuestionDetailResponse struct {
-	ID            uuid.UUID        `json:"id"`
-	QuestionText  string           `json:"question_text"`
-	QuestionImage *string          `json:"question_image"`
-	Answers       []AnswerResponse `json:"answers"`
+// StudentQuestionResponse: untuk short answer, answers dikosongkan (daftar jawaban
+// yang diterima adalah kunci); untuk matching, answers berisi sisi kiri dan
+// match_options berisi pilihan pasangan yang diurutkan alfabetis.
+type StudentQuestionResponse struct {
+	ID            uuid.UUID               `json:"id"`
+	QuestionText  string                  `json:"question_text"`
+	QuestionImage *string                 `json:"question_image"`
+	QuestionType  models.QuestionType     `json:"question_type"`
+	Answers       []StudentAnswerResponse `json:"answers"`
+	MatchOptions  []string                `json:"match_options,omitempty"`
 }
 
 type OrderedQuizQuestionsResponse struct {
-	QuizID    uuid.UUID                `json:"quiz_id"`
-	Questions []QuestionDetailResponse `json:"questions"`
+	QuizID    uuid.UUID                 `json:"quiz_id"`
+	Questions []StudentQuestionResponse `json:"questions"`
+}
+
+func ToStudentQuestionResponse(question models.Question) StudentQuestionResponse {
+	questionType := question.QuestionType
+	if questionType == "" {
+		questionType = models.QuestionSingleChoice
+	}
+
+	answers := make([]StudentAnswerResponse, 0, len(question.Answers))
+	var matchOptions []string
+	if questionType != models.QuestionShortAnswer {
+		seenMatch := make(map[string]bool)
+		for _, answer := range question.Answers {
+			answers = append(answers, StudentAnswerResponse{
+				ID:         answer.ID,
+				AnswerText: answer.AnswerText,
+			})
+			if questionType == models.QuestionMatching && !seenMatch[answer.MatchText] {
+				seenMatch[answer.MatchText] = true
+				matchOptions = append(matchOptions, answer.MatchText)
+			}
+		}
+		sort.Strings(matchOptions)
+	}
+
+	return StudentQuestionResponse{
+		ID:            question.ID,
+		QuestionText:  question.QuestionText,
+		QuestionImage: &question.QuestionImage,
+		QuestionType:  questionType,
+		Answers:       answers,
+		MatchOptions:  matchOptions,
+	}
 }
 
 type DetailQuizSession struct {
@@ -0,0 +1,41 @@
+package sessionresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type TokenResponse struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresIn    int       `json:"expires_in"`
+	SessionID    uuid.UUID `json:"session_id"`
+	// MustChangePassword berarti belum ada sesi; password harus diganti dulu memakai ResetToken.
+	MustChangePassword bool   `json:"must_change_password,omitempty"`
+	ResetToken         string `json:"reset_token,omitempty"`
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastSeenAt string    `json:"last_seen_at"`
+	ExpiresAt  string    `json:"expires_at"`
+	CreatedAt  string    `json:"created_at"`
+	Current    bool      `json:"current"`
+}
+
+func ToSessionResponse(session models.UserSession, currentSessionId string) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		LastSeenAt: utils.FormatDateTime(&session.LastSeenAt),
+		ExpiresAt:  utils.FormatDateTime(&session.ExpiresAt),
+		CreatedAt:  utils.FormatDateTime(&session.CreatedAt),
+		Current:    session.ID.String() == currentSessionId,
+	}
+}
@@ -31,6 +31,46 @@ type AllStudentResponse struct {
 	UpdatedAt   string    `json:"updated_at"`
 }
 
+// StudentImportRowResult adalah hasil validasi/pembuatan akun untuk satu baris file impor.
+type StudentImportRowResult struct {
+	Row             int      `json:"row"`
+	Name            string   `json:"name"`
+	Nisn            string   `json:"nisn"`
+	DateOfBirth     string   `json:"date_of_birth"`
+	Class           string   `json:"class"`
+	Username        string   `json:"username,omitempty"`
+	InitialPassword string   `json:"initial_password,omitempty"`
+	Valid           bool     `json:"valid"`
+	Errors          []string `json:"errors"`
+}
+
+type StudentImportResponse struct {
+	DryRun      bool                     `json:"dry_run"`
+	TotalRows   int                      `json:"total_rows"`
+	ValidRows   int                      `json:"valid_rows"`
+	InvalidRows int                      `json:"invalid_rows"`
+	Created     int                      `json:"created"`
+	Rows        []StudentImportRowResult `json:"rows"`
+}
+
+// PasswordResetTokenResponse dipakai sekali pada PUT /update-new-password.
+type PasswordResetTokenResponse struct {
+	ResetToken string  `json:"reset_token"`
+	ExpiresIn  int     `json:"expires_in"`
+	Name       *string `json:"name"`
+}
+
+// StudentResetCodeResponse adalah data kode reset sementara yang dicetak admin untuk siswa.
+type StudentResetCodeResponse struct {
+	StudentID uuid.UUID `json:"student_id"`
+	Name      *string   `json:"name"`
+	Nisn      string    `json:"nisn"`
+	Username  string    `json:"username"`
+	Class     string    `json:"class"`
+	Code      string    `json:"code"`
+	ExpiresAt string    `json:"expires_at"`
+}
+
 func ToStudentResponse(student models.User) StudentResponse {
 	return StudentResponse{
 		ID:          student.ID,
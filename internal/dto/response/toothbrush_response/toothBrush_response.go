@@ -8,21 +8,77 @@ import (
 )
 
 type ToothBrushResponse struct {
-	ID        uuid.UUID `json:"id"`
-	User      string    `json:"user"`
-	TimeType  string    `json:"time_type"`
-	LogDate   string    `json:"log_date"`
-	LogTime   string    `json:"log_time"`
-	CreatedAt string    `json:"created_at"`
+	ID           uuid.UUID `json:"id"`
+	User         string    `json:"user"`
+	TimeType     string    `json:"time_type"`
+	LogDate      string    `json:"log_date"`
+	LogTime      string    `json:"log_time"`
+	IsLate       bool      `json:"is_late"`
+	PhotoURL     *string   `json:"photo_url"`
+	ReviewStatus string    `json:"review_status"`
+	ReviewNote   *string   `json:"review_note"`
+	ReviewedAt   string    `json:"reviewed_at"`
+	CreatedAt    string    `json:"created_at"`
 }
 
 func ToToothBrushResponse(toothBrush models.ToootBrushLog) ToothBrushResponse {
 	return ToothBrushResponse{
-		ID:        toothBrush.ID,
-		User:      *toothBrush.User.Name,
-		TimeType:  toothBrush.TimeType,
-		LogDate:   utils.FormatLogDate(toothBrush.LogDate),
-		LogTime:   utils.FormatTime(toothBrush.LogTime),
-		CreatedAt: utils.FormatDate(toothBrush.CreatedAt),
+		ID:           toothBrush.ID,
+		User:         *toothBrush.User.Name,
+		TimeType:     toothBrush.TimeType,
+		LogDate:      utils.FormatLogDate(toothBrush.LogDate),
+		LogTime:      utils.FormatTime(toothBrush.LogTime),
+		IsLate:       toothBrush.IsLate,
+		PhotoURL:     toothBrush.PhotoURL,
+		ReviewStatus: toothBrush.ReviewStatus,
+		ReviewNote:   toothBrush.ReviewNote,
+		ReviewedAt:   utils.FormatDateTime(toothBrush.ReviewedAt),
+		CreatedAt:    utils.FormatDate(toothBrush.CreatedAt),
 	}
 }
+
+type ToothBrushComplianceResponse struct {
+	Logged   int     `json:"logged"`
+	Expected int     `json:"expected"`
+	Rate     float64 `json:"rate"`
+}
+
+// ToothBrushSessionComplianceResponse adalah kepatuhan untuk satu jenis sesi aktif.
+type ToothBrushSessionComplianceResponse struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Logged   int     `json:"logged"`
+	Expected int     `json:"expected"`
+	Rate     float64 `json:"rate"`
+}
+
+// ToothBrushCalendarDayResponse: Sessions berisi status per kode sesi aktif; Complete berarti
+// semua sesi aktif tercatat. Morning & Night dipertahankan untuk klien lama.
+type ToothBrushCalendarDayResponse struct {
+	Date     string          `json:"date"`
+	Day      int             `json:"day"`
+	Sessions map[string]bool `json:"sessions"`
+	Morning  bool            `json:"morning"`
+	Night    bool            `json:"night"`
+	Complete bool            `json:"complete"`
+}
+
+type ToothBrushCalendarResponse struct {
+	Month string `json:"month"`
+	// Weeks berisi baris minggu (Senin s/d Minggu); null untuk tanggal di luar bulan.
+	Weeks [][]*ToothBrushCalendarDayResponse `json:"weeks"`
+}
+
+// ToothBrushProgressResponse: Sessions dihitung dari daftar sesi aktif; Morning & Night dipertahankan untuk klien lama.
+type ToothBrushProgressResponse struct {
+	StartDate     string                                `json:"start_date"`
+	EndDate       string                                `json:"end_date"`
+	TotalDays     int                                   `json:"total_days"`
+	CurrentStreak int                                   `json:"current_streak"`
+	LongestStreak int                                   `json:"longest_streak"`
+	Sessions      []ToothBrushSessionComplianceResponse `json:"sessions"`
+	Morning       ToothBrushComplianceResponse          `json:"morning"`
+	Night         ToothBrushComplianceResponse          `json:"night"`
+	Overall       ToothBrushComplianceResponse          `json:"overall"`
+	Calendar      ToothBrushCalendarResponse            `json:"calendar"`
+}
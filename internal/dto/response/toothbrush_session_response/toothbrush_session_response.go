@@ -0,0 +1,55 @@
+package toothbrushsessionresponse
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type ClassOverrideResponse struct {
+	ClassID      uuid.UUID `json:"class_id"`
+	NameClass    string    `json:"name_class"`
+	StartTime    string    `json:"start_time"`
+	EndTime      string    `json:"end_time"`
+	GraceMinutes *int      `json:"grace_minutes"`
+}
+
+type ToothBrushSessionResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	Code           string                  `json:"code"`
+	Name           string                  `json:"name"`
+	StartTime      string                  `json:"start_time"`
+	EndTime        string                  `json:"end_time"`
+	GraceMinutes   int                     `json:"grace_minutes"`
+	IsActive       bool                    `json:"is_active"`
+	ClassOverrides []ClassOverrideResponse `json:"class_overrides"`
+	CreatedAt      string                  `json:"created_at"`
+	UpdatedAt      string                  `json:"updated_at"`
+}
+
+func ToToothBrushSessionResponse(session models.ToothBrushSession) ToothBrushSessionResponse {
+	overrides := make([]ClassOverrideResponse, len(session.ClassOverrides))
+	for i, o := range session.ClassOverrides {
+		overrides[i] = ClassOverrideResponse{
+			ClassID:      o.ClassID,
+			NameClass:    o.Class.NameClass,
+			StartTime:    o.StartTime,
+			EndTime:      o.EndTime,
+			GraceMinutes: o.GraceMinutes,
+		}
+	}
+
+	return ToothBrushSessionResponse{
+		ID:             session.ID,
+		Code:           session.Code,
+		Name:           session.Name,
+		StartTime:      session.StartTime,
+		EndTime:        session.EndTime,
+		GraceMinutes:   session.GraceMinutes,
+		IsActive:       session.IsActive,
+		ClassOverrides: overrides,
+		CreatedAt:      utils.FormatDate(session.CreatedAt),
+		UpdatedAt:      utils.FormatDate(session.UpdatedAt),
+	}
+}
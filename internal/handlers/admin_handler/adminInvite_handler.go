@@ -0,0 +1,81 @@
+package adminhandler
+
+import (
+	adminrequest "giat-cerika-service/internal/dto/request/admin_request"
+	adminresponse "giat-cerika-service/internal/dto/response/admin_response"
+	adminservice "giat-cerika-service/internal/services/admin_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AdminInviteHandler struct {
+	inviteService adminservice.IAdminInviteService
+}
+
+func NewAdminInviteHandler(service adminservice.IAdminInviteService) *AdminInviteHandler {
+	return &AdminInviteHandler{inviteService: service}
+}
+
+func (a *AdminInviteHandler) CreateInvite(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	var req adminrequest.CreateAdminInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	invite, err := a.inviteService.CreateInvite(c.Request().Context(), uuid.MustParse(claims.UserID), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to create invite")
+	}
+
+	return response.Success(c, http.StatusCreated, "Invite Created Successfully", invite)
+}
+
+func (a *AdminInviteHandler) GetAllInvites(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	status := c.QueryParam("status")
+
+	invites, total, err := a.inviteService.GetAllInvites(c.Request().Context(), status, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get invites")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]adminresponse.AdminInviteResponse, len(invites))
+	for i, invite := range invites {
+		data[i] = adminresponse.ToAdminInviteResponse(*invite)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All Invites Successfully", data, meta)
+}
+
+func (a *AdminInviteHandler) RevokeInvite(c echo.Context) error {
+	inviteId, err := uuid.Parse(c.Param("inviteId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid invite id", err.Error())
+	}
+
+	if err := a.inviteService.RevokeInvite(c.Request().Context(), inviteId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to revoke invite")
+	}
+
+	return response.Success(c, http.StatusOK, "Invite Revoked Successfully", nil)
+}
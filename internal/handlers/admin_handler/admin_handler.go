@@ -8,7 +8,6 @@ import (
 	"giat-cerika-service/pkg/constant/response"
 	"giat-cerika-service/pkg/utils"
 	"net/http"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -26,6 +25,7 @@ func (a *AdminHandler) RegisterAdmin(c echo.Context) error {
 	var req adminrequest.RegisterAdminRequest
 	req.Username = c.FormValue("username")
 	req.Password = c.FormValue("password")
+	req.InviteToken = c.FormValue("invite_token")
 	if photo, err := c.FormFile("photo"); err == nil {
 		req.Photo = photo
 	}
@@ -47,8 +47,10 @@ func (a *AdminHandler) LoginAdmin(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
 
-	token, err := a.adminService.Login(c.Request().Context(), req)
+	tokens, err := a.adminService.Login(c.Request().Context(), req)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
@@ -56,9 +58,7 @@ func (a *AdminHandler) LoginAdmin(c echo.Context) error {
 		return response.Error(c, http.StatusInternalServerError, err.Error(), "invalid login admin")
 	}
 
-	return response.Success(c, http.StatusOK, "Login Successfully", map[string]interface{}{
-		"access_token": token,
-	})
+	return response.Success(c, http.StatusOK, "Login Successfully", tokens)
 
 }
 
@@ -69,10 +69,8 @@ func (a *AdminHandler) GetProfileAdmin(c echo.Context) error {
 	}
 
 	adminID := claims.UserID
-	authHeader := c.Request().Header.Get("Authorization")
-	token := strings.TrimPrefix(authHeader, "Bearer ")
 
-	me, err := a.adminService.GetProfile(c.Request().Context(), uuid.MustParse(adminID), token)
+	me, err := a.adminService.GetProfile(c.Request().Context(), uuid.MustParse(adminID))
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
@@ -91,29 +89,12 @@ func (a *AdminHandler) LogoutAdmin(c echo.Context) error {
 	}
 
 	adminID := claims.UserID
-	authHeader := c.Request().Header.Get("Authorization")
-	token := strings.TrimPrefix(authHeader, "Bearer ")
 
-	blackList, err := a.adminService.CheckTokenBlacklisted(c.Request().Context(), token)
-	if err != nil {
-		if customErr, ok := errorresponse.AsCustomErr(err); ok {
-			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
-		}
-		return response.Error(c, http.StatusInternalServerError, err.Error(), "invalid to get blacklisted")
-	}
-	if blackList {
-		return response.Error(c, http.StatusUnauthorized, "You're logged out", nil)
-	}
-
-	if token == "" {
-		return response.Error(c, http.StatusUnauthorized, "Token is empty", nil)
-	}
-
-	if err := a.adminService.Logout(c.Request().Context(), uuid.MustParse(adminID), token); err != nil {
+	if err := a.adminService.Logout(c.Request().Context(), uuid.MustParse(adminID), claims); err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
 		}
-		return response.Error(c, http.StatusInternalServerError, err.Error(), "invalid to blacklist token")
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "invalid to logout")
 	}
 
 	return response.Success(c, http.StatusOK, "Logout Successfully", nil)
@@ -0,0 +1,86 @@
+package alarmhandler
+
+import (
+	alarmrequest "giat-cerika-service/internal/dto/request/alarm_request"
+	alarmresponse "giat-cerika-service/internal/dto/response/alarm_response"
+	alarmservice "giat-cerika-service/internal/services/alarm_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AlarmHandler struct {
+	alarmService alarmservice.IAlarmService
+}
+
+func NewAlarmHandler(service alarmservice.IAlarmService) *AlarmHandler {
+	return &AlarmHandler{alarmService: service}
+}
+
+func (a *AlarmHandler) CreateAlarm(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+	studentId := claims.UserID
+
+	var req alarmrequest.CreateAlarmRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = a.alarmService.CreateAlarm(c.Request().Context(), uuid.MustParse(studentId), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to create alarm")
+	}
+
+	return response.Success(c, http.StatusCreated, "Alarm Created Successfully", nil)
+}
+
+func (a *AlarmHandler) GetMyAlarm(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+	studentId := claims.UserID
+
+	alarm, err := a.alarmService.GetMyAlarm(c.Request().Context(), uuid.MustParse(studentId))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get alarm")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Alarm Successfully", alarmresponse.ToAlarmResponse(*alarm))
+}
+
+func (a *AlarmHandler) UpdateAlarm(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+	studentId := claims.UserID
+
+	var req alarmrequest.UpdateAlarmRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = a.alarmService.UpdateAlarm(c.Request().Context(), uuid.MustParse(studentId), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update alarm")
+	}
+
+	return response.Success(c, http.StatusOK, "Alarm Updated Successfully", nil)
+}
@@ -0,0 +1,81 @@
+package apikeyhandler
+
+import (
+	apikeyrequest "giat-cerika-service/internal/dto/request/api_key_request"
+	apikeyresponse "giat-cerika-service/internal/dto/response/api_key_response"
+	apikeyservice "giat-cerika-service/internal/services/api_key_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	apiKeyService apikeyservice.IAPIKeyService
+}
+
+func NewAPIKeyHandler(service apikeyservice.IAPIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: service}
+}
+
+func (a *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	var req apikeyrequest.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	key, err := a.apiKeyService.CreateAPIKey(c.Request().Context(), uuid.MustParse(claims.UserID), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to create api key")
+	}
+
+	return response.Success(c, http.StatusCreated, "API Key Created Successfully", key)
+}
+
+func (a *APIKeyHandler) GetAllAPIKeys(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	status := c.QueryParam("status")
+
+	keys, total, err := a.apiKeyService.GetAllAPIKeys(c.Request().Context(), status, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get api keys")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]apikeyresponse.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		data[i] = apikeyresponse.ToAPIKeyResponse(*key)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All API Keys Successfully", data, meta)
+}
+
+func (a *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	keyId, err := uuid.Parse(c.Param("apiKeyId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid api key id", err.Error())
+	}
+
+	if err := a.apiKeyService.RevokeAPIKey(c.Request().Context(), keyId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to revoke api key")
+	}
+
+	return response.Success(c, http.StatusOK, "API Key Revoked Successfully", nil)
+}
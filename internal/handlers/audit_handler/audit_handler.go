@@ -0,0 +1,75 @@
+package audithandler
+
+import (
+	auditrequest "giat-cerika-service/internal/dto/request/audit_request"
+	auditresponse "giat-cerika-service/internal/dto/response/audit_response"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditService auditservice.IAuditService
+}
+
+func NewAuditHandler(service auditservice.IAuditService) *AuditHandler {
+	return &AuditHandler{auditService: service}
+}
+
+// SearchAuditLog mencari audit log.
+// Query: actor_id, action, entity_type, entity_id, start_date & end_date (YYYY-MM-DD, inklusif).
+func (a *AuditHandler) SearchAuditLog(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 20)
+
+	req := auditrequest.SearchAuditLogRequest{
+		Action:     c.QueryParam("action"),
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   c.QueryParam("entity_id"),
+	}
+	if v := c.QueryParam("actor_id"); v != "" {
+		actorId, err := uuid.Parse(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid actor id", err.Error())
+		}
+		req.ActorID = &actorId
+	}
+
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	if v := c.QueryParam("start_date"); v != "" {
+		start, err := time.ParseInLocation("2006-01-02", v, locJakarta)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid start_date format, use YYYY-MM-DD", err.Error())
+		}
+		req.StartDate = &start
+	}
+	if v := c.QueryParam("end_date"); v != "" {
+		end, err := time.ParseInLocation("2006-01-02", v, locJakarta)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid end_date format, use YYYY-MM-DD", err.Error())
+		}
+		end = end.AddDate(0, 0, 1)
+		req.EndDate = &end
+	}
+
+	logs, total, err := a.auditService.Search(c.Request().Context(), req, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get audit logs")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]auditresponse.AuditLogResponse, len(logs))
+	for i, log := range logs {
+		data[i] = auditresponse.ToAuditLogResponse(*log)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get Audit Logs Successfully", data, meta)
+}
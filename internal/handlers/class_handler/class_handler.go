@@ -8,6 +8,9 @@ import (
 	"giat-cerika-service/pkg/constant/response"
 	"giat-cerika-service/pkg/utils"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -100,6 +103,49 @@ func (ch *ClassHandler) UpdateClass(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "Class Updated Successfully", nil)
 }
 
+func (ch *ClassHandler) GetClassTeachers(c echo.Context) error {
+	classId, err := uuid.Parse(c.Param("classId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	teachers, err := ch.classService.GetClassTeachers(c.Request().Context(), classId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get class teachers")
+	}
+
+	data := make([]classresponse.ClassTeacherResponse, len(teachers))
+	for i, teacher := range teachers {
+		data[i] = classresponse.ToClassTeacherResponse(*teacher)
+	}
+
+	return response.Success(c, http.StatusOK, "Get Class Teachers Successfully", data)
+}
+
+func (ch *ClassHandler) UpdateClassTeachers(c echo.Context) error {
+	classId, err := uuid.Parse(c.Param("classId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req classrequest.UpdateClassTeachersRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := ch.classService.UpdateClassTeachers(c.Request().Context(), classId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update class teachers")
+	}
+
+	return response.Success(c, http.StatusOK, "Class Teachers Updated Successfully", nil)
+}
+
 func (ch *ClassHandler) DeleteClass(c echo.Context) error {
 	classId, err := uuid.Parse(c.Param("classId"))
 	if err != nil {
@@ -131,3 +177,58 @@ func (ch *ClassHandler) GetAllPublic(c echo.Context) error {
 	}
 	return response.Success(c, http.StatusOK, "Get All Public Classes Successfully", data)
 }
+
+// parseDateParam menerima tanggal dengan format DD-MM-YYYY atau YYYY-MM-DD.
+func parseDateParam(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse("02-01-2006", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func (ch *ClassHandler) GetToothBrushCompliance(c echo.Context) error {
+	var classId *uuid.UUID
+	if v := c.QueryParam("class_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+		}
+		classId = &id
+	}
+
+	var startDate, endDate time.Time
+	if v := c.QueryParam("start_date"); v != "" {
+		t, err := parseDateParam(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid start date format", err.Error())
+		}
+		startDate = t
+	}
+	if v := c.QueryParam("end_date"); v != "" {
+		t, err := parseDateParam(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid end date format", err.Error())
+		}
+		endDate = t
+	}
+
+	missedDays := 0
+	if v := c.QueryParam("missed_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid missed days", err.Error())
+		}
+		missedDays = n
+	}
+
+	data, err := ch.classService.GetToothBrushCompliance(c.Request().Context(), classId, startDate, endDate, missedDays)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get tooth brush compliance")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Tooth Brush Compliance Successfully", data)
+}
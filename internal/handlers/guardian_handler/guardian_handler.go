@@ -0,0 +1,288 @@
+package guardianhandler
+
+import (
+	guardianrequest "giat-cerika-service/internal/dto/request/guardian_request"
+	guardianresponse "giat-cerika-service/internal/dto/response/guardian_response"
+	predictionresponse "giat-cerika-service/internal/dto/response/prediction_response"
+	toothbrushresponse "giat-cerika-service/internal/dto/response/toothbrush_response"
+	guardianservice "giat-cerika-service/internal/services/guardian_service"
+	predictionservice "giat-cerika-service/internal/services/prediction_service"
+	quizhistoryservice "giat-cerika-service/internal/services/quiz_history_service"
+	studentservice "giat-cerika-service/internal/services/student_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GuardianHandler melayani akun orang tua/wali. Data anak hanya bisa dibaca (read-only)
+// dan selalu melalui pengecekan link wali–siswa terlebih dahulu.
+type GuardianHandler struct {
+	guardianService    guardianservice.IGuardianService
+	studentService     studentservice.IStudentService
+	quizHistoryService quizhistoryservice.IQuizHistoryService
+	predictionService  predictionservice.IPredictionService
+}
+
+func NewGuardianHandler(guardianService guardianservice.IGuardianService, studentService studentservice.IStudentService, quizHistoryService quizhistoryservice.IQuizHistoryService, predictionService predictionservice.IPredictionService) *GuardianHandler {
+	return &GuardianHandler{
+		guardianService:    guardianService,
+		studentService:     studentService,
+		quizHistoryService: quizHistoryService,
+		predictionService:  predictionService,
+	}
+}
+
+func (h *GuardianHandler) RegisterGuardian(c echo.Context) error {
+	var req guardianrequest.RegisterGuardianRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := h.guardianService.Register(c.Request().Context(), req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to register guardian")
+	}
+
+	return response.Success(c, http.StatusCreated, "Register Guardian Successfully", nil)
+}
+
+func (h *GuardianHandler) LoginGuardian(c echo.Context) error {
+	var req guardianrequest.LoginGuardianRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	tokens, err := h.guardianService.Login(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to login guardian")
+	}
+
+	return response.Success(c, http.StatusOK, "Login Successfully", tokens)
+}
+
+func (h *GuardianHandler) GetProfileGuardian(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	me, err := h.guardianService.GetProfile(c.Request().Context(), uuid.MustParse(claims.UserID))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get guardian profile")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Profile Successfully", guardianresponse.ToGuardianResponse(*me))
+}
+
+func (h *GuardianHandler) Logout(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	if err := h.guardianService.Logout(c.Request().Context(), uuid.MustParse(claims.UserID), claims); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to logout guardian")
+	}
+
+	return response.Success(c, http.StatusOK, "Logout Success", nil)
+}
+
+func (h *GuardianHandler) LinkStudent(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	var req guardianrequest.LinkStudentRequest
+	req.Nisn = c.FormValue("nisn")
+	req.Code = c.FormValue("code")
+	req.IPAddress = c.RealIP()
+	if dateStr := c.FormValue("date_of_birth"); dateStr != "" {
+		dateOfBirth, err := time.Parse("02-01-2006", dateStr)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid date of birth format", err.Error())
+		}
+
+		req.DateOfBirth = dateOfBirth
+	}
+
+	student, err := h.guardianService.LinkStudent(c.Request().Context(), uuid.MustParse(claims.UserID), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to link student")
+	}
+
+	return response.Success(c, http.StatusOK, "Link Student Successfully", guardianresponse.ToLinkedStudentResponse(*student))
+}
+
+func (h *GuardianHandler) GetLinkedStudents(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	students, err := h.guardianService.GetLinkedStudents(c.Request().Context(), uuid.MustParse(claims.UserID))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get linked students")
+	}
+
+	data := make([]guardianresponse.LinkedStudentResponse, len(students))
+	for i, s := range students {
+		data[i] = guardianresponse.ToLinkedStudentResponse(*s)
+	}
+
+	return response.Success(c, http.StatusOK, "Get Linked Students Successfully", data)
+}
+
+func (h *GuardianHandler) UnlinkStudent(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := h.guardianService.UnlinkStudent(c.Request().Context(), uuid.MustParse(claims.UserID), studentId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to unlink student")
+	}
+
+	return response.Success(c, http.StatusOK, "Unlink Student Successfully", nil)
+}
+
+// linkedStudentParam membaca :studentId dan memastikan siswa tersebut terhubung dengan wali yang login.
+func (h *GuardianHandler) linkedStudentParam(c echo.Context) (uuid.UUID, error) {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return uuid.Nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+	}
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return uuid.Nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "bad request", http.StatusBadRequest)
+	}
+
+	if err := h.guardianService.EnsureLinked(c.Request().Context(), uuid.MustParse(claims.UserID), studentId); err != nil {
+		return uuid.Nil, err
+	}
+
+	return studentId, nil
+}
+
+func (h *GuardianHandler) GetStudentToothBrushHistory(c echo.Context) error {
+	studentId, err := h.linkedStudentParam(c)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to check guardian link")
+	}
+
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	timeType := c.QueryParam("time_type")
+
+	histories, total, err := h.studentService.GetHitoryToothBrush(c.Request().Context(), studentId, timeType, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get history tooth brush")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+
+	data := make([]toothbrushresponse.ToothBrushResponse, len(histories))
+	for i, history := range histories {
+		data[i] = toothbrushresponse.ToToothBrushResponse(*history)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get Tooth Brush History Successfully", data, meta)
+}
+
+func (h *GuardianHandler) GetStudentQuizHistory(c echo.Context) error {
+	studentId, err := h.linkedStudentParam(c)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to check guardian link")
+	}
+
+	data, err := h.quizHistoryService.GetHistoryQuizStudent(c.Request().Context(), studentId, c.QueryParam("search"))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get quiz history")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Quiz History Student Successfully", data)
+}
+
+func (h *GuardianHandler) GetStudentPredictions(c echo.Context) error {
+	studentId, err := h.linkedStudentParam(c)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to check guardian link")
+	}
+
+	items, err := h.predictionService.GetPredictionByStudent(c.Request().Context(), studentId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get predictions")
+	}
+
+	data := make([]predictionresponse.PredictionByStudentResponse, len(items))
+	for i, ps := range items {
+		data[i] = predictionresponse.ToPredictionByStudentResponse(*ps)
+	}
+
+	return response.Success(c, http.StatusOK, "Get Prediction Student Successfully", data)
+}
+
+func (h *GuardianHandler) IssueLinkCode(c echo.Context) error {
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	res, err := h.guardianService.IssueLinkCode(c.Request().Context(), studentId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to generate link code")
+	}
+
+	return response.Success(c, http.StatusOK, "Link Code Generated Successfully", res)
+}
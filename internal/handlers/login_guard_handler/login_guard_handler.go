@@ -0,0 +1,67 @@
+package loginguardhandler
+
+import (
+	loginguardrequest "giat-cerika-service/internal/dto/request/login_guard_request"
+	loginguardresponse "giat-cerika-service/internal/dto/response/login_guard_response"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type LoginGuardHandler struct {
+	loginGuardService loginguardservice.ILoginGuardService
+}
+
+func NewLoginGuardHandler(service loginguardservice.ILoginGuardService) *LoginGuardHandler {
+	return &LoginGuardHandler{loginGuardService: service}
+}
+
+func (l *LoginGuardHandler) GetLockouts(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	search := c.QueryParam("search")
+	activeOnly := c.QueryParam("active") == "true"
+
+	lockouts, total, err := l.loginGuardService.GetLockouts(c.Request().Context(), search, activeOnly, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get login lockouts")
+	}
+
+	now := time.Now()
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]loginguardresponse.LoginLockoutResponse, len(lockouts))
+	for i, lockout := range lockouts {
+		data[i] = loginguardresponse.ToLoginLockoutResponse(*lockout, now)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get Login Lockouts Successfully", data, meta)
+}
+
+func (l *LoginGuardHandler) Unlock(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	var req loginguardrequest.UnlockLoginRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := l.loginGuardService.Unlock(c.Request().Context(), uuid.MustParse(claims.UserID), req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to unlock login")
+	}
+
+	return response.Success(c, http.StatusOK, "Login Unlocked Successfully", nil)
+}
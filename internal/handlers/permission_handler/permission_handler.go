@@ -0,0 +1,154 @@
+package permissionhandler
+
+import (
+	permissionrequest "giat-cerika-service/internal/dto/request/permission_request"
+	permissionresponse "giat-cerika-service/internal/dto/response/permission_response"
+	permissionservice "giat-cerika-service/internal/services/permission_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PermissionHandler struct {
+	permissionService permissionservice.IPermissionService
+}
+
+func NewPermissionHandler(service permissionservice.IPermissionService) *PermissionHandler {
+	return &PermissionHandler{permissionService: service}
+}
+
+func (p *PermissionHandler) CreatePermission(c echo.Context) error {
+	var req permissionrequest.CreatePermissionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err := p.permissionService.CreatePermission(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to create permission")
+	}
+
+	return response.Success(c, http.StatusOK, "Permission Created Successfully", nil)
+}
+
+func (p *PermissionHandler) GetAllPermission(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	search := c.QueryParam("search")
+
+	permissions, total, err := p.permissionService.GetAllPermission(c.Request().Context(), pageInt, limitInt, search)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get permission")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]permissionresponse.PermissionResponse, len(permissions))
+	for i, s := range permissions {
+		data[i] = permissionresponse.ToPermissionResponse(*s)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All Permission Successfully", data, meta)
+}
+
+func (p *PermissionHandler) GetByIdPermission(c echo.Context) error {
+	permissionId, err := uuid.Parse(c.Param("permissionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	data, err := p.permissionService.GetByIdPermission(c.Request().Context(), permissionId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get permission")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Permission Successfully", permissionresponse.ToPermissionResponse(*data))
+}
+
+func (p *PermissionHandler) UpdatePermission(c echo.Context) error {
+	permissionId, err := uuid.Parse(c.Param("permissionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req permissionrequest.UpdatePermissionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = p.permissionService.UpdatePermission(c.Request().Context(), permissionId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update permission")
+	}
+
+	return response.Success(c, http.StatusOK, "Permission Updated Successfully", nil)
+}
+
+func (p *PermissionHandler) DeletePermission(c echo.Context) error {
+	permissionId, err := uuid.Parse(c.Param("permissionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := p.permissionService.DeletePermission(c.Request().Context(), permissionId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to delete permission")
+	}
+
+	return response.Success(c, http.StatusOK, "Permission Deleted Successfully", nil)
+}
+
+func (p *PermissionHandler) GetRolePermissions(c echo.Context) error {
+	roleId, err := uuid.Parse(c.Param("roleId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	role, err := p.permissionService.GetRolePermissions(c.Request().Context(), roleId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get role permissions")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Role Permissions Successfully", permissionresponse.ToRolePermissionResponse(*role))
+}
+
+func (p *PermissionHandler) UpdateRolePermissions(c echo.Context) error {
+	roleId, err := uuid.Parse(c.Param("roleId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req permissionrequest.UpdateRolePermissionsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = p.permissionService.UpdateRolePermissions(c.Request().Context(), roleId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update role permissions")
+	}
+
+	return response.Success(c, http.StatusOK, "Role Permissions Updated Successfully", nil)
+}
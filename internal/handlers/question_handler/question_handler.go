@@ -5,6 +5,7 @@ import (
 	answerrequest "giat-cerika-service/internal/dto/request/answer_request"
 	questionrequest "giat-cerika-service/internal/dto/request/question_request"
 	questionresponse "giat-cerika-service/internal/dto/response/question_response"
+	"giat-cerika-service/internal/models"
 	questionservice "giat-cerika-service/internal/services/question_service"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"giat-cerika-service/pkg/constant/response"
@@ -28,6 +29,9 @@ func (qh *QuestionHandler) CreateQuestion(c echo.Context) error {
 	var req questionrequest.CreateQuestionRequest
 
 	req.QuestionText = c.FormValue("question_text")
+	req.QuestionType = models.QuestionType(c.FormValue("question_type"))
+	req.Tag = c.FormValue("tag")
+	req.Difficulty = models.QuestionDifficulty(c.FormValue("difficulty"))
 
 	// Parse quiz_id
 	quizIdStr := c.FormValue("quiz_id")
@@ -111,6 +115,9 @@ func (qh *QuestionHandler) UpdateQuestion(c echo.Context) error {
 	var req questionrequest.UpdateQuestionRequest
 
 	req.QuestionText = c.FormValue("question_text")
+	req.QuestionType = models.QuestionType(c.FormValue("question_type"))
+	req.Tag = c.FormValue("tag")
+	req.Difficulty = models.QuestionDifficulty(c.FormValue("difficulty"))
 	// Parse quiz_id
 	quizIdStr := c.FormValue("quiz_id")
 	if quizIdStr != "" {
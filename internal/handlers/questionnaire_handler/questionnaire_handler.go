@@ -0,0 +1,292 @@
+package questionnairehandler
+
+import (
+	questionnairerequest "giat-cerika-service/internal/dto/request/questionnaire_request"
+	questionnaireresponse "giat-cerika-service/internal/dto/response/questionnaire_response"
+	questionnaireservice "giat-cerika-service/internal/services/questionnaire_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type QuestionnaireHandler struct {
+	questionnaireService questionnaireservice.IQuestionnaireService
+}
+
+func NewQuestionnaireHandler(service questionnaireservice.IQuestionnaireService) *QuestionnaireHandler {
+	return &QuestionnaireHandler{questionnaireService: service}
+}
+
+func (q *QuestionnaireHandler) CreateQuestionnaire(c echo.Context) error {
+	var req questionnairerequest.CreateQuestionnaireRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.CreateQuestionnaire(c.Request().Context(), req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to create questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusCreated, "questionnaire created successfully", nil)
+}
+
+func (q *QuestionnaireHandler) GetAllQuestionnaire(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	search := c.QueryParam("search")
+	questionnaireType := c.QueryParam("type")
+
+	questionnaires, total, err := q.questionnaireService.GetAllQuestionnaire(c.Request().Context(), pageInt, limitInt, search, questionnaireType)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get questionnaires", err.Error())
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]questionnaireresponse.QuestionnaireResponse, len(questionnaires))
+	for i, questionnaire := range questionnaires {
+		data[i] = questionnaireresponse.ToQuestionnaireResponse(*questionnaire)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All Questionnaires Successfully", data, meta)
+}
+
+func (q *QuestionnaireHandler) GetQuestionnaireById(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	questionnaire, err := q.questionnaireService.GetQuestionnaireById(c.Request().Context(), questionnaireId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Get Questionnaire By ID Successfully", questionnaireresponse.ToQuestionnaireDetailResponse(*questionnaire))
+}
+
+func (q *QuestionnaireHandler) UpdateQuestionnaire(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req questionnairerequest.UpdateQuestionnaireRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.UpdateQuestionnaire(c.Request().Context(), questionnaireId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "questionnaire updated successfully", nil)
+}
+
+func (q *QuestionnaireHandler) DeleteQuestionnaire(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.DeleteQuestionnaire(c.Request().Context(), questionnaireId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to delete questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "questionnaire deleted successfully", nil)
+}
+
+func (q *QuestionnaireHandler) UpdateStatusQuestionnaire(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req questionnairerequest.UpdateStatusQuestionnaireRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.UpdateStatusQuestionnaire(c.Request().Context(), questionnaireId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update status questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "questionnaire status updated successfully", nil)
+}
+
+func (q *QuestionnaireHandler) CreateItem(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req questionnairerequest.CreateQuestionnaireItemRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.CreateItem(c.Request().Context(), questionnaireId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to create item", err.Error())
+	}
+
+	return response.Success(c, http.StatusCreated, "item created successfully", nil)
+}
+
+func (q *QuestionnaireHandler) UpdateItem(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	itemId, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req questionnairerequest.UpdateQuestionnaireItemRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.UpdateItem(c.Request().Context(), questionnaireId, itemId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update item", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "item updated successfully", nil)
+}
+
+func (q *QuestionnaireHandler) DeleteItem(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	itemId, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.DeleteItem(c.Request().Context(), questionnaireId, itemId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to delete item", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "item deleted successfully", nil)
+}
+
+func (q *QuestionnaireHandler) GetResultByClass(c echo.Context) error {
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var classId *uuid.UUID
+	if v := c.QueryParam("class_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid class id", err.Error())
+		}
+		classId = &id
+	}
+
+	result, err := q.questionnaireService.GetResultByClass(c.Request().Context(), questionnaireId, c.QueryParam("period"), classId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get questionnaire result", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Get Questionnaire Result Successfully", result)
+}
+
+func (q *QuestionnaireHandler) GetAllAvailable(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	data, err := q.questionnaireService.GetAllAvailable(c.Request().Context(), uuid.MustParse(claims.UserID), c.QueryParam("search"))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get questionnaires", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Get All Available Questionnaires Successfully", data)
+}
+
+func (q *QuestionnaireHandler) GetAvailableById(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	data, err := q.questionnaireService.GetAvailableById(c.Request().Context(), uuid.MustParse(claims.UserID), questionnaireId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Get Available Questionnaire Successfully", data)
+}
+
+func (q *QuestionnaireHandler) SubmitQuestionnaire(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	questionnaireId, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req questionnairerequest.SubmitQuestionnaireRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := q.questionnaireService.SubmitQuestionnaire(c.Request().Context(), uuid.MustParse(claims.UserID), questionnaireId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to submit questionnaire", err.Error())
+	}
+
+	return response.Success(c, http.StatusCreated, "questionnaire submitted successfully", nil)
+}
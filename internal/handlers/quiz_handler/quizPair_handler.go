@@ -0,0 +1,131 @@
+package quizhandler
+
+import (
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
+	quizresponse "giat-cerika-service/internal/dto/response/quiz_response"
+	quizservice "giat-cerika-service/internal/services/quiz_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type QuizPairHandler struct {
+	pairService quizservice.IQuizPairService
+}
+
+func NewQuizPairHandler(service quizservice.IQuizPairService) *QuizPairHandler {
+	return &QuizPairHandler{pairService: service}
+}
+
+func (q *QuizPairHandler) CreateQuizPair(c echo.Context) error {
+	var req quizrequest.CreateQuizPairRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err := q.pairService.CreateQuizPair(c.Request().Context(), req)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to create quiz pair", err.Error())
+	}
+	return response.Success(c, http.StatusCreated, "quiz pair created successfully", nil)
+}
+
+func (q *QuizPairHandler) GetAllQuizPair(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	search := c.QueryParam("search")
+
+	pairs, total, err := q.pairService.GetAllQuizPair(c.Request().Context(), pageInt, limitInt, search)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get quiz pairs", err.Error())
+	}
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]quizresponse.QuizPairResponse, len(pairs))
+	for i, p := range pairs {
+		data[i] = quizresponse.ToQuizPairResponse(*p)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All Quiz Pairs Successfully", data, meta)
+}
+
+func (q *QuizPairHandler) GetQuizPairByID(c echo.Context) error {
+	pairId, err := uuid.Parse(c.Param("pairId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	pair, err := q.pairService.GetQuizPairById(c.Request().Context(), pairId)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get quiz pair", err.Error())
+	}
+	return response.Success(c, http.StatusOK, "Get Quiz Pair By ID Successfully", quizresponse.ToQuizPairResponse(*pair))
+}
+
+func (q *QuizPairHandler) UpdateQuizPair(c echo.Context) error {
+	pairId, err := uuid.Parse(c.Param("pairId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	var req quizrequest.UpdateQuizPairRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err = q.pairService.UpdateQuizPair(c.Request().Context(), pairId, req)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update quiz pair", err.Error())
+	}
+	return response.Success(c, http.StatusOK, "quiz pair updated successfully", nil)
+}
+
+func (q *QuizPairHandler) DeleteQuizPair(c echo.Context) error {
+	pairId, err := uuid.Parse(c.Param("pairId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err = q.pairService.DeleteQuizPair(c.Request().Context(), pairId)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to delete quiz pair", err.Error())
+	}
+	return response.Success(c, http.StatusOK, "quiz pair deleted successfully", nil)
+}
+
+func (q *QuizPairHandler) GetLearningGain(c echo.Context) error {
+	pairId, err := uuid.Parse(c.Param("pairId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var classId *uuid.UUID
+	if v := c.QueryParam("class_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid class id", err.Error())
+		}
+		classId = &id
+	}
+
+	result, err := q.pairService.GetLearningGain(c.Request().Context(), pairId, classId)
+	if err != nil {
+		if cutomErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, cutomErr.Status, cutomErr.Msg, cutomErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to get learning gain", err.Error())
+	}
+	return response.Success(c, http.StatusOK, "Get Learning Gain Successfully", result)
+}
@@ -146,6 +146,66 @@ func (q *QuizHandler) UpdateQuestionOrderMode(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "Quiz Question Order Mode Updated Successfully", nil)
 }
 
+func (q *QuizHandler) UpdateCorrectAnswerVisibility(c echo.Context) error {
+	quizId, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	var req quizrequest.UpdateCorrectAnswerVisibilityRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err = q.quizService.UpdateCorrectAnswerVisibility(c.Request().Context(), quizId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update correct answer visibility", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Quiz Correct Answer Visibility Updated Successfully", nil)
+}
+
+func (q *QuizHandler) UpdateShuffleAnswers(c echo.Context) error {
+	quizId, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	var req quizrequest.UpdateShuffleAnswersRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err = q.quizService.UpdateShuffleAnswers(c.Request().Context(), quizId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update shuffle answers", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Quiz Shuffle Answers Updated Successfully", nil)
+}
+
+func (q *QuizHandler) UpdateQuestionPool(c echo.Context) error {
+	quizId, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	var req quizrequest.UpdateQuestionPoolRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	err = q.quizService.UpdateQuestionPool(c.Request().Context(), quizId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, "failed to update question pool", err.Error())
+	}
+
+	return response.Success(c, http.StatusOK, "Quiz Question Pool Updated Successfully", nil)
+}
+
 func (q *QuizHandler) GetAllQuizAvailable(c echo.Context) error {
 	search := c.QueryParam("search")
 
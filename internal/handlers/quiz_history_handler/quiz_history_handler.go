@@ -1,12 +1,15 @@
 package quizhistoryhandler
 
 import (
-	quizhistoryresponse "giat-cerika-service/internal/dto/response/quiz_history_response"
+	"fmt"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
 	quizhistoryservice "giat-cerika-service/internal/services/quiz_history_service"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"giat-cerika-service/pkg/constant/response"
 	"giat-cerika-service/pkg/utils"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -49,7 +52,12 @@ func (qh *QuizHistoryHandler) GetAllQuestionHistory(c echo.Context) error {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
 
-	questionHistory, err := qh.qhService.GetAllHistoryQuestionByQuizHistory(c.Request().Context(), quizHistoryId)
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+	}
+
+	data, err := qh.qhService.GetAllHistoryQuestionByQuizHistory(c.Request().Context(), uuid.MustParse(claims.UserID), quizHistoryId)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err)
@@ -57,11 +65,6 @@ func (qh *QuizHistoryHandler) GetAllQuestionHistory(c echo.Context) error {
 		return response.Error(c, http.StatusInternalServerError, "failed to get question history", err.Error())
 	}
 
-	data := make([]quizhistoryresponse.QuestionHistory, len(questionHistory))
-	for i, question := range questionHistory {
-		data[i] = quizhistoryresponse.ToQuestionHistory(*question)
-	}
-
 	return response.Success(c, http.StatusOK, "Get all question history successfully", data)
 }
 
@@ -76,3 +79,93 @@ func (qh *QuizHistoryHandler) GetHistoryQuizByQuizID(c echo.Context) error {
 
 	return response.Success(c, http.StatusOK, "Get Quiz History Successfully", data)
 }
+
+func (qh *QuizHistoryHandler) GetItemAnalysis(c echo.Context) error {
+	quizId, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid quiz id", err.Error())
+	}
+
+	data, err := qh.qhService.GetItemAnalysis(c.Request().Context(), quizId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err)
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return response.Success(c, http.StatusOK, "Get Item Analysis Successfully", data)
+}
+
+func parseExportUUID(c echo.Context, name string) (*uuid.UUID, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// ExportQuizHistory mengekspor riwayat quiz sebagai CSV (default) atau XLSX.
+// Query: quiz_id, class_id, start_date & end_date (YYYY-MM-DD, inklusif), format=csv|xlsx, include_answers=true.
+func (qh *QuizHistoryHandler) ExportQuizHistory(c echo.Context) error {
+	var req quizrequest.ExportQuizHistoryRequest
+	var err error
+
+	if req.QuizID, err = parseExportUUID(c, "quiz_id"); err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid quiz id", err.Error())
+	}
+	if req.ClassID, err = parseExportUUID(c, "class_id"); err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid class id", err.Error())
+	}
+
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	if v := c.QueryParam("start_date"); v != "" {
+		start, err := time.ParseInLocation("2006-01-02", v, locJakarta)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid start_date format, use YYYY-MM-DD", err.Error())
+		}
+		req.StartDate = &start
+	}
+	if v := c.QueryParam("end_date"); v != "" {
+		end, err := time.ParseInLocation("2006-01-02", v, locJakarta)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid end_date format, use YYYY-MM-DD", err.Error())
+		}
+		end = end.AddDate(0, 0, 1)
+		req.EndDate = &end
+	}
+	req.IncludeAnswers = c.QueryParam("include_answers") == "true"
+
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		return response.Error(c, http.StatusBadRequest, "format only 'csv' or 'xlsx'", nil)
+	}
+
+	data, err := qh.qhService.ExportQuizHistory(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err)
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	filename := fmt.Sprintf("quiz_history_%s.%s", time.Now().In(locJakarta).Format("20060102_150405"), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "xlsx" {
+		c.Response().Header().Set(echo.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		c.Response().WriteHeader(http.StatusOK)
+		return utils.WriteXLSX(c.Response(), "Quiz History", data.Headers, data.Rows)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().WriteHeader(http.StatusOK)
+	return utils.WriteCSV(c.Response(), data.Headers, data.Rows)
+}
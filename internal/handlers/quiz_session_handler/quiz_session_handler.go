@@ -117,7 +117,7 @@ func (qs QuizSessionHandler) SubmitQuizSession(c echo.Context) error {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
 
-	err = qs.qsService.SubmtiQuizSession(c.Request().Context(), uuid.MustParse(studentId), quizSessionId, req)
+	result, err := qs.qsService.SubmtiQuizSession(c.Request().Context(), uuid.MustParse(studentId), quizSessionId, req)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err)
@@ -125,7 +125,10 @@ func (qs QuizSessionHandler) SubmitQuizSession(c echo.Context) error {
 		return response.Error(c, http.StatusInternalServerError, "failed to submit quiz", 500)
 	}
 
-	return response.Success(c, http.StatusOK, "Quiz Submitted Successfully", nil)
+	if result.Late {
+		return response.Success(c, http.StatusOK, "Quiz time has ended, late answers were not scored", result)
+	}
+	return response.Success(c, http.StatusOK, "Quiz Submitted Successfully", result)
 }
 
 func (qs *QuizSessionHandler) GetQuizQuestionByOrderMode(c echo.Context) error {
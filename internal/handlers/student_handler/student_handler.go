@@ -94,8 +94,10 @@ func (s *StudentHandler) LoginStudent(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
 
-	token, err := s.studentService.Login(c.Request().Context(), req)
+	tokens, err := s.studentService.Login(c.Request().Context(), req)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
@@ -103,9 +105,7 @@ func (s *StudentHandler) LoginStudent(c echo.Context) error {
 		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to login student")
 	}
 
-	return response.Success(c, http.StatusOK, "Login Successfully", map[string]interface{}{
-		"access_token": token,
-	})
+	return response.Success(c, http.StatusOK, "Login Successfully", tokens)
 }
 
 func (s *StudentHandler) GetProfileStudent(c echo.Context) error {
@@ -114,10 +114,8 @@ func (s *StudentHandler) GetProfileStudent(c echo.Context) error {
 		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
 	}
 	studentId := claims.UserID
-	authHeader := c.Request().Header.Get("Authorization")
-	token := strings.TrimPrefix(authHeader, "Bearer ")
 
-	me, err := s.studentService.GetProfile(c.Request().Context(), uuid.MustParse(studentId), token)
+	me, err := s.studentService.GetProfile(c.Request().Context(), uuid.MustParse(studentId))
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
@@ -135,24 +133,8 @@ func (s *StudentHandler) Logout(c echo.Context) error {
 		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
 	}
 	studentId := claims.UserID
-	authHeader := c.Request().Header.Get("Authorization")
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-
-	blacklist, err := s.studentService.CheckTokenBlacklisted(c.Request().Context(), token)
-	if err != nil {
-		if customErr, ok := errorresponse.AsCustomErr(err); ok {
-			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
-		}
-		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get profile")
-	}
-	if blacklist {
-		return response.Error(c, http.StatusUnauthorized, "unauthorized access", "token blacklisted")
-	}
-	if token == "" {
-		return response.Error(c, http.StatusBadRequest, "bad request: missing token", nil)
-	}
 
-	if err := s.studentService.Logout(c.Request().Context(), uuid.MustParse(studentId), token); err != nil {
+	if err := s.studentService.Logout(c.Request().Context(), uuid.MustParse(studentId), claims); err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
 		}
@@ -165,6 +147,7 @@ func (s *StudentHandler) Logout(c echo.Context) error {
 func (s *StudentHandler) CheckNisnAndDateOfBirthStudent(c echo.Context) error {
 	var req studentrequest.CheckNisnAndDateOfBirth
 	req.Nisn = c.FormValue("nisn")
+	req.IPAddress = c.RealIP()
 	if dateStr := c.FormValue("date_of_birth"); dateStr != "" {
 		dateOfBirth, err := time.Parse("02-01-2006", dateStr)
 		if err != nil {
@@ -174,15 +157,47 @@ func (s *StudentHandler) CheckNisnAndDateOfBirthStudent(c echo.Context) error {
 		req.DateOfBirth = dateOfBirth
 	}
 
-	student, err := s.studentService.CheckNisnAndDateOfBirth(c.Request().Context(), req)
+	res, err := s.studentService.CheckNisnAndDateOfBirth(c.Request().Context(), req)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
 		}
 		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to check nisn and date of birth")
 	}
-	studentResponse := studentresponse.ToStudentResponse(*student)
-	return response.Success(c, http.StatusOK, "Check Nisn and Date of Birth Successfully", studentResponse)
+	return response.Success(c, http.StatusOK, "Check Nisn and Date of Birth Successfully", res)
+}
+
+func (s *StudentHandler) VerifyResetCode(c echo.Context) error {
+	var req studentrequest.VerifyResetCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	req.IPAddress = c.RealIP()
+
+	res, err := s.studentService.VerifyResetCode(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to verify reset code")
+	}
+	return response.Success(c, http.StatusOK, "Reset Code Verified Successfully", res)
+}
+
+func (s *StudentHandler) ResetPasswordByAdmin(c echo.Context) error {
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	res, err := s.studentService.ResetPasswordByAdmin(c.Request().Context(), studentId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to reset password")
+	}
+	return response.Success(c, http.StatusOK, "Reset Code Generated Successfully", res)
 }
 
 func (s *StudentHandler) UpdateNewPasswordStudent(c echo.Context) error {
@@ -191,7 +206,7 @@ func (s *StudentHandler) UpdateNewPasswordStudent(c echo.Context) error {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
 
-	err := s.studentService.UpdateNewPasswordStudent(c.Request().Context(), req.StudentID, req)
+	err := s.studentService.UpdateNewPasswordStudent(c.Request().Context(), req)
 	if err != nil {
 		if customErr, ok := errorresponse.AsCustomErr(err); ok {
 			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
@@ -279,6 +294,10 @@ func (s *StudentHandler) CreateToothBrush(c echo.Context) error {
 		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
 	}
 
+	if photo, err := c.FormFile("photo"); err == nil {
+		req.Photo = photo
+	}
+
 	// LEMPAR KE SERVICE
 	err = s.studentService.CreateTootBrushStudent(c.Request().Context(), uuid.MustParse(studentId), req)
 	if err != nil {
@@ -320,6 +339,79 @@ func (s *StudentHandler) GetHistoryToothBrush(c echo.Context) error {
 	return response.PaginatedSuccess(c, http.StatusOK, "Get Tooth Brush History Successfully", data, meta)
 }
 
+// parseProgressParams membaca query start_date, end_date (format tanggal fleksibel) dan month (MM-YYYY / YYYY-MM).
+func parseProgressParams(c echo.Context) (startDate, endDate, month time.Time, err error) {
+	if v := c.QueryParam("start_date"); v != "" {
+		if startDate, err = parseFlexibleDate(v); err != nil {
+			return
+		}
+	}
+	if v := c.QueryParam("end_date"); v != "" {
+		if endDate, err = parseFlexibleDate(v); err != nil {
+			return
+		}
+	}
+	if v := strings.TrimSpace(c.QueryParam("month")); v != "" {
+		var parseErr error
+		for _, f := range []string{"01-2006", "2006-01"} {
+			if month, parseErr = time.Parse(f, v); parseErr == nil {
+				break
+			}
+		}
+		if parseErr != nil {
+			err = errorresponse.NewCustomError(errorresponse.ErrBadRequest, "format bulan tidak valid", 400)
+			return
+		}
+	}
+	return
+}
+
+func (s *StudentHandler) GetToothBrushProgress(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	studentId := claims.UserID
+
+	startDate, endDate, month, err := parseProgressParams(c)
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid date format", err.Error())
+	}
+
+	progress, err := s.studentService.GetToothBrushProgress(c.Request().Context(), uuid.MustParse(studentId), startDate, endDate, month)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get tooth brush progress")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Tooth Brush Progress Successfully", progress)
+}
+
+func (s *StudentHandler) GetToothBrushProgressByStudent(c echo.Context) error {
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	startDate, endDate, month, err := parseProgressParams(c)
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid date format", err.Error())
+	}
+
+	progress, err := s.studentService.GetToothBrushProgress(c.Request().Context(), studentId, startDate, endDate, month)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get tooth brush progress")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Tooth Brush Progress Successfully", progress)
+}
+
 func (q *StudentHandler) GetStudentAll(c echo.Context) error {
 	search := c.QueryParam("search")
 	students, _, err := q.studentService.GetAllStudents(c.Request().Context(), search)
@@ -338,3 +430,113 @@ func (q *StudentHandler) GetStudentAll(c echo.Context) error {
 
 	return response.Success(c, http.StatusOK, "Get All Students Successfully", data)
 }
+
+func (s *StudentHandler) GetToothBrushForReview(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	reviewStatus := c.QueryParam("review_status")
+	onlyWithPhoto := c.QueryParam("has_photo") == "true"
+
+	var classId *uuid.UUID
+	if v := c.QueryParam("class_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "invalid class id", err.Error())
+		}
+		classId = &id
+	}
+
+	logs, total, err := s.studentService.GetToothBrushForReview(c.Request().Context(), reviewStatus, classId, onlyWithPhoto, pageInt, limitInt)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get toothbrush logs")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]toothbrushresponse.ToothBrushResponse, len(logs))
+	for i, log := range logs {
+		data[i] = toothbrushresponse.ToToothBrushResponse(*log)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get Tooth Brush Logs For Review Successfully", data, meta)
+}
+
+func (s *StudentHandler) ReviewToothBrush(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	logId, err := uuid.Parse(c.Param("logId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid log id", err.Error())
+	}
+
+	var req studentrequest.ReviewToothBrushRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = s.studentService.ReviewToothBrush(c.Request().Context(), logId, uuid.MustParse(claims.UserID), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to review toothbrush log")
+	}
+
+	return response.Success(c, http.StatusOK, "Tooth brush log reviewed successfully", nil)
+}
+
+func (s *StudentHandler) ImportStudents(c echo.Context) error {
+	var req studentrequest.ImportStudentRequest
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "file is required", err.Error())
+	}
+	req.File = file
+	if v := c.FormValue("dry_run"); v != "" {
+		req.DryRun, _ = strconv.ParseBool(v)
+	} else {
+		req.DryRun = c.QueryParam("dry_run") == "true"
+	}
+
+	result, err := s.studentService.ImportStudents(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to import students")
+	}
+
+	if req.DryRun {
+		return response.Success(c, http.StatusOK, "Import Students Validated Successfully", result)
+	}
+	if result.InvalidRows > 0 {
+		return response.Error(c, http.StatusUnprocessableEntity, "Import dibatalkan: terdapat baris yang tidak valid", result)
+	}
+
+	return response.Success(c, http.StatusCreated, "Students Imported Successfully", result)
+}
+
+func (s *StudentHandler) UpdateStudentStatus(c echo.Context) error {
+	studentId, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid student id", err.Error())
+	}
+
+	var req studentrequest.UpdateStudentStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := s.studentService.UpdateStudentStatus(c.Request().Context(), studentId, req); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update student status")
+	}
+
+	return response.Success(c, http.StatusOK, "Student Status Updated Successfully", nil)
+}
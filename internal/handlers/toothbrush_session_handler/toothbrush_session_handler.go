@@ -0,0 +1,157 @@
+package toothbrushsessionhandler
+
+import (
+	toothbrushsessionrequest "giat-cerika-service/internal/dto/request/toothbrush_session_request"
+	toothbrushsessionresponse "giat-cerika-service/internal/dto/response/toothbrush_session_response"
+	toothbrushsessionservice "giat-cerika-service/internal/services/toothbrush_session_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ToothBrushSessionHandler struct {
+	sessionService toothbrushsessionservice.IToothBrushSessionService
+}
+
+func NewToothBrushSessionHandler(service toothbrushsessionservice.IToothBrushSessionService) *ToothBrushSessionHandler {
+	return &ToothBrushSessionHandler{sessionService: service}
+}
+
+func (th *ToothBrushSessionHandler) CreateSession(c echo.Context) error {
+	var req toothbrushsessionrequest.CreateToothBrushSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err := th.sessionService.CreateSession(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to create session")
+	}
+
+	return response.Success(c, http.StatusCreated, "Tooth Brush Session Created Successfully", nil)
+}
+
+func (th *ToothBrushSessionHandler) GetAllSession(c echo.Context) error {
+	pageInt, limitInt := utils.ParsePaginationParams(c, 10)
+	search := c.QueryParam("search")
+
+	sessions, total, err := th.sessionService.GetAllSession(c.Request().Context(), pageInt, limitInt, search)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get sessions")
+	}
+
+	meta := utils.BuildPaginationMeta(c, pageInt, limitInt, total)
+	data := make([]toothbrushsessionresponse.ToothBrushSessionResponse, len(sessions))
+	for i, session := range sessions {
+		data[i] = toothbrushsessionresponse.ToToothBrushSessionResponse(*session)
+	}
+
+	return response.PaginatedSuccess(c, http.StatusOK, "Get All Tooth Brush Sessions Successfully", data, meta)
+}
+
+func (th *ToothBrushSessionHandler) GetByIdSession(c echo.Context) error {
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	session, err := th.sessionService.GetByIdSession(c.Request().Context(), sessionId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get session")
+	}
+
+	return response.Success(c, http.StatusOK, "Get Tooth Brush Session Successfully", toothbrushsessionresponse.ToToothBrushSessionResponse(*session))
+}
+
+func (th *ToothBrushSessionHandler) UpdateSession(c echo.Context) error {
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req toothbrushsessionrequest.UpdateToothBrushSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = th.sessionService.UpdateSession(c.Request().Context(), sessionId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to update session")
+	}
+
+	return response.Success(c, http.StatusOK, "Tooth Brush Session Updated Successfully", nil)
+}
+
+func (th *ToothBrushSessionHandler) DeleteSession(c echo.Context) error {
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := th.sessionService.DeleteSession(c.Request().Context(), sessionId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to delete session")
+	}
+
+	return response.Success(c, http.StatusOK, "Tooth Brush Session Deleted Successfully", nil)
+}
+
+func (th *ToothBrushSessionHandler) UpsertClassOverride(c echo.Context) error {
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	var req toothbrushsessionrequest.UpsertClassOverrideRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	err = th.sessionService.UpsertClassOverride(c.Request().Context(), sessionId, req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to save class override")
+	}
+
+	return response.Success(c, http.StatusOK, "Class Override Saved Successfully", nil)
+}
+
+func (th *ToothBrushSessionHandler) DeleteClassOverride(c echo.Context) error {
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	classId, err := uuid.Parse(c.Param("classId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+
+	if err := th.sessionService.DeleteClassOverride(c.Request().Context(), sessionId, classId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to delete class override")
+	}
+
+	return response.Success(c, http.StatusOK, "Class Override Deleted Successfully", nil)
+}
@@ -0,0 +1,114 @@
+package usersessionhandler
+
+import (
+	sessionrequest "giat-cerika-service/internal/dto/request/session_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/response"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type UserSessionHandler struct {
+	sessionService usersessionservice.IUserSessionService
+}
+
+func NewUserSessionHandler(service usersessionservice.IUserSessionService) *UserSessionHandler {
+	return &UserSessionHandler{sessionService: service}
+}
+
+func (u *UserSessionHandler) RefreshToken(c echo.Context) error {
+	var req sessionrequest.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return response.Error(c, http.StatusBadRequest, "bad request", err.Error())
+	}
+	if req.RefreshToken == "" {
+		return response.Error(c, http.StatusBadRequest, "refresh token is required", nil)
+	}
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	tokens, err := u.sessionService.Refresh(c.Request().Context(), req)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to refresh token")
+	}
+
+	return response.Success(c, http.StatusOK, "Refresh Token Successfully", tokens)
+}
+
+func (u *UserSessionHandler) GetMySessions(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	sessions, err := u.sessionService.GetMySessions(c.Request().Context(), uuid.MustParse(claims.UserID))
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to get sessions")
+	}
+
+	data := make([]sessionresponse.SessionResponse, len(sessions))
+	for i, s := range sessions {
+		data[i] = sessionresponse.ToSessionResponse(*s, claims.SessionID)
+	}
+
+	return response.Success(c, http.StatusOK, "Get Sessions Successfully", data)
+}
+
+func (u *UserSessionHandler) RevokeSession(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	sessionId, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "invalid session id", err.Error())
+	}
+
+	if err := u.sessionService.RevokeSession(c.Request().Context(), uuid.MustParse(claims.UserID), sessionId); err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to revoke session")
+	}
+
+	return response.Success(c, http.StatusOK, "Session Revoked Successfully", nil)
+}
+
+func (u *UserSessionHandler) RevokeAllSessions(c echo.Context) error {
+	claims, err := utils.GetClaimsFromContext(c)
+	if err != nil {
+		return response.Error(c, http.StatusUnauthorized, "Unauthorized: "+err.Error(), nil)
+	}
+
+	// keep_current=true: keluar dari semua perangkat lain tanpa mengakhiri sesi yang sedang dipakai.
+	var exceptSessionId *uuid.UUID
+	if c.QueryParam("keep_current") == "true" {
+		if id, err := uuid.Parse(claims.SessionID); err == nil {
+			exceptSessionId = &id
+		}
+	}
+
+	revoked, err := u.sessionService.RevokeAllSessions(c.Request().Context(), uuid.MustParse(claims.UserID), exceptSessionId)
+	if err != nil {
+		if customErr, ok := errorresponse.AsCustomErr(err); ok {
+			return response.Error(c, customErr.Status, customErr.Msg, customErr.Err.Error())
+		}
+		return response.Error(c, http.StatusInternalServerError, err.Error(), "failed to revoke sessions")
+	}
+
+	return response.Success(c, http.StatusOK, "Sessions Revoked Successfully", map[string]interface{}{
+		"revoked": revoked,
+	})
+}
@@ -0,0 +1,97 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"giat-cerika-service/configs"
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// JWTOrAPIKeyMiddleware menerima API key integrasi (header X-API-Key) atau, bila header tersebut
+// tidak ada, token JWT biasa lewat JWTMiddleware. Hak akses API key dibatasi scopes-nya di RequirePermission.
+func JWTOrAPIKeyMiddleware(rdb *redis.Client) echo.MiddlewareFunc {
+	jwtMiddleware := JWTMiddleware(rdb)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withJWT := jwtMiddleware(next)
+
+		return func(c echo.Context) error {
+			rawKey := strings.TrimSpace(c.Request().Header.Get(utils.APIKeyHeader))
+			if rawKey == "" {
+				return withJWT(c)
+			}
+
+			ctx := c.Request().Context()
+			principal, err := findAPIKeyPrincipal(ctx, rdb, utils.HashToken(rawKey))
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "invalid api key")
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to verify api key")
+			}
+			if !time.Now().Before(principal.ExpiresAt) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "api key has expired")
+			}
+
+			touchAPIKeyLastUsed(ctx, rdb, principal)
+
+			// API key tidak terikat user; role aktor di audit log menyebut prefix key-nya
+			ctx = utils.WithAuditActor(ctx, utils.AuditActor{
+				Role:      "api_key:" + principal.Prefix,
+				IPAddress: c.RealIP(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+			utils.SetAPIKeyToContext(c, principal)
+			return next(c)
+		}
+	}
+}
+
+// findAPIKeyPrincipal membaca API key aktif (belum dicabut) dari cache Redis, fallback ke database.
+func findAPIKeyPrincipal(ctx context.Context, rdb *redis.Client, keyHash string) (*utils.APIKeyPrincipal, error) {
+	cacheKey := utils.APIKeyCacheKey(keyHash)
+	if cached, err := rdb.Get(ctx, cacheKey).Bytes(); err == nil {
+		var principal utils.APIKeyPrincipal
+		if json.Unmarshal(cached, &principal) == nil {
+			return &principal, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	var key models.APIKey
+	if err := configs.DB.WithContext(ctx).First(&key, "key_hash = ? AND revoked_at IS NULL", keyHash).Error; err != nil {
+		return nil, err
+	}
+
+	principal := &utils.APIKeyPrincipal{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+	}
+	if buf, err := json.Marshal(principal); err == nil {
+		_ = rdb.Set(ctx, cacheKey, buf, utils.APIKeyCacheTTL).Err()
+	}
+
+	return principal, nil
+}
+
+// touchAPIKeyLastUsed memperbarui last_used_at paling sering sekali per APIKeyLastUsedInterval.
+func touchAPIKeyLastUsed(ctx context.Context, rdb *redis.Client, principal *utils.APIKeyPrincipal) {
+	ok, err := rdb.SetNX(ctx, utils.APIKeyLastUsedKey(principal.ID), 1, utils.APIKeyLastUsedInterval).Result()
+	if err != nil || !ok {
+		return
+	}
+	_ = configs.DB.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", principal.ID).Update("last_used_at", time.Now()).Error
+}
@@ -2,11 +2,12 @@ package middlewares
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"giat-cerika-service/configs"
+	"giat-cerika-service/internal/models"
 	"giat-cerika-service/pkg/utils"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/golang-jwt/jwt/v5"
 	jwtmiddleware "github.com/labstack/echo-jwt/v4"
@@ -15,7 +16,7 @@ import (
 )
 
 func JWTMiddleware(rdb *redis.Client) echo.MiddlewareFunc {
-	return jwtmiddleware.WithConfig(jwtmiddleware.Config{
+	jwtMiddleware := jwtmiddleware.WithConfig(jwtmiddleware.Config{
 		SigningKey: []byte(configs.GetJWTSecret()),
 		ContextKey: "user",
 
@@ -23,23 +24,85 @@ func JWTMiddleware(rdb *redis.Client) echo.MiddlewareFunc {
 			return new(utils.JWTClaims)
 		},
 
-		Skipper: func(c echo.Context) bool {
-			token := c.Request().Header.Get("Authorization")
-			if token != "" {
-				token = strings.TrimPrefix(token, "Bearer ")
-				ctx := context.Background()
-				val, err := rdb.Get(ctx, fmt.Sprintf("blacklist:%s", token)).Result()
-				if err == nil && val == "blacklisted" {
-					return true
-				}
-			}
-			return false
-		},
-
 		ErrorHandler: func(c echo.Context, err error) error {
 			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 		},
 	})
+
+	// Pengecekan pencabutan dilakukan setelah signature & expiry tervalidasi oleh jwtMiddleware.
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return jwtMiddleware(func(c echo.Context) error {
+			claims, err := utils.GetClaimsFromContext(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			}
+
+			revoked, err := isTokenRevoked(c.Request().Context(), rdb, claims)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to verify token")
+			}
+			if revoked {
+				return echo.NewHTTPError(http.StatusUnauthorized, "token has been revoked")
+			}
+
+			// identitas aktor dibawa lewat context agar service bisa menulis audit log
+			ctx := utils.WithAuditActor(c.Request().Context(), utils.AuditActor{
+				UserID:    claims.UserID,
+				Role:      claims.Role,
+				IPAddress: c.RealIP(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		})
+	}
+}
+
+// isTokenRevoked mengecek tiga jenis pencabutan: jti (logout), sesi perangkat, dan epoch user
+// (naik saat akun dinonaktifkan / password direset sehingga semua token lama tidak berlaku).
+func isTokenRevoked(ctx context.Context, rdb *redis.Client, claims *utils.JWTClaims) (bool, error) {
+	keys := []string{}
+	if claims.ID != "" {
+		keys = append(keys, utils.RevokedTokenKey(claims.ID))
+	}
+	if claims.SessionID != "" {
+		keys = append(keys, utils.SessionRevokedKey(claims.SessionID))
+	}
+	if len(keys) > 0 {
+		exists, err := rdb.Exists(ctx, keys...).Result()
+		if err != nil {
+			return false, err
+		}
+		if exists > 0 {
+			return true, nil
+		}
+	}
+
+	epoch, err := currentTokenEpoch(ctx, rdb, claims.UserID)
+	if err != nil {
+		return false, err
+	}
+	return claims.Epoch < epoch, nil
+}
+
+// currentTokenEpoch membaca users.token_epoch dari cache Redis, fallback ke database bila cache kosong.
+func currentTokenEpoch(ctx context.Context, rdb *redis.Client, userID string) (int, error) {
+	key := utils.TokenEpochKey(userID)
+	val, err := rdb.Get(ctx, key).Result()
+	if err == nil {
+		if epoch, convErr := strconv.Atoi(val); convErr == nil {
+			return epoch, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	var epoch int
+	if err := configs.DB.WithContext(ctx).Model(&models.User{}).Select("token_epoch").Where("id = ?", userID).Scan(&epoch).Error; err != nil {
+		return 0, err
+	}
+	_ = rdb.Set(ctx, key, epoch, utils.TokenEpochCacheTTL).Err()
+
+	return epoch, nil
 }
 
 func LoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
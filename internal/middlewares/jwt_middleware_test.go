@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+)
+
+const testJWTSecret = "test-secret"
+
+type jwtTestEnv struct {
+	mr   *miniredis.Miniredis
+	echo *echo.Echo
+}
+
+// newJWTTestEnv menyiapkan echo dengan satu route terlindungi JWTMiddleware di atas miniredis.
+func newJWTTestEnv(t *testing.T) *jwtTestEnv {
+	t.Helper()
+	t.Setenv("JWT_SECRET", testJWTSecret)
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	e := echo.New()
+	e.GET("/protected", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(rdb))
+
+	return &jwtTestEnv{mr: mr, echo: e}
+}
+
+func (env *jwtTestEnv) do(token string) int {
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	env.echo.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// issueToken membuat access token dan mengisi cache epoch agar middleware tidak perlu ke database.
+func (env *jwtTestEnv) issueToken(t *testing.T, epoch int) (string, *utils.JWTClaims) {
+	t.Helper()
+	userID := uuid.NewString()
+	token, err := utils.GenerateToken(userID, "student", uuid.NewString(), epoch)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	claims := new(utils.JWTClaims)
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	env.mr.Set(utils.TokenEpochKey(userID), "0")
+	return token, claims
+}
+
+func TestJWTMiddleware_ValidToken(t *testing.T) {
+	env := newJWTTestEnv(t)
+	token, _ := env.issueToken(t, 0)
+
+	if code := env.do(token); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+}
+
+func TestJWTMiddleware_RevokedJTI(t *testing.T) {
+	env := newJWTTestEnv(t)
+	token, claims := env.issueToken(t, 0)
+	env.mr.Set(utils.RevokedTokenKey(claims.ID), "1")
+
+	if code := env.do(token); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+}
+
+func TestJWTMiddleware_RevokedSession(t *testing.T) {
+	env := newJWTTestEnv(t)
+	token, claims := env.issueToken(t, 0)
+	env.mr.Set(utils.SessionRevokedKey(claims.SessionID), "1")
+
+	if code := env.do(token); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+}
+
+func TestJWTMiddleware_TokenEpochBumped(t *testing.T) {
+	env := newJWTTestEnv(t)
+	token, claims := env.issueToken(t, 0)
+	env.mr.Set(utils.TokenEpochKey(claims.UserID), "1")
+
+	if code := env.do(token); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+}
+
+func TestJWTMiddleware_InvalidSignatureSkipsRedis(t *testing.T) {
+	env := newJWTTestEnv(t)
+
+	now := time.Now()
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, utils.JWTClaims{
+		UserID:    uuid.NewString(),
+		Role:      "admin",
+		SessionID: uuid.NewString(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(utils.AccessTokenTTL)),
+		},
+	})
+	token, err := forged.SignedString([]byte("wrong-secret"))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	if code := env.do(token); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+	if n := env.mr.CommandCount(); n != 0 {
+		t.Fatalf("expected no redis lookup for invalid signature, got %d commands", n)
+	}
+}
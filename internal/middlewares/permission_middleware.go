@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"giat-cerika-service/configs"
+	"giat-cerika-service/pkg/constant/permission"
+	"giat-cerika-service/pkg/utils"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequirePermission memastikan role pada token (atau scopes API key) memiliki permission tertentu.
+// Dipasang setelah JWTMiddleware / JWTOrAPIKeyMiddleware; daftar permission role di-cache di Redis.
+func RequirePermission(code string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if apiKey, ok := utils.GetAPIKeyFromContext(c); ok {
+				if !slices.Contains(apiKey.Scopes, code) {
+					return echo.NewHTTPError(http.StatusForbidden, "forbidden: api key missing scope "+code)
+				}
+				return next(c)
+			}
+
+			claims, err := utils.GetClaimsFromContext(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			}
+
+			codes, err := rolePermissionCodes(c.Request().Context(), claims.Role)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to verify permission")
+			}
+
+			if !slices.Contains(codes, code) {
+				return echo.NewHTTPError(http.StatusForbidden, "forbidden: missing permission "+code)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func rolePermissionCodes(ctx context.Context, roleName string) ([]string, error) {
+	cacheKey := permission.CacheKey(roleName)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var codes []string
+		if json.Unmarshal([]byte(cached), &codes) == nil {
+			return codes, nil
+		}
+	}
+
+	codes := []string{}
+	err := configs.DB.WithContext(ctx).
+		Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", roleName).
+		Pluck("permissions.code", &codes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	buf, _ := json.Marshal(codes)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return codes, nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminInvite adalah undangan sekali pakai untuk mendaftarkan akun admin/staf dengan role tertentu.
+// Token mentah hanya diberikan saat undangan dibuat; yang disimpan hanya hash-nya.
+type AdminInvite struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	RoleID    uuid.UUID  `gorm:"type:uuid" json:"role_id"`
+	Role      Role       `gorm:"foreignKey:RoleID"`
+	Note      string     `gorm:"type:varchar(255)" json:"note"`
+	CreatedBy uuid.UUID  `gorm:"type:uuid;index" json:"created_by"`
+	ExpiresAt time.Time  `gorm:"index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	UsedBy    *uuid.UUID `gorm:"type:uuid" json:"used_by"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -8,10 +8,10 @@ import (
 
 type Alarm struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	UserID    uuid.UUID `gorm:"type:uuid"`
-	User      User      `gorm:"foreignKey:UserID"`
-	Morning   time.Time `json:"morning"`
-	Night     time.Time `json:"night"`
+	UserID    uuid.UUID `gorm:"type:uuid;uniqueIndex" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Morning   string    `gorm:"type:varchar(5);index" json:"morning"`
+	Night     string    `gorm:"type:varchar(5);index" json:"night"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -12,6 +12,8 @@ type Answer struct {
 	Question   Question  `gorm:"foreignKey:QuestionID"`
 	AnswerText string    `gorm:"type:text" json:"answer_text"`
 	ScoreValue int       `gorm:"type:int" json:"score_value"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// MatchText adalah pasangan yang benar untuk soal matching; kosong untuk tipe lain.
+	MatchText string    `gorm:"type:text" json:"match_text"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
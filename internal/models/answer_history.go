@@ -16,6 +16,14 @@ type AnswerHistory struct {
 	ScoreValue  int    `gorm:"type:int" json:"score_value"`
 	ScoreEarned int    `gorm:"type:int" json:"score_earned"`
 
+	// Selected menandai opsi yang dipilih siswa; untuk matching, MatchText adalah
+	// pasangan benar dan SubmittedMatch pasangan yang dipilih siswa.
+	Selected       bool   `gorm:"type:boolean;default:false" json:"selected"`
+	MatchText      string `gorm:"type:text" json:"match_text"`
+	SubmittedMatch string `gorm:"type:text" json:"submitted_match"`
+	// DisplayOrder adalah posisi opsi seperti yang dilihat siswa (0-based).
+	DisplayOrder int `gorm:"type:int;default:0" json:"display_order"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// APIKey dipakai sistem informasi sekolah untuk mengakses API tanpa akun manusia.
+// Key mentah hanya diberikan saat dibuat; yang disimpan hanya hash-nya. Scopes berisi kode permission.
+type APIKey struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name       string         `gorm:"type:varchar(255)" json:"name"`
+	Prefix     string         `gorm:"type:varchar(20);index" json:"prefix"`
+	KeyHash    string         `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	Scopes     pq.StringArray `gorm:"type:text[]" json:"scopes"`
+	CreatedBy  uuid.UUID      `gorm:"type:uuid;index" json:"created_by"`
+	ExpiresAt  time.Time      `gorm:"index" json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
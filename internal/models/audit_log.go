@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog mencatat satu mutasi administratif. Before/After hanya berisi field yang berubah
+// (untuk create/delete berisi seluruh data). Tabel ini hanya ditambah, tidak pernah diubah.
+type AuditLog struct {
+	ID         uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ActorID    *uuid.UUID      `gorm:"type:uuid;index" json:"actor_id"`
+	Actor      *User           `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	ActorRole  string          `gorm:"type:varchar(50)" json:"actor_role"`
+	Action     string          `gorm:"type:varchar(50);index" json:"action"`
+	EntityType string          `gorm:"type:varchar(50);index:idx_audit_entity" json:"entity_type"`
+	EntityID   string          `gorm:"type:varchar(64);index:idx_audit_entity" json:"entity_id"`
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after"`
+	IPAddress  string          `gorm:"type:varchar(64)" json:"ip_address"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -14,3 +14,12 @@ type Class struct {
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
+
+// ClassTeacher menghubungkan akun guru (role teacher) dengan kelas yang diampunya.
+type ClassTeacher struct {
+	ClassID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"class_id"`
+	Class     Class     `gorm:"foreignKey:ClassID;constraint:OnDelete:CASCADE;" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuardianStudent menghubungkan akun orang tua/wali (role guardian) dengan akun siswa.
+// LinkedVia mencatat cara verifikasi yang dipakai: "nisn_dob" atau "code".
+type GuardianStudent struct {
+	GuardianID uuid.UUID `gorm:"type:uuid;primaryKey" json:"guardian_id"`
+	Guardian   User      `gorm:"foreignKey:GuardianID;constraint:OnDelete:CASCADE;" json:"-"`
+	StudentID  uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"student_id"`
+	Student    User      `gorm:"foreignKey:StudentID;constraint:OnDelete:CASCADE;" json:"-"`
+	LinkedVia  string    `gorm:"type:varchar(20)" json:"linked_via"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginLockout mencatat setiap kali username atau IP dikunci sementara karena terlalu banyak login gagal.
+type LoginLockout struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Scope          string     `gorm:"type:varchar(20);index" json:"scope"`
+	Username       string     `gorm:"type:varchar(255);index" json:"username"`
+	UserID         *uuid.UUID `gorm:"type:uuid;index" json:"user_id"`
+	IPAddress      string     `gorm:"type:varchar(64);index" json:"ip_address"`
+	FailedAttempts int        `gorm:"type:int" json:"failed_attempts"`
+	LockedUntil    time.Time  `gorm:"index" json:"locked_until"`
+	UnlockedAt     *time.Time `json:"unlocked_at"`
+	UnlockedBy     *uuid.UUID `gorm:"type:uuid" json:"unlocked_by"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -6,14 +6,81 @@ import (
 	"github.com/google/uuid"
 )
 
+// QuestionType menentukan bentuk jawaban dan aturan penilaian soal.
+type QuestionType string
+
+const (
+	// QuestionSingleChoice: satu opsi dipilih, skor = score_value opsi tersebut.
+	QuestionSingleChoice QuestionType = "single_choice"
+	// QuestionMultiSelect: beberapa opsi boleh dipilih; skor parsial = jumlah score_value
+	// opsi terpilih, dibatasi 0..skor maksimum. Opsi salah bernilai 0 otomatis mengurangi skor
+	// (skor maksimum / jumlah opsi salah) atau memakai score_value negatifnya sendiri.
+	QuestionMultiSelect QuestionType = "multi_select"
+	// QuestionTrueFalse: dua opsi (benar/salah), dinilai seperti single choice.
+	QuestionTrueFalse QuestionType = "true_false"
+	// QuestionShortAnswer: jawaban teks dicocokkan ke daftar jawaban yang diterima
+	// (answer_text) tanpa membedakan huruf besar/kecil dan spasi berlebih.
+	QuestionShortAnswer QuestionType = "short_answer"
+	// QuestionMatching: tiap answer adalah pasangan answer_text -> match_text;
+	// skor = jumlah score_value pasangan yang dijodohkan dengan benar.
+	QuestionMatching QuestionType = "matching"
+)
+
+// IsValid memastikan tipe soal dikenal.
+func (t QuestionType) IsValid() bool {
+	switch t {
+	case QuestionSingleChoice, QuestionMultiSelect, QuestionTrueFalse, QuestionShortAnswer, QuestionMatching:
+		return true
+	}
+	return false
+}
+
+// IsChoice bernilai true untuk tipe yang jawabannya satu opsi (response.answer_id).
+func (t QuestionType) IsChoice() bool {
+	return t == "" || t == QuestionSingleChoice || t == QuestionTrueFalse
+}
+
+// MaxScore menghitung skor maksimum soal dari score_value opsinya sesuai tipe soal.
+func (t QuestionType) MaxScore(scoreValues []int) int {
+	maxScore := 0
+	switch t {
+	case QuestionMultiSelect, QuestionMatching:
+		for _, v := range scoreValues {
+			if v > 0 {
+				maxScore += v
+			}
+		}
+	default:
+		for _, v := range scoreValues {
+			if v > maxScore {
+				maxScore = v
+			}
+		}
+	}
+	return maxScore
+}
+
+type QuestionDifficulty string
+
+const (
+	QuestionDifficultyEasy   QuestionDifficulty = "easy"
+	QuestionDifficultyMedium QuestionDifficulty = "medium"
+	QuestionDifficultyHard   QuestionDifficulty = "hard"
+)
+
 type Question struct {
 	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	QuizID        uuid.UUID `gorm:"type:uuid;index" json:"quiz_id"`
 	Quiz          Quiz      `gorm:"foreignKey:QuizID"`
 	QuestionText  string    `gorm:"type:text" json:"question_text"`
 	QuestionImage string    `gorm:"type:varchar(255); null" json:"question_image"`
-	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// QuestionType default single_choice agar soal lama tetap dinilai seperti sebelumnya.
+	QuestionType QuestionType `gorm:"type:varchar(30);default:'single_choice'" json:"question_type"`
+	// Tag & Difficulty dipakai untuk undian bank soal berstrata; boleh kosong.
+	Tag        string             `gorm:"type:varchar(100);index" json:"tag"`
+	Difficulty QuestionDifficulty `gorm:"type:varchar(20)" json:"difficulty"`
+	CreatedAt  time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Answers []Answer `gorm:"constraint:OnDelete:CASCADE;"`
 }
@@ -15,6 +15,12 @@ type QuestionHistory struct {
 	QuestionText  string `gorm:"type:text" json:"question_text"`
 	QuestionImage string `gorm:"type:varchar(255); null" json:"question_image"`
 
+	// Snapshot penilaian per soal; SubmittedText hanya terisi untuk short answer.
+	QuestionType  QuestionType `gorm:"type:varchar(30);default:'single_choice'" json:"question_type"`
+	SubmittedText string       `gorm:"type:text" json:"submitted_text"`
+	ScoreEarned   int          `gorm:"type:int" json:"score_earned"`
+	MaxScore      int          `gorm:"type:int" json:"max_score"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuestionnaireType string
+
+const (
+	QuestionnairePengetahuan QuestionnaireType = "Pengetahuan"
+	QuestionnairePerilaku    QuestionnaireType = "Perilaku"
+)
+
+// Questionnaire adalah survei skala Likert. Siswa hanya bisa mengisi satu kali
+// untuk setiap periode (mis. PRE / POST); periode yang sedang dibuka diatur lewat ActivePeriod.
+type Questionnaire struct {
+	ID           uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code         string            `gorm:"type:varchar(255);index" json:"code"`
+	Title        string            `gorm:"type:varchar(255)" json:"title"`
+	Description  string            `gorm:"type:text" json:"description"`
+	Type         QuestionnaireType `gorm:"type:questionnaire_type;not null" json:"type"`
+	Status       int               `gorm:"type:int" json:"status"`
+	ActivePeriod string            `gorm:"type:varchar(50);default:'PRE'" json:"active_period"`
+	ScaleMin     int               `gorm:"type:int;default:1" json:"scale_min"`
+	ScaleMax     int               `gorm:"type:int;default:5" json:"scale_max"`
+	AmountItems  int               `gorm:"type:int" json:"amount_items"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Items []QuestionnaireItem `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// QuestionnaireItem adalah satu pernyataan Likert. IsReversed dipakai untuk
+// pernyataan negatif sehingga skornya dibalik (ScaleMax + ScaleMin - nilai).
+type QuestionnaireItem struct {
+	ID              uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	QuestionnaireID uuid.UUID     `gorm:"type:uuid;index" json:"questionnaire_id"`
+	Questionnaire   Questionnaire `gorm:"foreignKey:QuestionnaireID"`
+	Statement       string        `gorm:"type:text" json:"statement"`
+	ItemOrder       int           `gorm:"type:int" json:"item_order"`
+	IsReversed      bool          `gorm:"default:false" json:"is_reversed"`
+	CreatedAt       time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -13,6 +13,24 @@ const (
 	QuestionOrderRandom     QuestionOrderMode = "random"
 )
 
+// CorrectAnswerVisibility menentukan kapan kunci jawaban boleh terlihat siswa di riwayat soal.
+type CorrectAnswerVisibility string
+
+const (
+	CorrectAnswerAfterSubmit CorrectAnswerVisibility = "after_submit"
+	CorrectAnswerAfterClose  CorrectAnswerVisibility = "after_close"
+	CorrectAnswerNever       CorrectAnswerVisibility = "never"
+)
+
+// QuestionPoolStratify menentukan pengelompokan saat mengundi soal dari bank soal.
+type QuestionPoolStratify string
+
+const (
+	QuestionPoolStratifyNone       QuestionPoolStratify = ""
+	QuestionPoolStratifyTag        QuestionPoolStratify = "tag"
+	QuestionPoolStratifyDifficulty QuestionPoolStratify = "difficulty"
+)
+
 type Quiz struct {
 	ID                uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	QuizTypeID        uuid.UUID         `gorm:"type:uuid"`
@@ -26,8 +44,19 @@ type Quiz struct {
 	AmountQuestions   int               `gorm:"type:int" json:"amount_questions"`
 	AmountAssigned    int               `gorm:"type:int" json:"amount_assigned"`
 	QuestionOrderMode QuestionOrderMode `gorm:"type:varchar(50);default:'sequential'" json:"question_order_mode"`
-	CreatedAt         time.Time         `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	// CorrectAnswerVisibility default after_submit mempertahankan perilaku lama (kunci tampil setelah submit).
+	CorrectAnswerVisibility CorrectAnswerVisibility `gorm:"type:varchar(20);default:'after_submit'" json:"correct_answer_visibility"`
+	// DrawCount > 0 mengaktifkan bank soal: tiap sesi mendapat DrawCount soal acak,
+	// proporsional per tag/difficulty bila DrawStratifyBy diisi. 0 = semua soal.
+	DrawCount      int                  `gorm:"type:int;default:0" json:"draw_count"`
+	DrawStratifyBy QuestionPoolStratify `gorm:"type:varchar(20);default:''" json:"draw_stratify_by"`
+	// ShuffleAnswers mengacak urutan opsi per soal dengan seed id sesi.
+	ShuffleAnswers bool `gorm:"type:boolean;default:false" json:"shuffle_answers"`
+	// DurationMinutes > 0 memberi batas waktu tetap per percobaan; deadline sesi =
+	// min(started_at + durasi, end_date). 0 = sisa waktu sampai end_date seperti sebelumnya.
+	DurationMinutes int       `gorm:"type:int;default:0" json:"duration_minutes"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Questions []Question `gorm:"constraint:OnDelete:CASCADE;"`
 }
@@ -26,6 +26,8 @@ type QuizHistory struct {
 	StartedAt      *time.Time        `gorm:"type:timestamp" json:"started_at"`
 	CompletedAt    *time.Time        `gorm:"type:timestamp" json:"completed_at"`
 	StatusCategory int               `gorm:"type:int" json:"status_category"`
+	// CorrectAnswerVisibility adalah snapshot pengaturan quiz, dipakai bila quiz aslinya sudah dihapus.
+	CorrectAnswerVisibility CorrectAnswerVisibility `gorm:"type:varchar(20);default:'after_submit'" json:"correct_answer_visibility"`
 
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuizPair menghubungkan dua quiz sebagai pre-test dan post-test untuk analisis learning gain.
+type QuizPair struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Title       string    `gorm:"type:varchar(255)" json:"title"`
+	Description string    `gorm:"type:text" json:"description"`
+	PreQuizID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_quiz_pair" json:"pre_quiz_id"`
+	PreQuiz     Quiz      `gorm:"foreignKey:PreQuizID;constraint:OnDelete:CASCADE;"`
+	PostQuizID  uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_quiz_pair" json:"post_quiz_id"`
+	PostQuiz    Quiz      `gorm:"foreignKey:PostQuizID;constraint:OnDelete:CASCADE;"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
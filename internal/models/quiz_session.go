@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type QuizSessionStatus string
@@ -25,8 +26,14 @@ type QuizSession struct {
 	Status      QuizSessionStatus `gorm:"type:varchar(50);default:'started'" json:"status"`
 	StartedAt   *time.Time        `gorm:"type:timestamptz" json:"started_at"`
 	CompletedAt *time.Time        `gorm:"type:timestamptz" json:"completed_at"`
-	CreatedAt   time.Time         `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	// Deadline dihitung sekali saat quiz dimulai; Redis quiz_session:<id>:duration hanya cache-nya.
+	// nil berarti tanpa batas waktu (atau sesi belum dimulai).
+	Deadline *time.Time `gorm:"type:timestamptz" json:"deadline"`
+	// DrawnQuestionIDs adalah subset soal hasil undian bank soal untuk sesi ini;
+	// kosong berarti sesi memakai seluruh soal quiz.
+	DrawnQuestionIDs pq.StringArray `gorm:"type:text[]" json:"drawn_question_ids"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Responses []Response `gorm:"constraint:OnDelete:CASCADE;"`
 }
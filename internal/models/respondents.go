@@ -1,17 +1,35 @@
 package models
 
-// type Respondents struct {
-// 	ID          uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-// 	UserID      uuid.UUID     `gorm:"type:uuid"`
-// 	DetailID    uuid.UUID     `gorm:"type:uuid"`
-// 	KuisionerID uuid.UUID     `gorm:"type:uuid"`
-// 	Status      int           `gorm:"type:int" json:"status"`
-// 	Duration    string        `gorm:"type:varchar(100)" json:"duration"`
-// 	Answer      string        `gorm:"type:text" json:"answer"`
-// 	Score       string        `gorm:"type:varchar(50)" json:"score"`
-// 	User        User          `gorm:"foreignKey:UserID"`
-// 	Detail      Detail        `gorm:"foreignKey:DetailID"`
-// 	Kuisioner   Questionnaire `gorm:"foreignKey:KuisionerID"`
-// 	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
-// 	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
-// }
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Respondents menyimpan satu kali pengisian kuesioner oleh siswa pada satu periode.
+// ClassID disalin saat pengisian agar agregasi per kelas tidak berubah bila siswa pindah kelas.
+type Respondents struct {
+	ID              uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID          uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_respondent_period" json:"user_id"`
+	User            User          `gorm:"foreignKey:UserID"`
+	QuestionnaireID uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_respondent_period" json:"questionnaire_id"`
+	Questionnaire   Questionnaire `gorm:"foreignKey:QuestionnaireID;constraint:OnDelete:CASCADE;"`
+	Period          string        `gorm:"type:varchar(50);uniqueIndex:idx_respondent_period" json:"period"`
+	ClassID         *uuid.UUID    `gorm:"type:uuid;index" json:"class_id"`
+	TotalScore      int           `gorm:"type:int" json:"total_score"`
+	AverageScore    float64       `gorm:"type:decimal(5,2)" json:"average_score"`
+	CreatedAt       time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Answers []RespondentAnswer `gorm:"foreignKey:RespondentID;constraint:OnDelete:CASCADE;"`
+}
+
+type RespondentAnswer struct {
+	ID           uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	RespondentID uuid.UUID         `gorm:"type:uuid;index" json:"respondent_id"`
+	ItemID       uuid.UUID         `gorm:"type:uuid;index" json:"item_id"`
+	Item         QuestionnaireItem `gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE;"`
+	Value        int               `gorm:"type:int" json:"value"`
+	Score        int               `gorm:"type:int" json:"score"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,7 +14,10 @@ type Response struct {
 	QuestionID    uuid.UUID   `gorm:"type:uuid;index" json:"question_id"`
 	Question      Question    `gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE;"`
 	AnswerID      *uuid.UUID  `gorm:"type:uuid; null" json:"answer_id"`
-	ScoreEarned   int         `gorm:"type:int" json:"score_earned"`
-	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	// AnswerDetail menyimpan jawaban mentah untuk tipe selain pilihan tunggal
+	// (answer_ids multi select, text short answer, pairs matching).
+	AnswerDetail json.RawMessage `gorm:"type:jsonb" json:"answer_detail"`
+	ScoreEarned  int             `gorm:"type:int" json:"score_earned"`
+	CreatedAt    time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -7,11 +7,18 @@ import (
 )
 
 type ToootBrushLog struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	UserID    uuid.UUID `gorm:"not null" json:"user_id"`
-	User      User      `gorm:"foreignKey:UserID"`
-	TimeType  string    `gorm:"type:varchar(100);not null" json:"time_type"`
-	LogDate   string    `gorm:"type:date;not null" json:"log_date"`
-	LogTime   time.Time `gorm:"type:time;not null" json:"log_time"`
-	CreatedAt time.Time `json:"created_at"`
+	ID       uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID   uuid.UUID `gorm:"not null" json:"user_id"`
+	User     User      `gorm:"foreignKey:UserID"`
+	TimeType string    `gorm:"type:varchar(100);not null" json:"time_type"`
+	LogDate  string    `gorm:"type:date;not null" json:"log_date"`
+	LogTime  time.Time `gorm:"type:time;not null" json:"log_time"`
+	IsLate   bool      `gorm:"default:false" json:"is_late"`
+	PhotoURL *string   `gorm:"type:varchar(255)" json:"photo_url"`
+	// ReviewStatus: PENDING (belum ditinjau), APPROVED, FLAGGED (mencurigakan)
+	ReviewStatus string     `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"review_status"`
+	ReviewNote   *string    `gorm:"type:text" json:"review_note"`
+	ReviewedBy   *uuid.UUID `gorm:"type:uuid" json:"reviewed_by"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kode sesi bawaan hasil seed. Kolom alarm (morning/night) dan field respons lama terikat ke kode ini,
+// karena itu kode sesi tidak bisa diubah setelah dibuat.
+const (
+	ToothBrushSessionMorning = "MORNING"
+	ToothBrushSessionNight   = "NIGHT"
+)
+
+// ToothBrushSession adalah jenis sesi absen sikat gigi (mis. MORNING, NIGHT) beserta jendela waktunya (Asia/Jakarta).
+type ToothBrushSession struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code         string    `gorm:"type:varchar(100);uniqueIndex" json:"code"`
+	Name         string    `gorm:"type:varchar(255)" json:"name"`
+	StartTime    string    `gorm:"type:varchar(5)" json:"start_time"`
+	EndTime      string    `gorm:"type:varchar(5)" json:"end_time"`
+	GraceMinutes int       `gorm:"type:int;default:0" json:"grace_minutes"`
+	IsActive     bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	ClassOverrides []ToothBrushSessionOverride `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"class_overrides"`
+}
+
+// ToothBrushSessionOverride menggantikan jendela waktu sebuah sesi untuk kelas tertentu.
+type ToothBrushSessionOverride struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	SessionID    uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_session_class" json:"session_id"`
+	ClassID      uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_session_class" json:"class_id"`
+	Class        Class     `gorm:"foreignKey:ClassID;constraint:OnDelete:CASCADE;" json:"class"`
+	StartTime    string    `gorm:"type:varchar(5)" json:"start_time"`
+	EndTime      string    `gorm:"type:varchar(5)" json:"end_time"`
+	GraceMinutes *int      `gorm:"type:int" json:"grace_minutes"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
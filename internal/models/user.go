@@ -20,6 +20,9 @@ type User struct {
 	Role        Role       `gorm:"foreignKey:RoleID"`
 	Class       Class      `gorm:"foreignKey:ClassID"`
 	Status      int        `gorm:"type:int;" json:"status"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	TokenEpoch  int        `gorm:"type:int;not null;default:0" json:"-"`
+	// MustChangePassword diisi untuk akun dengan password sementara (hasil impor).
+	MustChangePassword bool      `gorm:"not null;default:false" json:"must_change_password"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
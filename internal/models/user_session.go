@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSession adalah satu sesi login per perangkat. Refresh token hanya disimpan dalam bentuk hash
+// dan dirotasi setiap kali dipakai; hash sebelumnya disimpan untuk mendeteksi pemakaian ulang.
+type UserSession struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID              uuid.UUID  `gorm:"type:uuid;index" json:"user_id"`
+	User                User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	RefreshTokenHash    string     `gorm:"type:varchar(64);index" json:"-"`
+	PreviousRefreshHash string     `gorm:"type:varchar(64)" json:"-"`
+	UserAgent           string     `gorm:"type:varchar(512)" json:"user_agent"`
+	IPAddress           string     `gorm:"type:varchar(64)" json:"ip_address"`
+	LastSeenAt          time.Time  `json:"last_seen_at"`
+	ExpiresAt           time.Time  `gorm:"index" json:"expires_at"`
+	RevokedAt           *time.Time `json:"revoked_at"`
+	RevokeReason        string     `gorm:"type:varchar(50)" json:"revoke_reason"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,82 @@
+package adminrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AdminInviteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAdminInviteRepositoryImpl(db *gorm.DB) IAdminInviteRepository {
+	return &AdminInviteRepositoryImpl{db: db}
+}
+
+// Create implements IAdminInviteRepository.
+func (a *AdminInviteRepositoryImpl) Create(ctx context.Context, data *models.AdminInvite) error {
+	return a.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByID implements IAdminInviteRepository.
+func (a *AdminInviteRepositoryImpl) FindByID(ctx context.Context, inviteId uuid.UUID) (*models.AdminInvite, error) {
+	var invite models.AdminInvite
+	if err := a.db.WithContext(ctx).Preload("Role").First(&invite, "id = ?", inviteId).Error; err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// FindByTokenHash implements IAdminInviteRepository.
+func (a *AdminInviteRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*models.AdminInvite, error) {
+	var invite models.AdminInvite
+	if err := a.db.WithContext(ctx).Preload("Role").First(&invite, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// FindAll implements IAdminInviteRepository.
+func (a *AdminInviteRepositoryImpl) FindAll(ctx context.Context, status string, limit, offset int) ([]*models.AdminInvite, int, error) {
+	var (
+		invites []*models.AdminInvite
+		count   int64
+	)
+
+	now := time.Now()
+	query := a.db.WithContext(ctx).Model(&models.AdminInvite{})
+	switch status {
+	case "pending":
+		query = query.Where("used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+	case "used":
+		query = query.Where("used_at IS NOT NULL")
+	case "revoked":
+		query = query.Where("revoked_at IS NOT NULL")
+	case "expired":
+		query = query.Where("used_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", now)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Role").Order("created_at DESC").Limit(limit).Offset(offset).Find(&invites).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return invites, int(count), nil
+}
+
+// Revoke implements IAdminInviteRepository.
+func (a *AdminInviteRepositoryImpl) Revoke(ctx context.Context, inviteId uuid.UUID) error {
+	return a.db.WithContext(ctx).
+		Model(&models.AdminInvite{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", inviteId).
+		Update("revoked_at", time.Now()).Error
+}
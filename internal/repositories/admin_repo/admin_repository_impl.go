@@ -31,6 +31,20 @@ func (a *AdminRepositoryImpl) FindUsername(ctx context.Context, username string)
 	return &user, nil
 }
 
+// CountAdmins implements IAdminRepository.
+func (a *AdminRepositoryImpl) CountAdmins(ctx context.Context) (int, error) {
+	var count int64
+	if err := a.db.WithContext(ctx).
+		Model(&models.User{}).
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Where("roles.name = ?", "admin").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
 // FindRoleAdmin implements IAdminRepository.
 func (a *AdminRepositoryImpl) FindRoleAdmin(ctx context.Context) (*models.Role, error) {
 	var role models.Role
@@ -43,7 +57,8 @@ func (a *AdminRepositoryImpl) FindRoleAdmin(ctx context.Context) (*models.Role,
 
 // UpdatePhotoAdmin implements IAdminRepository.
 func (a *AdminRepositoryImpl) UpdatePhotoAdmin(ctx context.Context, adminID uuid.UUID, photo string) error {
-	subQuery := a.db.Select("id").Where("name = ?", "admin").Table("roles")
+	// semua akun staf (role selain student) memakai endpoint admin
+	subQuery := a.db.Select("id").Where("name <> ?", "student").Table("roles")
 	return a.db.WithContext(ctx).Model(&models.User{}).
 		Where("id = ?", adminID).
 		Where("role_id IN (?)", subQuery).
@@ -60,12 +75,13 @@ func (a *AdminRepositoryImpl) FindByAdminID(ctx context.Context, adminID uuid.UU
 	return &user, nil
 }
 
-// FindSuperAdmin implements IAdminRepository.
+// FindAdmin implements IAdminRepository.
+// Akses sudah dijaga permission, jadi role staf apa pun (guru, UKS, dokter gigi) dianggap admin di sini.
 func (a *AdminRepositoryImpl) FindAdmin(ctx context.Context, adminId uuid.UUID) (*models.User, error) {
 	var admin models.User
 	if err := a.db.WithContext(ctx).
 		Joins("LEFT JOIN roles ON roles.id = users.role_id").
-		Where("users.id = ? AND roles.name = ?", adminId, "admin").
+		Where("users.id = ? AND roles.name <> ?", adminId, "student").
 		Preload("Role").
 		First(&admin).Error; err != nil {
 		return nil, err
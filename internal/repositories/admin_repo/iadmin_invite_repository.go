@@ -0,0 +1,17 @@
+package adminrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAdminInviteRepository interface {
+	Create(ctx context.Context, data *models.AdminInvite) error
+	FindByID(ctx context.Context, inviteId uuid.UUID) (*models.AdminInvite, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.AdminInvite, error)
+	// FindAll mendukung filter status: pending, used, revoked, expired (kosong = semua).
+	FindAll(ctx context.Context, status string, limit, offset int) ([]*models.AdminInvite, int, error)
+	Revoke(ctx context.Context, inviteId uuid.UUID) error
+}
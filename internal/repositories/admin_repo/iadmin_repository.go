@@ -11,6 +11,8 @@ type IAdminRepository interface {
 	Create(ctx context.Context, data *models.User) error
 	FindUsername(ctx context.Context, username string) (*models.User, error)
 	FindRoleAdmin(ctx context.Context) (*models.Role, error)
+	// CountAdmins menghitung user ber-role admin; dipakai untuk bootstrap admin pertama.
+	CountAdmins(ctx context.Context) (int, error)
 	UpdatePhotoAdmin(ctx context.Context, adminID uuid.UUID, photo string) error
 
 	FindByAdminID(ctx context.Context, adminID uuid.UUID) (*models.User, error)
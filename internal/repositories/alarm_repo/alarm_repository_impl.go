@@ -0,0 +1,68 @@
+package alarmrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AlarmRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAlarmRepositoryImpl(db *gorm.DB) IAlarmRepository {
+	return &AlarmRepositoryImpl{db: db}
+}
+
+// Create implements IAlarmRepository.
+func (a *AlarmRepositoryImpl) Create(ctx context.Context, data *models.Alarm) error {
+	return a.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByUserID implements IAlarmRepository.
+func (a *AlarmRepositoryImpl) FindByUserID(ctx context.Context, userId uuid.UUID) (*models.Alarm, error) {
+	var alarm models.Alarm
+	if err := a.db.WithContext(ctx).First(&alarm, "user_id = ?", userId).Error; err != nil {
+		return nil, err
+	}
+
+	return &alarm, nil
+}
+
+// Update implements IAlarmRepository.
+func (a *AlarmRepositoryImpl) Update(ctx context.Context, alarmId uuid.UUID, data *models.Alarm) error {
+	return a.db.WithContext(ctx).
+		Model(&models.Alarm{}).
+		Where("id = ?", alarmId).
+		Updates(map[string]interface{}{
+			"morning": data.Morning,
+			"night":   data.Night,
+		}).Error
+}
+
+// FindDueAlarms implements IAlarmRepository.
+func (a *AlarmRepositoryImpl) FindDueAlarms(ctx context.Context, timeType string, clock string) ([]*models.Alarm, error) {
+	// alarm siswa hanya punya jam pagi & malam; sesi lain belum mendukung alarm
+	var column string
+	switch timeType {
+	case models.ToothBrushSessionMorning:
+		column = "morning"
+	case models.ToothBrushSessionNight:
+		column = "night"
+	default:
+		return []*models.Alarm{}, nil
+	}
+
+	var alarms []*models.Alarm
+	if err := a.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = alarms.user_id").
+		Where("alarms."+column+" = ?", clock).
+		Where("users.status = ?", 1).
+		Find(&alarms).Error; err != nil {
+		return nil, err
+	}
+
+	return alarms, nil
+}
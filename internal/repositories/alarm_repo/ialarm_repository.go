@@ -0,0 +1,17 @@
+package alarmrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAlarmRepository interface {
+	Create(ctx context.Context, data *models.Alarm) error
+	FindByUserID(ctx context.Context, userId uuid.UUID) (*models.Alarm, error)
+	Update(ctx context.Context, alarmId uuid.UUID, data *models.Alarm) error
+
+	// FindDueAlarms mengambil alarm yang jam sesi (MORNING/NIGHT)-nya sama dengan clock (format HH:MM).
+	FindDueAlarms(ctx context.Context, timeType string, clock string) ([]*models.Alarm, error)
+}
@@ -0,0 +1,70 @@
+package apikeyrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepositoryImpl(db *gorm.DB) IAPIKeyRepository {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+// Create implements IAPIKeyRepository.
+func (a *APIKeyRepositoryImpl) Create(ctx context.Context, data *models.APIKey) error {
+	return a.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByID implements IAPIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindByID(ctx context.Context, keyId uuid.UUID) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := a.db.WithContext(ctx).First(&key, "id = ?", keyId).Error; err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// FindAll implements IAPIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindAll(ctx context.Context, status string, limit, offset int) ([]*models.APIKey, int, error) {
+	var (
+		keys  []*models.APIKey
+		count int64
+	)
+
+	now := time.Now()
+	query := a.db.WithContext(ctx).Model(&models.APIKey{})
+	switch status {
+	case "active":
+		query = query.Where("revoked_at IS NULL AND expires_at > ?", now)
+	case "revoked":
+		query = query.Where("revoked_at IS NOT NULL")
+	case "expired":
+		query = query.Where("revoked_at IS NULL AND expires_at <= ?", now)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&keys).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return keys, int(count), nil
+}
+
+// Revoke implements IAPIKeyRepository.
+func (a *APIKeyRepositoryImpl) Revoke(ctx context.Context, keyId uuid.UUID) error {
+	return a.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", keyId).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,16 @@
+package apikeyrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, data *models.APIKey) error
+	FindByID(ctx context.Context, keyId uuid.UUID) (*models.APIKey, error)
+	// FindAll mendukung filter status: active, revoked, expired (kosong = semua).
+	FindAll(ctx context.Context, status string, limit, offset int) ([]*models.APIKey, int, error)
+	Revoke(ctx context.Context, keyId uuid.UUID) error
+}
@@ -0,0 +1,58 @@
+package auditrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepositoryImpl(db *gorm.DB) IAuditRepository {
+	return &AuditRepositoryImpl{db: db}
+}
+
+// Create implements IAuditRepository.
+func (a *AuditRepositoryImpl) Create(ctx context.Context, data *models.AuditLog) error {
+	return a.db.WithContext(ctx).Create(data).Error
+}
+
+// FindAll implements IAuditRepository.
+func (a *AuditRepositoryImpl) FindAll(ctx context.Context, filter AuditLogFilter, limit int, offset int) ([]*models.AuditLog, int, error) {
+	var (
+		logs  []*models.AuditLog
+		count int64
+	)
+
+	query := a.db.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("created_at < ?", *filter.EndDate)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Preload("Actor").Order("created_at DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, int(count), nil
+}
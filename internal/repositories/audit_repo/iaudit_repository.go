@@ -0,0 +1,24 @@
+package auditrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogFilter adalah filter pencarian audit log; field kosong/nil diabaikan.
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	StartDate  *time.Time
+	EndDate    *time.Time
+}
+
+type IAuditRepository interface {
+	Create(ctx context.Context, data *models.AuditLog) error
+	FindAll(ctx context.Context, filter AuditLogFilter, limit, offset int) ([]*models.AuditLog, int, error)
+}
@@ -3,6 +3,7 @@ package classrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,6 +17,12 @@ func NewClassRepositoryImpl(db *gorm.DB) IClassRepository {
 	return &ClassRepositoryImpl{db: db}
 }
 
+// TeacherClassSubQuery menghasilkan subquery class_id yang diampu seorang guru,
+// dipakai repository lain untuk membatasi data siswa milik guru tersebut.
+func TeacherClassSubQuery(db *gorm.DB, teacherId uuid.UUID) *gorm.DB {
+	return db.Model(&models.ClassTeacher{}).Select("class_id").Where("user_id = ?", teacherId)
+}
+
 // Create implements IClassRepository.
 func (c *ClassRepositoryImpl) Create(ctx context.Context, data *models.Class) error {
 	return c.db.WithContext(ctx).Create(data).Error
@@ -32,7 +39,7 @@ func (c *ClassRepositoryImpl) FindByNameClass(ctx context.Context, NameClass str
 }
 
 // FindAll implements IClassRepository.
-func (c *ClassRepositoryImpl) FindAll(ctx context.Context, limit int, offset int, search string) ([]*models.Class, int, error) {
+func (c *ClassRepositoryImpl) FindAll(ctx context.Context, limit int, offset int, search string, teacherId *uuid.UUID) ([]*models.Class, int, error) {
 	var (
 		classes []*models.Class
 		count   int64
@@ -42,6 +49,9 @@ func (c *ClassRepositoryImpl) FindAll(ctx context.Context, limit int, offset int
 	if search != "" {
 		query = query.Where("name_class ILIKE ?", "%"+search+"%")
 	}
+	if teacherId != nil {
+		query = query.Where("id IN (?)", TeacherClassSubQuery(c.db, *teacherId))
+	}
 	if err := query.Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
@@ -80,3 +90,113 @@ func (c *ClassRepositoryImpl) GetAllPublic(ctx context.Context) ([]*models.Class
 	}
 	return classes, nil
 }
+
+// FindStudentsByClass implements IClassRepository.
+func (c *ClassRepositoryImpl) FindStudentsByClass(ctx context.Context, classId *uuid.UUID, teacherId *uuid.UUID) ([]*models.User, error) {
+	var students []*models.User
+
+	query := c.db.WithContext(ctx).
+		Model(&models.User{}).
+		Preload("Class").
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Where("roles.name = ?", "student")
+
+	if classId != nil {
+		query = query.Where("users.class_id = ?", *classId)
+	}
+	if teacherId != nil {
+		query = query.Where("users.class_id IN (?)", TeacherClassSubQuery(c.db, *teacherId))
+	}
+
+	if err := query.Order("users.name ASC").Find(&students).Error; err != nil {
+		return nil, err
+	}
+
+	return students, nil
+}
+
+// FindToothBrushLogsByClass implements IClassRepository.
+func (c *ClassRepositoryImpl) FindToothBrushLogsByClass(ctx context.Context, classId *uuid.UUID, teacherId *uuid.UUID, startDate, endDate time.Time) ([]*models.ToootBrushLog, error) {
+	var logs []*models.ToootBrushLog
+
+	query := c.db.WithContext(ctx).
+		Model(&models.ToootBrushLog{}).
+		Where("log_date >= ? AND log_date <= ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	if classId != nil {
+		subQuery := c.db.Model(&models.User{}).Select("id").Where("class_id = ?", *classId)
+		query = query.Where("user_id IN (?)", subQuery)
+	}
+	if teacherId != nil {
+		subQuery := c.db.Model(&models.User{}).Select("id").Where("class_id IN (?)", TeacherClassSubQuery(c.db, *teacherId))
+		query = query.Where("user_id IN (?)", subQuery)
+	}
+
+	if err := query.Order("log_date ASC").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FindTeachersByClass implements IClassRepository.
+func (c *ClassRepositoryImpl) FindTeachersByClass(ctx context.Context, classId uuid.UUID) ([]*models.User, error) {
+	var teachers []*models.User
+	if err := c.db.WithContext(ctx).
+		Model(&models.User{}).
+		Joins("JOIN class_teachers ON class_teachers.user_id = users.id").
+		Where("class_teachers.class_id = ?", classId).
+		Order("users.username ASC").
+		Find(&teachers).Error; err != nil {
+		return nil, err
+	}
+
+	return teachers, nil
+}
+
+// FindTeachersByIds implements IClassRepository.
+func (c *ClassRepositoryImpl) FindTeachersByIds(ctx context.Context, teacherIds []uuid.UUID) ([]*models.User, error) {
+	if len(teacherIds) == 0 {
+		return []*models.User{}, nil
+	}
+
+	var teachers []*models.User
+	if err := c.db.WithContext(ctx).
+		Model(&models.User{}).
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Where("roles.name = ? AND users.id IN ?", "teacher", teacherIds).
+		Find(&teachers).Error; err != nil {
+		return nil, err
+	}
+
+	return teachers, nil
+}
+
+// ReplaceTeachers implements IClassRepository.
+func (c *ClassRepositoryImpl) ReplaceTeachers(ctx context.Context, classId uuid.UUID, teacherIds []uuid.UUID) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("class_id = ?", classId).Delete(&models.ClassTeacher{}).Error; err != nil {
+			return err
+		}
+		if len(teacherIds) == 0 {
+			return nil
+		}
+
+		links := make([]models.ClassTeacher, len(teacherIds))
+		for i, teacherId := range teacherIds {
+			links[i] = models.ClassTeacher{ClassID: classId, UserID: teacherId}
+		}
+		return tx.Omit("Class", "User").Create(&links).Error
+	})
+}
+
+// IsClassTeacher implements IClassRepository.
+func (c *ClassRepositoryImpl) IsClassTeacher(ctx context.Context, classId uuid.UUID, teacherId uuid.UUID) (bool, error) {
+	var count int64
+	if err := c.db.WithContext(ctx).Model(&models.ClassTeacher{}).
+		Where("class_id = ? AND user_id = ?", classId, teacherId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -3,6 +3,7 @@ package classrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,10 +11,25 @@ import (
 type IClassRepository interface {
 	Create(ctx context.Context, data *models.Class) error
 	FindByNameClass(ctx context.Context, NameClass string) (*models.Class, error)
-	FindAll(ctx context.Context, limit, offset int, search string) ([]*models.Class, int, error)
+	// FindAll mengambil kelas dengan paginasi; teacherId non-nil membatasi ke kelas yang diampu guru tersebut.
+	FindAll(ctx context.Context, limit, offset int, search string, teacherId *uuid.UUID) ([]*models.Class, int, error)
 	FindById(ctx context.Context, classId uuid.UUID) (*models.Class, error)
 	Update(ctx context.Context, classId uuid.UUID, data *models.Class) error
 	Delete(ctx context.Context, classId uuid.UUID) error
 
 	GetAllPublic(ctx context.Context) ([]*models.Class, error)
+
+	// FindStudentsByClass mengambil siswa pada satu kelas (nil = semua kelas, dibatasi teacherId bila diisi).
+	FindStudentsByClass(ctx context.Context, classId *uuid.UUID, teacherId *uuid.UUID) ([]*models.User, error)
+	// FindTeachersByClass mengambil akun guru yang mengampu kelas.
+	FindTeachersByClass(ctx context.Context, classId uuid.UUID) ([]*models.User, error)
+	// FindTeachersByIds hanya mengembalikan user dengan role teacher.
+	FindTeachersByIds(ctx context.Context, teacherIds []uuid.UUID) ([]*models.User, error)
+	ReplaceTeachers(ctx context.Context, classId uuid.UUID, teacherIds []uuid.UUID) error
+	// IsClassTeacher mengecek apakah guru mengampu kelas tersebut.
+	IsClassTeacher(ctx context.Context, classId uuid.UUID, teacherId uuid.UUID) (bool, error)
+
+	// FindToothBrushLogsByClass mengambil log sikat gigi siswa pada kelas & rentang tanggal
+	// (classId nil = semua kelas, dibatasi teacherId bila diisi).
+	FindToothBrushLogsByClass(ctx context.Context, classId *uuid.UUID, teacherId *uuid.UUID, startDate, endDate time.Time) ([]*models.ToootBrushLog, error)
 }
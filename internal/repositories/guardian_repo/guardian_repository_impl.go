@@ -0,0 +1,89 @@
+package guardianrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GuardianRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewGuardianRepositoryImpl(db *gorm.DB) IGuardianRepository {
+	return &GuardianRepositoryImpl{db: db}
+}
+
+// Create implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) Create(ctx context.Context, data *models.User) error {
+	return g.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByUsername implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	var guardian models.User
+	if err := g.db.WithContext(ctx).Preload("Role").First(&guardian, "username = ?", username).Error; err != nil {
+		return nil, err
+	}
+
+	return &guardian, nil
+}
+
+// FindById implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) FindById(ctx context.Context, guardianId uuid.UUID) (*models.User, error) {
+	var guardian models.User
+	if err := g.db.WithContext(ctx).Preload("Role").First(&guardian, "id = ?", guardianId).Error; err != nil {
+		return nil, err
+	}
+
+	return &guardian, nil
+}
+
+// FindRoleGuardian implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) FindRoleGuardian(ctx context.Context) (*models.Role, error) {
+	var role models.Role
+	if err := g.db.WithContext(ctx).First(&role, "name = ?", "guardian").Error; err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// LinkStudent implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) LinkStudent(ctx context.Context, data *models.GuardianStudent) error {
+	return g.db.WithContext(ctx).Omit("Guardian", "Student").Clauses(clause.OnConflict{DoNothing: true}).Create(data).Error
+}
+
+// IsLinked implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) IsLinked(ctx context.Context, guardianId, studentId uuid.UUID) (bool, error) {
+	var count int64
+	if err := g.db.WithContext(ctx).Model(&models.GuardianStudent{}).
+		Where("guardian_id = ? AND student_id = ?", guardianId, studentId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// FindLinkedStudents implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) FindLinkedStudents(ctx context.Context, guardianId uuid.UUID) ([]*models.User, error) {
+	var students []*models.User
+	if err := g.db.WithContext(ctx).Preload("Class").
+		Where("id IN (?)", g.db.Model(&models.GuardianStudent{}).Select("student_id").Where("guardian_id = ?", guardianId)).
+		Order("name ASC").
+		Find(&students).Error; err != nil {
+		return nil, err
+	}
+
+	return students, nil
+}
+
+// UnlinkStudent implements IGuardianRepository.
+func (g *GuardianRepositoryImpl) UnlinkStudent(ctx context.Context, guardianId, studentId uuid.UUID) (int64, error) {
+	result := g.db.WithContext(ctx).Where("guardian_id = ? AND student_id = ?", guardianId, studentId).Delete(&models.GuardianStudent{})
+	return result.RowsAffected, result.Error
+}
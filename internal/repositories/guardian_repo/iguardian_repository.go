@@ -0,0 +1,22 @@
+package guardianrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IGuardianRepository interface {
+	Create(ctx context.Context, data *models.User) error
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	FindById(ctx context.Context, guardianId uuid.UUID) (*models.User, error)
+	FindRoleGuardian(ctx context.Context) (*models.Role, error)
+
+	// LinkStudent menghubungkan wali dengan siswa; link yang sudah ada tidak diubah.
+	LinkStudent(ctx context.Context, data *models.GuardianStudent) error
+	IsLinked(ctx context.Context, guardianId, studentId uuid.UUID) (bool, error)
+	// FindLinkedStudents mengambil seluruh siswa yang terhubung dengan wali beserta kelasnya.
+	FindLinkedStudents(ctx context.Context, guardianId uuid.UUID) ([]*models.User, error)
+	UnlinkStudent(ctx context.Context, guardianId, studentId uuid.UUID) (int64, error)
+}
@@ -0,0 +1,15 @@
+package loginlockoutrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type ILoginLockoutRepository interface {
+	Create(ctx context.Context, data *models.LoginLockout) error
+	FindAll(ctx context.Context, search string, activeOnly bool, limit, offset int) ([]*models.LoginLockout, int, error)
+	// MarkUnlocked menandai kunci yang masih aktif untuk username/IP sebagai dibuka oleh admin.
+	MarkUnlocked(ctx context.Context, scope, value string, adminId uuid.UUID) error
+}
@@ -0,0 +1,62 @@
+package loginlockoutrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoginLockoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLoginLockoutRepositoryImpl(db *gorm.DB) ILoginLockoutRepository {
+	return &LoginLockoutRepositoryImpl{db: db}
+}
+
+// Create implements ILoginLockoutRepository.
+func (l *LoginLockoutRepositoryImpl) Create(ctx context.Context, data *models.LoginLockout) error {
+	return l.db.WithContext(ctx).Create(data).Error
+}
+
+// FindAll implements ILoginLockoutRepository.
+func (l *LoginLockoutRepositoryImpl) FindAll(ctx context.Context, search string, activeOnly bool, limit, offset int) ([]*models.LoginLockout, int, error) {
+	var (
+		lockouts []*models.LoginLockout
+		count    int64
+	)
+
+	query := l.db.WithContext(ctx).Model(&models.LoginLockout{})
+	if search != "" {
+		query = query.Where("username ILIKE ? OR ip_address ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if activeOnly {
+		query = query.Where("unlocked_at IS NULL AND locked_until > ?", time.Now())
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&lockouts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return lockouts, int(count), nil
+}
+
+// MarkUnlocked implements ILoginLockoutRepository.
+func (l *LoginLockoutRepositoryImpl) MarkUnlocked(ctx context.Context, scope, value string, adminId uuid.UUID) error {
+	column := "username"
+	if scope == "ip" {
+		column = "ip_address"
+	}
+
+	now := time.Now()
+	return l.db.WithContext(ctx).
+		Model(&models.LoginLockout{}).
+		Where("scope = ? AND "+column+" = ? AND unlocked_at IS NULL AND locked_until > ?", scope, value, now).
+		Updates(map[string]any{"unlocked_at": now, "unlocked_by": adminId}).Error
+}
@@ -0,0 +1,21 @@
+package permissionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IPermissionRepository interface {
+	Create(ctx context.Context, data *models.Permission) error
+	FindByCode(ctx context.Context, code string) (*models.Permission, error)
+	FindAll(ctx context.Context, limit, offset int, search string) ([]*models.Permission, int, error)
+	FindById(ctx context.Context, permissionId uuid.UUID) (*models.Permission, error)
+	FindByIds(ctx context.Context, permissionIds []uuid.UUID) ([]models.Permission, error)
+	Update(ctx context.Context, data *models.Permission) error
+	Delete(ctx context.Context, permissionId uuid.UUID) error
+
+	FindRoleWithPermissions(ctx context.Context, roleId uuid.UUID) (*models.Role, error)
+	ReplaceRolePermissions(ctx context.Context, role *models.Role, permissions []models.Permission) error
+}
@@ -0,0 +1,109 @@
+package permissionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PermissionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepositoryImpl(db *gorm.DB) IPermissionRepository {
+	return &PermissionRepositoryImpl{db: db}
+}
+
+// Create implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) Create(ctx context.Context, data *models.Permission) error {
+	return p.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByCode implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) FindByCode(ctx context.Context, code string) (*models.Permission, error) {
+	var permission models.Permission
+	if err := p.db.WithContext(ctx).First(&permission, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// FindAll implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) FindAll(ctx context.Context, limit int, offset int, search string) ([]*models.Permission, int, error) {
+	var (
+		permissions []*models.Permission
+		count       int64
+	)
+
+	query := p.db.WithContext(ctx).Model(&models.Permission{})
+	if search != "" {
+		query = query.Where("code ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Offset(offset).Limit(limit).Order("code ASC").Find(&permissions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return permissions, int(count), nil
+}
+
+// FindById implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) FindById(ctx context.Context, permissionId uuid.UUID) (*models.Permission, error) {
+	var permission models.Permission
+	if err := p.db.WithContext(ctx).First(&permission, "id = ?", permissionId).Error; err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// FindByIds implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) FindByIds(ctx context.Context, permissionIds []uuid.UUID) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(permissionIds) == 0 {
+		return permissions, nil
+	}
+	if err := p.db.WithContext(ctx).Where("id IN ?", permissionIds).Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Update implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) Update(ctx context.Context, data *models.Permission) error {
+	return p.db.WithContext(ctx).Save(data).Error
+}
+
+// Delete implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) Delete(ctx context.Context, permissionId uuid.UUID) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM role_permissions WHERE permission_id = ?", permissionId).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Permission{}, "id = ?", permissionId).Error
+	})
+}
+
+// FindRoleWithPermissions implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) FindRoleWithPermissions(ctx context.Context, roleId uuid.UUID) (*models.Role, error) {
+	var role models.Role
+	err := p.db.WithContext(ctx).
+		Preload("Permissions", func(db *gorm.DB) *gorm.DB { return db.Order("code ASC") }).
+		First(&role, "id = ?", roleId).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ReplaceRolePermissions implements IPermissionRepository.
+func (p *PermissionRepositoryImpl) ReplaceRolePermissions(ctx context.Context, role *models.Role, permissions []models.Permission) error {
+	association := p.db.WithContext(ctx).Model(role).Association("Permissions")
+	if len(permissions) == 0 {
+		return association.Clear()
+	}
+	return association.Replace(permissions)
+}
@@ -18,7 +18,8 @@ type IPredictionRepository interface {
 	CreateStimulatedSaliva(ctx context.Context, data *models.StimulatedSaliva) error
 	CreateSalivaOption(ctx context.Context, data *models.SalivaOption) error
 
-	GetAllPrediction(ctx context.Context, limit, offset int, search string) ([]*models.Prediction, int, error)
+	// GetAllPrediction: teacherId membatasi ke prediksi yang sudah dikirim ke siswa di kelas guru (nil = semua).
+	GetAllPrediction(ctx context.Context, limit, offset int, search string, teacherId *uuid.UUID) ([]*models.Prediction, int, error)
 	GetByIdPrediction(ctx context.Context, predictionId uuid.UUID) (*models.Prediction, error)
 	DeletePrediction(ctx context.Context, predictionId uuid.UUID) error
 
@@ -3,6 +3,7 @@ package predictionrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -61,7 +62,7 @@ func (p *PredictionRepositoryImpl) CreatePrediction(ctx context.Context, data *m
 }
 
 // GetAllPrediction implements [IPredictionRepository].
-func (p *PredictionRepositoryImpl) GetAllPrediction(ctx context.Context, limit int, offset int, search string) ([]*models.Prediction, int, error) {
+func (p *PredictionRepositoryImpl) GetAllPrediction(ctx context.Context, limit int, offset int, search string, teacherId *uuid.UUID) ([]*models.Prediction, int, error) {
 	var (
 		predictions []*models.Prediction
 		count       int64
@@ -79,6 +80,13 @@ func (p *PredictionRepositoryImpl) GetAllPrediction(ctx context.Context, limit i
 	if search != "" {
 		query = query.Where("patient_name ILIKE ?", "%"+search+"%")
 	}
+	if teacherId != nil {
+		// prediksi tidak punya relasi kelas; guru hanya melihat prediksi yang sudah dikirim ke siswanya
+		query = query.Where("id IN (?)", p.db.Model(&models.PredictHistory{}).
+			Select("predict_histories.prediction_id").
+			Joins("JOIN users ON users.id = predict_histories.user_id").
+			Where("users.class_id IN (?)", classrepo.TeacherClassSubQuery(p.db, *teacherId)))
+	}
 
 	if err := query.Count(&count).Error; err != nil {
 		return nil, 0, err
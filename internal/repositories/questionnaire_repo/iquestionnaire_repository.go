@@ -0,0 +1,30 @@
+package questionnairerepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IQuestionnaireRepository interface {
+	Create(ctx context.Context, data *models.Questionnaire) error
+	FindAll(ctx context.Context, limit, offset int, search, questionnaireType string) ([]*models.Questionnaire, int, error)
+	FindById(ctx context.Context, questionnaireId uuid.UUID) (*models.Questionnaire, error)
+	Update(ctx context.Context, questionnaireId uuid.UUID, data *models.Questionnaire) error
+	Delete(ctx context.Context, questionnaireId uuid.UUID) error
+	UpdateStatus(ctx context.Context, questionnaireId uuid.UUID, status int) error
+	FindAllAvailable(ctx context.Context, search string) ([]*models.Questionnaire, error)
+
+	CreateItem(ctx context.Context, data *models.QuestionnaireItem) error
+	FindItemById(ctx context.Context, itemId uuid.UUID) (*models.QuestionnaireItem, error)
+	UpdateItem(ctx context.Context, itemId uuid.UUID, data *models.QuestionnaireItem) error
+	DeleteItem(ctx context.Context, itemId uuid.UUID) error
+	SyncAmountItems(ctx context.Context, questionnaireId uuid.UUID) error
+
+	CountRespondents(ctx context.Context, questionnaireId uuid.UUID) (int, error)
+	ExistsRespondent(ctx context.Context, questionnaireId, userId uuid.UUID, period string) (bool, error)
+	FindRespondedPeriods(ctx context.Context, userId uuid.UUID) ([]*models.Respondents, error)
+	CreateRespondent(ctx context.Context, data *models.Respondents) error
+	FindRespondentsForResult(ctx context.Context, questionnaireId uuid.UUID, period string, classId *uuid.UUID) ([]*models.Respondents, error)
+}
@@ -0,0 +1,195 @@
+package questionnairerepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionnaireRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQuestionnaireRepositoryImpl(db *gorm.DB) IQuestionnaireRepository {
+	return &QuestionnaireRepositoryImpl{db: db}
+}
+
+// Create implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) Create(ctx context.Context, data *models.Questionnaire) error {
+	return q.db.WithContext(ctx).Create(data).Error
+}
+
+// FindAll implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindAll(ctx context.Context, limit, offset int, search, questionnaireType string) ([]*models.Questionnaire, int, error) {
+	var (
+		questionnaires []*models.Questionnaire
+		count          int64
+	)
+
+	query := q.db.WithContext(ctx).Model(&models.Questionnaire{})
+	if search != "" {
+		query = query.Where("title ILIKE ?", "%"+search+"%")
+	}
+	if questionnaireType != "" {
+		query = query.Where("type = ?", questionnaireType)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Limit(limit).Offset(offset).Order("created_at DESC").Find(&questionnaires).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return questionnaires, int(count), nil
+}
+
+// FindById implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindById(ctx context.Context, questionnaireId uuid.UUID) (*models.Questionnaire, error) {
+	var questionnaire models.Questionnaire
+	if err := q.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_order ASC").Order("created_at ASC")
+		}).
+		First(&questionnaire, "id = ?", questionnaireId).Error; err != nil {
+		return nil, err
+	}
+	return &questionnaire, nil
+}
+
+// Update implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) Update(ctx context.Context, questionnaireId uuid.UUID, data *models.Questionnaire) error {
+	return q.db.WithContext(ctx).Omit("Items").Save(data).Error
+}
+
+// Delete implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) Delete(ctx context.Context, questionnaireId uuid.UUID) error {
+	return q.db.WithContext(ctx).Delete(&models.Questionnaire{}, "id = ?", questionnaireId).Error
+}
+
+// UpdateStatus implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) UpdateStatus(ctx context.Context, questionnaireId uuid.UUID, status int) error {
+	return q.db.WithContext(ctx).Model(&models.Questionnaire{}).Where("id = ?", questionnaireId).Update("status", status).Error
+}
+
+// FindAllAvailable implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindAllAvailable(ctx context.Context, search string) ([]*models.Questionnaire, error) {
+	var questionnaires []*models.Questionnaire
+
+	query := q.db.WithContext(ctx).Model(&models.Questionnaire{}).Where("status = ?", 1)
+	if search != "" {
+		query = query.Where("title ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Order("created_at DESC").Find(&questionnaires).Error; err != nil {
+		return nil, err
+	}
+
+	return questionnaires, nil
+}
+
+// CreateItem implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) CreateItem(ctx context.Context, data *models.QuestionnaireItem) error {
+	return q.db.WithContext(ctx).Create(data).Error
+}
+
+// FindItemById implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindItemById(ctx context.Context, itemId uuid.UUID) (*models.QuestionnaireItem, error) {
+	var item models.QuestionnaireItem
+	if err := q.db.WithContext(ctx).First(&item, "id = ?", itemId).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateItem implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) UpdateItem(ctx context.Context, itemId uuid.UUID, data *models.QuestionnaireItem) error {
+	return q.db.WithContext(ctx).Omit("Questionnaire").Save(data).Error
+}
+
+// DeleteItem implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) DeleteItem(ctx context.Context, itemId uuid.UUID) error {
+	return q.db.WithContext(ctx).Delete(&models.QuestionnaireItem{}, "id = ?", itemId).Error
+}
+
+// SyncAmountItems implements IQuestionnaireRepository.
+// Jumlah item dihitung ulang dari tabel item agar tidak bergeser seperti counter increment/decrement.
+func (q *QuestionnaireRepositoryImpl) SyncAmountItems(ctx context.Context, questionnaireId uuid.UUID) error {
+	return q.db.WithContext(ctx).Model(&models.Questionnaire{}).
+		Where("id = ?", questionnaireId).
+		UpdateColumn("amount_items", q.db.Model(&models.QuestionnaireItem{}).Select("COUNT(*)").Where("questionnaire_id = ?", questionnaireId)).Error
+}
+
+// CountRespondents implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) CountRespondents(ctx context.Context, questionnaireId uuid.UUID) (int, error) {
+	var count int64
+	err := q.db.WithContext(ctx).Model(&models.Respondents{}).Where("questionnaire_id = ?", questionnaireId).Count(&count).Error
+	return int(count), err
+}
+
+// ExistsRespondent implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) ExistsRespondent(ctx context.Context, questionnaireId, userId uuid.UUID, period string) (bool, error) {
+	var count int64
+	err := q.db.WithContext(ctx).Model(&models.Respondents{}).
+		Where("questionnaire_id = ? AND user_id = ? AND period = ?", questionnaireId, userId, period).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindRespondedPeriods implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindRespondedPeriods(ctx context.Context, userId uuid.UUID) ([]*models.Respondents, error) {
+	var respondents []*models.Respondents
+	if err := q.db.WithContext(ctx).
+		Select("id", "questionnaire_id", "period").
+		Where("user_id = ?", userId).
+		Find(&respondents).Error; err != nil {
+		return nil, err
+	}
+	return respondents, nil
+}
+
+// CreateRespondent implements IQuestionnaireRepository.
+// Respondent beserta seluruh jawabannya disimpan dalam satu transaksi.
+func (q *QuestionnaireRepositoryImpl) CreateRespondent(ctx context.Context, data *models.Respondents) error {
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answers := data.Answers
+		data.Answers = nil
+		if err := tx.Omit("User", "Questionnaire").Create(data).Error; err != nil {
+			return err
+		}
+
+		for i := range answers {
+			answers[i].RespondentID = data.ID
+		}
+		if len(answers) > 0 {
+			if err := tx.Omit("Item").Create(&answers).Error; err != nil {
+				return err
+			}
+		}
+
+		data.Answers = answers
+		return nil
+	})
+}
+
+// FindRespondentsForResult implements IQuestionnaireRepository.
+func (q *QuestionnaireRepositoryImpl) FindRespondentsForResult(ctx context.Context, questionnaireId uuid.UUID, period string, classId *uuid.UUID) ([]*models.Respondents, error) {
+	var respondents []*models.Respondents
+
+	query := q.db.WithContext(ctx).Model(&models.Respondents{}).
+		Where("questionnaire_id = ?", questionnaireId)
+	if period != "" {
+		query = query.Where("period = ?", period)
+	}
+	if classId != nil {
+		query = query.Where("class_id = ?", *classId)
+	}
+
+	if err := query.Preload("Answers").Find(&respondents).Error; err != nil {
+		return nil, err
+	}
+
+	return respondents, nil
+}
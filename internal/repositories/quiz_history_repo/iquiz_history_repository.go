@@ -3,13 +3,33 @@ package quizhistoryrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// QuizHistoryExportFilter menyaring riwayat quiz untuk ekspor; field nil/false berarti tanpa filter.
+type QuizHistoryExportFilter struct {
+	QuizID        *uuid.UUID
+	ClassID       *uuid.UUID
+	TeacherID     *uuid.UUID
+	StartDate     *time.Time
+	EndDate       *time.Time
+	WithQuestions bool
+}
+
 type IQuizHistoryRepository interface {
 	FindHistoryByUserID(ctx context.Context, userId uuid.UUID, search string) ([]*models.QuizHistory, error)
 	FindAllQuestionHistory(ctx context.Context, quizHistoryId uuid.UUID) ([]*models.QuestionHistory, error)
 	FindQuizHistoryById(ctx context.Context, quizHistoryId uuid.UUID) (*models.QuizHistory, error)
-	FindHistoryByQuizID(ctx context.Context) ([]*models.QuizHistory, error)
+	// FindHistoryByQuizID mengambil seluruh riwayat quiz; teacherId membatasi ke siswa di kelas guru (nil = semua kelas).
+	FindHistoryByQuizID(ctx context.Context, teacherId *uuid.UUID) ([]*models.QuizHistory, error)
+	// FindCompletedHistoryByQuizIDs mengambil riwayat quiz yang selesai untuk beberapa quiz, urut dari yang paling awal;
+	// teacherId membatasi ke siswa di kelas guru (nil = semua kelas).
+	FindCompletedHistoryByQuizIDs(ctx context.Context, quizIds []uuid.UUID, teacherId *uuid.UUID) ([]*models.QuizHistory, error)
+	// FindCompletedHistoryWithQuestions sama seperti di atas untuk satu quiz, beserta snapshot soal & jawabannya.
+	FindCompletedHistoryWithQuestions(ctx context.Context, quizId uuid.UUID, teacherId *uuid.UUID) ([]*models.QuizHistory, error)
+	// FindResponsesBySessionIDs mengambil jawaban yang dipilih siswa pada sesi-sesi quiz.
+	FindResponsesBySessionIDs(ctx context.Context, sessionIds []uuid.UUID) ([]*models.Response, error)
+	FindHistoryForExport(ctx context.Context, filter QuizHistoryExportFilter) ([]*models.QuizHistory, error)
 }
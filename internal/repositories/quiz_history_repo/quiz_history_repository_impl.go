@@ -3,6 +3,7 @@ package quizhistoryrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,8 +19,11 @@ func NewQuizHistoryRepositoryImpl(db *gorm.DB) IQuizHistoryRepository {
 
 func (q *QuizHistoryRepositoryImpl) preloadQuestionHistory(db *gorm.DB) *gorm.DB {
 	return db.
-		Preload("QuizHistory").  // Mengambil data QuizHistory dari QuestionHistory
-		Preload("AnswerHistory") // Perbaikan typo dari AnswerHIstory -> AnswerHistory
+		Preload("QuizHistory"). // Mengambil data QuizHistory dari QuestionHistory
+		// Perbaikan typo dari AnswerHIstory -> AnswerHistory; urutan opsi seperti yang dilihat siswa
+		Preload("AnswerHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order ASC, created_at ASC")
+		})
 }
 
 func (q *QuizHistoryRepositoryImpl) preloadQuizHistory(db *gorm.DB) *gorm.DB {
@@ -75,11 +79,19 @@ func (q *QuizHistoryRepositoryImpl) FindQuizHistoryById(ctx context.Context, qui
 	return &quizHistory, nil
 }
 
+// teacherStudentIDs adalah subquery ID siswa pada kelas yang diampu guru.
+func (q *QuizHistoryRepositoryImpl) teacherStudentIDs(teacherId uuid.UUID) *gorm.DB {
+	return q.db.Model(&models.User{}).Select("id").Where("class_id IN (?)", classrepo.TeacherClassSubQuery(q.db, teacherId))
+}
+
 // FindHistoryByQuizID implements [IQuizHistoryRepository].
-func (q *QuizHistoryRepositoryImpl) FindHistoryByQuizID(ctx context.Context) ([]*models.QuizHistory, error) {
+func (q *QuizHistoryRepositoryImpl) FindHistoryByQuizID(ctx context.Context, teacherId *uuid.UUID) ([]*models.QuizHistory, error) {
 	var quizHistories []*models.QuizHistory
 
 	query := q.db.WithContext(ctx).Model(&models.QuizHistory{}).Order("quiz_id ASC, created_at ASC")
+	if teacherId != nil {
+		query = query.Where("user_id IN (?)", q.teacherStudentIDs(*teacherId))
+	}
 
 	if err := query.Order("created_at ASC").Find(&quizHistories).Error; err != nil {
 		return nil, err
@@ -87,3 +99,92 @@ func (q *QuizHistoryRepositoryImpl) FindHistoryByQuizID(ctx context.Context) ([]
 
 	return quizHistories, nil
 }
+
+// FindCompletedHistoryByQuizIDs implements [IQuizHistoryRepository].
+func (q *QuizHistoryRepositoryImpl) FindCompletedHistoryByQuizIDs(ctx context.Context, quizIds []uuid.UUID, teacherId *uuid.UUID) ([]*models.QuizHistory, error) {
+	if len(quizIds) == 0 {
+		return []*models.QuizHistory{}, nil
+	}
+
+	var quizHistories []*models.QuizHistory
+	query := q.db.WithContext(ctx).
+		Model(&models.QuizHistory{}).
+		Where("quiz_id IN ?", quizIds).
+		Where("status = ?", models.SessionStatusCompleted)
+	if teacherId != nil {
+		query = query.Where("user_id IN (?)", q.teacherStudentIDs(*teacherId))
+	}
+	if err := query.Order("completed_at ASC").Find(&quizHistories).Error; err != nil {
+		return nil, err
+	}
+
+	return quizHistories, nil
+}
+
+// FindCompletedHistoryWithQuestions implements [IQuizHistoryRepository].
+func (q *QuizHistoryRepositoryImpl) FindCompletedHistoryWithQuestions(ctx context.Context, quizId uuid.UUID, teacherId *uuid.UUID) ([]*models.QuizHistory, error) {
+	var quizHistories []*models.QuizHistory
+	query := q.db.WithContext(ctx).
+		Model(&models.QuizHistory{}).
+		Preload("QuestionHistory.AnswerHistory").
+		Where("quiz_id = ?", quizId).
+		Where("status = ?", models.SessionStatusCompleted)
+	if teacherId != nil {
+		query = query.Where("user_id IN (?)", q.teacherStudentIDs(*teacherId))
+	}
+	if err := query.Order("completed_at ASC").Find(&quizHistories).Error; err != nil {
+		return nil, err
+	}
+
+	return quizHistories, nil
+}
+
+// FindResponsesBySessionIDs implements [IQuizHistoryRepository].
+func (q *QuizHistoryRepositoryImpl) FindResponsesBySessionIDs(ctx context.Context, sessionIds []uuid.UUID) ([]*models.Response, error) {
+	if len(sessionIds) == 0 {
+		return []*models.Response{}, nil
+	}
+
+	var responses []*models.Response
+	if err := q.db.WithContext(ctx).
+		Model(&models.Response{}).
+		Where("quiz_session_id IN ?", sessionIds).
+		Find(&responses).Error; err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// FindHistoryForExport implements [IQuizHistoryRepository].
+func (q *QuizHistoryRepositoryImpl) FindHistoryForExport(ctx context.Context, filter QuizHistoryExportFilter) ([]*models.QuizHistory, error) {
+	var quizHistories []*models.QuizHistory
+
+	query := q.db.WithContext(ctx).Model(&models.QuizHistory{})
+	if filter.QuizID != nil {
+		query = query.Where("quiz_id = ?", *filter.QuizID)
+	}
+	if filter.ClassID != nil {
+		query = query.Where("user_id IN (?)", q.db.Model(&models.User{}).Select("id").Where("class_id = ?", *filter.ClassID))
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("user_id IN (?)", q.teacherStudentIDs(*filter.TeacherID))
+	}
+	if filter.StartDate != nil {
+		query = query.Where("completed_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("completed_at < ?", *filter.EndDate)
+	}
+	if filter.WithQuestions {
+		query = query.Preload("QuestionHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).Preload("QuestionHistory.AnswerHistory")
+	}
+
+	if err := query.Order("title ASC").Order("completed_at ASC").Find(&quizHistories).Error; err != nil {
+		return nil, err
+	}
+
+	return quizHistories, nil
+}
@@ -0,0 +1,17 @@
+package quizrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IQuizPairRepository interface {
+	Create(ctx context.Context, data *models.QuizPair) error
+	FindAll(ctx context.Context, limit, offset int, search string) ([]*models.QuizPair, int, error)
+	FindById(ctx context.Context, pairId uuid.UUID) (*models.QuizPair, error)
+	FindByQuizIds(ctx context.Context, preQuizId, postQuizId uuid.UUID) (*models.QuizPair, error)
+	Update(ctx context.Context, pairId uuid.UUID, data *models.QuizPair) error
+	Delete(ctx context.Context, pairId uuid.UUID) error
+}
@@ -19,6 +19,9 @@ type IQuizRepository interface {
 	IncreamentAmountQuestion(ctx context.Context, quizId uuid.UUID) error
 	DecreaseAmountQuestion(ctx context.Context, quizId uuid.UUID) error
 	UpdateQuestionOrderMode(ctx context.Context, quizId uuid.UUID, mode string) error
+	UpdateCorrectAnswerVisibility(ctx context.Context, quizId uuid.UUID, visibility string) error
+	UpdateShuffleAnswers(ctx context.Context, quizId uuid.UUID, shuffle bool) error
+	UpdateQuestionPool(ctx context.Context, quizId uuid.UUID, drawCount int, stratifyBy string) error
 	IncreamentAmountAssigned(ctx context.Context, quizId uuid.UUID) error
 
 	FindAllQuizAvailable(ctx context.Context, search string) ([]*models.Quiz, error)
@@ -0,0 +1,72 @@
+package quizrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuizPairRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQuizPairRepositoryImpl(db *gorm.DB) IQuizPairRepository {
+	return &QuizPairRepositoryImpl{db: db}
+}
+
+// Create implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) Create(ctx context.Context, data *models.QuizPair) error {
+	return q.db.WithContext(ctx).Omit("PreQuiz", "PostQuiz").Create(data).Error
+}
+
+// FindAll implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) FindAll(ctx context.Context, limit, offset int, search string) ([]*models.QuizPair, int, error) {
+	var (
+		pairs []*models.QuizPair
+		count int64
+	)
+
+	query := q.db.WithContext(ctx).Model(&models.QuizPair{})
+	if search != "" {
+		query = query.Where("title ILIKE ?", "%"+search+"%")
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("PreQuiz").Preload("PostQuiz").Limit(limit).Offset(offset).Order("created_at DESC").Find(&pairs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return pairs, int(count), nil
+}
+
+// FindById implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) FindById(ctx context.Context, pairId uuid.UUID) (*models.QuizPair, error) {
+	var pair models.QuizPair
+	if err := q.db.WithContext(ctx).Preload("PreQuiz").Preload("PostQuiz").First(&pair, "id = ?", pairId).Error; err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// FindByQuizIds implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) FindByQuizIds(ctx context.Context, preQuizId, postQuizId uuid.UUID) (*models.QuizPair, error) {
+	var pair models.QuizPair
+	if err := q.db.WithContext(ctx).First(&pair, "pre_quiz_id = ? AND post_quiz_id = ?", preQuizId, postQuizId).Error; err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// Update implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) Update(ctx context.Context, pairId uuid.UUID, data *models.QuizPair) error {
+	return q.db.WithContext(ctx).Omit("PreQuiz", "PostQuiz").Save(data).Error
+}
+
+// Delete implements IQuizPairRepository.
+func (q *QuizPairRepositoryImpl) Delete(ctx context.Context, pairId uuid.UUID) error {
+	return q.db.WithContext(ctx).Delete(&models.QuizPair{}, "id = ?", pairId).Error
+}
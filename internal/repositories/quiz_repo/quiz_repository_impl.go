@@ -98,6 +98,24 @@ func (q *QuizRepositoryImpl) UpdateQuestionOrderMode(ctx context.Context, quizId
 	return q.db.WithContext(ctx).Model(&models.Quiz{}).Where("id = ?", quizId).Update("question_order_mode", mode).Error
 }
 
+// UpdateCorrectAnswerVisibility implements [IQuizRepository].
+func (q *QuizRepositoryImpl) UpdateCorrectAnswerVisibility(ctx context.Context, quizId uuid.UUID, visibility string) error {
+	return q.db.WithContext(ctx).Model(&models.Quiz{}).Where("id = ?", quizId).Update("correct_answer_visibility", visibility).Error
+}
+
+// UpdateShuffleAnswers implements [IQuizRepository].
+func (q *QuizRepositoryImpl) UpdateShuffleAnswers(ctx context.Context, quizId uuid.UUID, shuffle bool) error {
+	return q.db.WithContext(ctx).Model(&models.Quiz{}).Where("id = ?", quizId).Update("shuffle_answers", shuffle).Error
+}
+
+// UpdateQuestionPool implements [IQuizRepository].
+func (q *QuizRepositoryImpl) UpdateQuestionPool(ctx context.Context, quizId uuid.UUID, drawCount int, stratifyBy string) error {
+	return q.db.WithContext(ctx).Model(&models.Quiz{}).Where("id = ?", quizId).Updates(map[string]interface{}{
+		"draw_count":       drawCount,
+		"draw_stratify_by": stratifyBy,
+	}).Error
+}
+
 // IncreamentAmountAssigned implements [IQuizRepository].
 func (q *QuizRepositoryImpl) IncreamentAmountAssigned(ctx context.Context, quizId uuid.UUID) error {
 	return q.db.WithContext(ctx).Model(&models.Quiz{}).Where("id = ?", quizId).UpdateColumn("amount_assigned", gorm.Expr("amount_assigned + ?", 1)).Error
@@ -11,7 +11,10 @@ import (
 type IQuizSessionRepository interface {
 	AssignCodeQuiz(ctx context.Context, quizId uuid.UUID, code string) (uuid.UUID, error)
 	SaveQuizSession(ctx context.Context, data *models.QuizSession) error
-	CreateStartedAt(ctx context.Context, quizSessionId uuid.UUID) error
+	// CreateStartedAt menandai sesi dimulai sekaligus menyimpan deadline (nil = tanpa batas waktu).
+	CreateStartedAt(ctx context.Context, quizSessionId uuid.UUID, startedAt time.Time, deadline *time.Time) error
+	// SaveDeadline mengisi deadline sesi yang sudah berjalan sebelum deadline disimpan di database.
+	SaveDeadline(ctx context.Context, quizSessionId uuid.UUID, deadline time.Time) error
 	FindById(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID) (*models.QuizSession, error)
 	FindByUserAndQuiz(ctx context.Context, userId uuid.UUID, quizId uuid.UUID) (*models.QuizSession, error)
 
@@ -24,8 +27,12 @@ type IQuizSessionRepository interface {
 		answerHistories []models.AnswerHistory,
 	) error
 	FindQuizWithOrderedQuestions(ctx context.Context, quizId uuid.UUID, orderMode string) (*models.Quiz, error)
+	// SaveDrawnQuestions menyimpan subset undian bank soal hanya bila sesi belum punya;
+	// false berarti sesi sudah diundi sebelumnya.
+	SaveDrawnQuestions(ctx context.Context, quizSessionId uuid.UUID, questionIds []string) (bool, error)
 
-	FindQuizSessionByQuiz(ctx context.Context) ([]models.QuizSession, error)
+	// FindQuizSessionByQuiz mengambil seluruh sesi quiz; teacherId membatasi ke siswa di kelas guru (nil = semua kelas).
+	FindQuizSessionByQuiz(ctx context.Context, teacherId *uuid.UUID) ([]models.QuizSession, error)
 	FindCompleteStatusQuizSession(ctx context.Context, userId uuid.UUID, quizId uuid.UUID) (bool, error)
 
 	// SubmitQuizTransaction membungkus seluruh proses submit quiz
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -40,17 +42,25 @@ func (q *QuizSessionRepositoryImpl) SaveQuizSession(ctx context.Context, data *m
 }
 
 // CreateStartedAt implements [IQuizSessionRepository].
-func (q *QuizSessionRepositoryImpl) CreateStartedAt(ctx context.Context, quizSessionId uuid.UUID) error {
-	now := time.Now()
+func (q *QuizSessionRepositoryImpl) CreateStartedAt(ctx context.Context, quizSessionId uuid.UUID, startedAt time.Time, deadline *time.Time) error {
 	return q.db.WithContext(ctx).
 		Model(&models.QuizSession{}).
 		Where("id = ?", quizSessionId).
 		Updates(map[string]interface{}{
-			"started_at": now,
+			"started_at": startedAt,
+			"deadline":   deadline,
 			"status":     models.SessionStatusInProgress,
 		}).Error
 }
 
+// SaveDeadline implements [IQuizSessionRepository].
+func (q *QuizSessionRepositoryImpl) SaveDeadline(ctx context.Context, quizSessionId uuid.UUID, deadline time.Time) error {
+	return q.db.WithContext(ctx).
+		Model(&models.QuizSession{}).
+		Where("id = ? AND deadline IS NULL", quizSessionId).
+		Update("deadline", deadline).Error
+}
+
 // FindById implements [IQuizSessionRepository].
 func (q *QuizSessionRepositoryImpl) FindById(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID) (*models.QuizSession, error) {
 	var qs models.QuizSession
@@ -152,14 +162,32 @@ func (q *QuizSessionRepositoryImpl) FindQuizWithOrderedQuestions(ctx context.Con
 	return &quiz, nil
 }
 
+// SaveDrawnQuestions implements [IQuizSessionRepository].
+func (q *QuizSessionRepositoryImpl) SaveDrawnQuestions(ctx context.Context, quizSessionId uuid.UUID, questionIds []string) (bool, error) {
+	result := q.db.WithContext(ctx).
+		Model(&models.QuizSession{}).
+		Where("id = ? AND (drawn_question_ids IS NULL OR cardinality(drawn_question_ids) = 0)", quizSessionId).
+		Update("drawn_question_ids", pq.StringArray(questionIds))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // FindQuizSessionByQuiz implements [IQuizSessionRepository].
-func (q *QuizSessionRepositoryImpl) FindQuizSessionByQuiz(ctx context.Context) ([]models.QuizSession, error) {
+func (q *QuizSessionRepositoryImpl) FindQuizSessionByQuiz(ctx context.Context, teacherId *uuid.UUID) ([]models.QuizSession, error) {
 
 	var sessions []models.QuizSession
 
-	err := q.db.WithContext(ctx).
+	query := q.db.WithContext(ctx).
 		Preload("Quiz").
-		Preload("User").
+		Preload("User")
+	if teacherId != nil {
+		query = query.Where("user_id IN (?)", q.db.Model(&models.User{}).Select("id").
+			Where("class_id IN (?)", classrepo.TeacherClassSubQuery(q.db, *teacherId)))
+	}
+
+	err := query.
 		Order("quiz_id ASC, created_at ASC").
 		Find(&sessions).Error
 
@@ -185,9 +213,10 @@ func (q *QuizSessionRepositoryImpl) FindCompleteStatusQuizSession(ctx context.Co
 }
 
 // SubmitQuizTransaction membungkus SELURUH proses submit quiz dalam satu transaksi:
-//  1. Simpan responses (jawaban siswa)
+//  1. Simpan responses (jawaban siswa; answer_detail untuk tipe selain pilihan tunggal)
 //  2. Update quiz session → completed
-//  3. Simpan quiz history + question history + answer history
+//  3. Simpan quiz history + question history + answer history (termasuk snapshot tipe soal,
+//     opsi terpilih, teks short answer dan pasangan matching)
 //
 // Jika salah satu langkah gagal atau terjadi timeout,
 // seluruh operasi di-ROLLBACK → tidak ada data partial / double input.
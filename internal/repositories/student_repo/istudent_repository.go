@@ -13,6 +13,10 @@ type IStudentRepository interface {
 	FindUsernameUnique(ctx context.Context, username string) (string, error)
 	FindNisnUnique(ctx context.Context, nisn string) (string, error)
 	FindRoleStudent(ctx context.Context) (*models.Role, error)
+	// FindExistingNisns mengembalikan NISN dari daftar yang sudah terdaftar.
+	FindExistingNisns(ctx context.Context, nisns []string) ([]string, error)
+	// FindExistingUsernames mengembalikan username dari daftar yang sudah terdaftar.
+	FindExistingUsernames(ctx context.Context, usernames []string) ([]string, error)
 	UpdatePhotoStudent(ctx context.Context, studentId uuid.UUID, photo string) error
 
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
@@ -23,10 +27,18 @@ type IStudentRepository interface {
 	UpdateNewPassword(ctx context.Context, studentID uuid.UUID, password string) error
 
 	UpdateProfile(ctx context.Context, studentId uuid.UUID, data *models.User) error
+	UpdateStatus(ctx context.Context, studentId uuid.UUID, status int) error
 
 	CreateTootBrush(ctx context.Context, studentId uuid.UUID, data *models.ToootBrushLog) error
 	CheckTootBrushExists(ctx context.Context, studentId uuid.UUID, typeTime string, logDate time.Time) (bool, error)
 	GetHistoryTootBrush(ctx context.Context, studentId uuid.UUID, typeTime string, limit int, offset int) ([]*models.ToootBrushLog, int, error)
+	// FindTootBrushLogsByRange mengambil seluruh log sikat gigi siswa pada rentang tanggal (nil = tanpa batas).
+	FindTootBrushLogsByRange(ctx context.Context, studentId uuid.UUID, startDate, endDate *time.Time) ([]*models.ToootBrushLog, error)
+	UpdatePhotoTootBrush(ctx context.Context, logId uuid.UUID, photo string) error
+	FindTootBrushByID(ctx context.Context, logId uuid.UUID) (*models.ToootBrushLog, error)
+	GetAllTootBrushForReview(ctx context.Context, reviewStatus string, classId *uuid.UUID, onlyWithPhoto bool, limit int, offset int) ([]*models.ToootBrushLog, int, error)
+	UpdateReviewTootBrush(ctx context.Context, logId uuid.UUID, data map[string]any) error
 
-	GetAllStudents(ctx context.Context, search string) ([]*models.User, int, error)
+	// GetAllStudents mengambil seluruh siswa; teacherId membatasi ke kelas yang diampu guru (nil = semua kelas).
+	GetAllStudents(ctx context.Context, search string, teacherId *uuid.UUID) ([]*models.User, int, error)
 }
@@ -3,6 +3,7 @@ package studentrepo
 import (
 	"context"
 	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
 	"time"
 
 	"github.com/google/uuid"
@@ -42,6 +43,30 @@ func (s *StudentRepositoryImpl) FindNisnUnique(ctx context.Context, nisn string)
 	return *student.Nisn, nil
 }
 
+// FindExistingNisns implements IStudentRepository.
+func (s *StudentRepositoryImpl) FindExistingNisns(ctx context.Context, nisns []string) ([]string, error) {
+	var existing []string
+	if len(nisns) == 0 {
+		return existing, nil
+	}
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("nisn IN ?", nisns).Pluck("nisn", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// FindExistingUsernames implements IStudentRepository.
+func (s *StudentRepositoryImpl) FindExistingUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	var existing []string
+	if len(usernames) == 0 {
+		return existing, nil
+	}
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("username IN ?", usernames).Pluck("username", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
 // FindRoleStudent implements IStudentRepository.
 func (s *StudentRepositoryImpl) FindRoleStudent(ctx context.Context) (*models.Role, error) {
 	var role models.Role
@@ -110,7 +135,10 @@ func (s *StudentRepositoryImpl) CheckNisnAndDateOfBirth(ctx context.Context, nis
 
 // UpdateNewPassword implements IStudentRepository.
 func (s *StudentRepositoryImpl) UpdateNewPassword(ctx context.Context, studentID uuid.UUID, password string) error {
-	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", studentID).Update("password", password).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", studentID).Updates(map[string]any{
+		"password":             password,
+		"must_change_password": false,
+	}).Error; err != nil {
 		return err
 	}
 
@@ -133,6 +161,14 @@ func (s *StudentRepositoryImpl) UpdateProfile(ctx context.Context, studentId uui
 		Updates(updateData).Error
 }
 
+// UpdateStatus implements IStudentRepository.
+func (s *StudentRepositoryImpl) UpdateStatus(ctx context.Context, studentId uuid.UUID, status int) error {
+	return s.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", studentId).
+		Update("status", status).Error
+}
+
 // CreateTootBrush implements IStudentRepository.
 func (s *StudentRepositoryImpl) CreateTootBrush(ctx context.Context, studentId uuid.UUID, data *models.ToootBrushLog) error {
 	return s.db.WithContext(ctx).Create(data).Error
@@ -187,9 +223,86 @@ func (s *StudentRepositoryImpl) GetHistoryTootBrush(ctx context.Context, student
 	return logs, int(count), nil
 }
 
+// FindTootBrushLogsByRange implements IStudentRepository.
+func (s *StudentRepositoryImpl) FindTootBrushLogsByRange(ctx context.Context, studentId uuid.UUID, startDate, endDate *time.Time) ([]*models.ToootBrushLog, error) {
+	var logs []*models.ToootBrushLog
+
+	query := s.db.WithContext(ctx).Model(&models.ToootBrushLog{}).
+		Where("user_id = ?", studentId)
+
+	if startDate != nil {
+		query = query.Where("log_date >= ?", startDate.Format("2006-01-02"))
+	}
+	if endDate != nil {
+		query = query.Where("log_date <= ?", endDate.Format("2006-01-02"))
+	}
+
+	if err := query.Order("log_date ASC").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// UpdatePhotoTootBrush implements IStudentRepository.
+func (s *StudentRepositoryImpl) UpdatePhotoTootBrush(ctx context.Context, logId uuid.UUID, photo string) error {
+	return s.db.WithContext(ctx).Model(&models.ToootBrushLog{}).Where("id = ?", logId).Update("photo_url", photo).Error
+}
+
+// FindTootBrushByID implements IStudentRepository.
+func (s *StudentRepositoryImpl) FindTootBrushByID(ctx context.Context, logId uuid.UUID) (*models.ToootBrushLog, error) {
+	var log models.ToootBrushLog
+	if err := s.db.WithContext(ctx).Preload("User").First(&log, "id = ?", logId).Error; err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// GetAllTootBrushForReview implements IStudentRepository.
+func (s *StudentRepositoryImpl) GetAllTootBrushForReview(ctx context.Context, reviewStatus string, classId *uuid.UUID, onlyWithPhoto bool, limit int, offset int) ([]*models.ToootBrushLog, int, error) {
+	var (
+		logs  []*models.ToootBrushLog
+		count int64
+	)
+
+	query := s.db.WithContext(ctx).Model(&models.ToootBrushLog{})
+
+	if reviewStatus != "" {
+		query = query.Where("review_status = ?", reviewStatus)
+	}
+	if classId != nil {
+		query = query.Where("user_id IN (?)", s.db.Model(&models.User{}).Select("id").Where("class_id = ?", *classId))
+	}
+	if onlyWithPhoto {
+		query = query.Where("photo_url IS NOT NULL")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("User").
+		Order("log_date DESC").
+		Order("log_time DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, int(count), nil
+}
+
+// UpdateReviewTootBrush implements IStudentRepository.
+func (s *StudentRepositoryImpl) UpdateReviewTootBrush(ctx context.Context, logId uuid.UUID, data map[string]any) error {
+	return s.db.WithContext(ctx).Model(&models.ToootBrushLog{}).Where("id = ?", logId).Updates(data).Error
+}
+
 func (q *StudentRepositoryImpl) GetAllStudents(
 	ctx context.Context,
 	search string,
+	teacherId *uuid.UUID,
 ) ([]*models.User, int, error) {
 
 	var (
@@ -205,6 +318,9 @@ func (q *StudentRepositoryImpl) GetAllStudents(
 	if search != "" {
 		query = query.Where("users.name ILIKE ?", "%"+search+"%")
 	}
+	if teacherId != nil {
+		query = query.Where("users.class_id IN (?)", classrepo.TeacherClassSubQuery(q.db, *teacherId))
+	}
 
 	if err := query.Count(&count).Error; err != nil {
 		return nil, 0, err
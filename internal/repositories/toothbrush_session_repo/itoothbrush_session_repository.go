@@ -0,0 +1,24 @@
+package toothbrushsessionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IToothBrushSessionRepository interface {
+	Create(ctx context.Context, data *models.ToothBrushSession) error
+	FindAll(ctx context.Context, limit, offset int, search string) ([]*models.ToothBrushSession, int, error)
+	FindById(ctx context.Context, sessionId uuid.UUID) (*models.ToothBrushSession, error)
+	FindByCode(ctx context.Context, code string) (*models.ToothBrushSession, error)
+	FindActive(ctx context.Context) ([]*models.ToothBrushSession, error)
+	Update(ctx context.Context, sessionId uuid.UUID, data *models.ToothBrushSession) error
+	Delete(ctx context.Context, sessionId uuid.UUID) error
+	// CountLogsByCode menghitung log sikat gigi yang tercatat dengan time_type = code.
+	CountLogsByCode(ctx context.Context, code string) (int64, error)
+
+	UpsertOverride(ctx context.Context, data *models.ToothBrushSessionOverride) error
+	FindOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) (*models.ToothBrushSessionOverride, error)
+	DeleteOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) error
+}
@@ -0,0 +1,139 @@
+package toothbrushsessionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ToothBrushSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewToothBrushSessionRepositoryImpl(db *gorm.DB) IToothBrushSessionRepository {
+	return &ToothBrushSessionRepositoryImpl{db: db}
+}
+
+// Create implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) Create(ctx context.Context, data *models.ToothBrushSession) error {
+	return t.db.WithContext(ctx).Create(data).Error
+}
+
+// FindAll implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) FindAll(ctx context.Context, limit int, offset int, search string) ([]*models.ToothBrushSession, int, error) {
+	var (
+		sessions []*models.ToothBrushSession
+		count    int64
+	)
+
+	query := t.db.WithContext(ctx).Model(&models.ToothBrushSession{})
+	if search != "" {
+		query = query.Where("code ILIKE ? OR name ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Preload("ClassOverrides").
+		Preload("ClassOverrides.Class").
+		Offset(offset).
+		Limit(limit).
+		Order("start_time ASC").
+		Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, int(count), nil
+}
+
+// FindById implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) FindById(ctx context.Context, sessionId uuid.UUID) (*models.ToothBrushSession, error) {
+	var session models.ToothBrushSession
+	if err := t.db.WithContext(ctx).
+		Preload("ClassOverrides").
+		Preload("ClassOverrides.Class").
+		First(&session, "id = ?", sessionId).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindByCode implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) FindByCode(ctx context.Context, code string) (*models.ToothBrushSession, error) {
+	var session models.ToothBrushSession
+	if err := t.db.WithContext(ctx).First(&session, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindActive implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) FindActive(ctx context.Context) ([]*models.ToothBrushSession, error) {
+	var sessions []*models.ToothBrushSession
+	if err := t.db.WithContext(ctx).Where("is_active = ?", true).Order("start_time ASC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// CountLogsByCode implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) CountLogsByCode(ctx context.Context, code string) (int64, error) {
+	var count int64
+	if err := t.db.WithContext(ctx).Model(&models.ToootBrushLog{}).Where("time_type = ?", code).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Update implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) Update(ctx context.Context, sessionId uuid.UUID, data *models.ToothBrushSession) error {
+	return t.db.WithContext(ctx).
+		Model(&models.ToothBrushSession{}).
+		Where("id = ?", sessionId).
+		Updates(map[string]interface{}{
+			"code":          data.Code,
+			"name":          data.Name,
+			"start_time":    data.StartTime,
+			"end_time":      data.EndTime,
+			"grace_minutes": data.GraceMinutes,
+			"is_active":     data.IsActive,
+		}).Error
+}
+
+// Delete implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) Delete(ctx context.Context, sessionId uuid.UUID) error {
+	return t.db.WithContext(ctx).Delete(&models.ToothBrushSession{}, "id = ?", sessionId).Error
+}
+
+// UpsertOverride implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) UpsertOverride(ctx context.Context, data *models.ToothBrushSessionOverride) error {
+	return t.db.WithContext(ctx).
+		Omit("Class").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}, {Name: "class_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"start_time", "end_time", "grace_minutes", "updated_at"}),
+		}).
+		Create(data).Error
+}
+
+// FindOverride implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) FindOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) (*models.ToothBrushSessionOverride, error) {
+	var override models.ToothBrushSessionOverride
+	if err := t.db.WithContext(ctx).First(&override, "session_id = ? AND class_id = ?", sessionId, classId).Error; err != nil {
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+// DeleteOverride implements IToothBrushSessionRepository.
+func (t *ToothBrushSessionRepositoryImpl) DeleteOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) error {
+	return t.db.WithContext(ctx).Delete(&models.ToothBrushSessionOverride{}, "session_id = ? AND class_id = ?", sessionId, classId).Error
+}
@@ -0,0 +1,21 @@
+package usersessionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IUserSessionRepository interface {
+	Create(ctx context.Context, data *models.UserSession) error
+	FindByID(ctx context.Context, sessionId uuid.UUID) (*models.UserSession, error)
+	// FindActiveByUserID mengambil sesi yang belum dicabut & belum kedaluwarsa, terbaru dipakai lebih dulu.
+	FindActiveByUserID(ctx context.Context, userId uuid.UUID) ([]*models.UserSession, error)
+	// RotateRefreshToken mengganti hash refresh token hanya jika hash lama masih sama (atomik).
+	RotateRefreshToken(ctx context.Context, sessionId uuid.UUID, oldHash, newHash, userAgent, ipAddress string, lastSeen time.Time) (bool, error)
+	Revoke(ctx context.Context, sessionIds []uuid.UUID, reason string) error
+	// IncrementTokenEpoch menaikkan users.token_epoch dan mengembalikan nilai barunya.
+	IncrementTokenEpoch(ctx context.Context, userId uuid.UUID) (int, error)
+}
@@ -0,0 +1,92 @@
+package usersessionrepo
+
+import (
+	"context"
+	"giat-cerika-service/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepositoryImpl(db *gorm.DB) IUserSessionRepository {
+	return &UserSessionRepositoryImpl{db: db}
+}
+
+// Create implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) Create(ctx context.Context, data *models.UserSession) error {
+	return u.db.WithContext(ctx).Create(data).Error
+}
+
+// FindByID implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) FindByID(ctx context.Context, sessionId uuid.UUID) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := u.db.WithContext(ctx).Preload("User").Preload("User.Role").First(&session, "id = ?", sessionId).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindActiveByUserID implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) FindActiveByUserID(ctx context.Context, userId uuid.UUID) ([]*models.UserSession, error) {
+	var sessions []*models.UserSession
+	if err := u.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userId, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RotateRefreshToken implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) RotateRefreshToken(ctx context.Context, sessionId uuid.UUID, oldHash, newHash, userAgent, ipAddress string, lastSeen time.Time) (bool, error) {
+	result := u.db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionId, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":    newHash,
+			"previous_refresh_hash": oldHash,
+			"user_agent":            userAgent,
+			"ip_address":            ipAddress,
+			"last_seen_at":          lastSeen,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Revoke implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) Revoke(ctx context.Context, sessionIds []uuid.UUID, reason string) error {
+	if len(sessionIds) == 0 {
+		return nil
+	}
+
+	return u.db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("id IN ? AND revoked_at IS NULL", sessionIds).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoke_reason": reason,
+		}).Error
+}
+
+// IncrementTokenEpoch implements IUserSessionRepository.
+func (u *UserSessionRepositoryImpl) IncrementTokenEpoch(ctx context.Context, userId uuid.UUID) (int, error) {
+	var epoch int
+	if err := u.db.WithContext(ctx).
+		Raw("UPDATE users SET token_epoch = token_epoch + 1 WHERE id = ? RETURNING token_epoch", userId).
+		Scan(&epoch).Error; err != nil {
+		return 0, err
+	}
+
+	return epoch, nil
+}
@@ -0,0 +1,133 @@
+package adminservice
+
+import (
+	"context"
+	"errors"
+	adminrequest "giat-cerika-service/internal/dto/request/admin_request"
+	adminresponse "giat-cerika-service/internal/dto/response/admin_response"
+	"giat-cerika-service/internal/models"
+	adminrepo "giat-cerika-service/internal/repositories/admin_repo"
+	rolerepo "giat-cerika-service/internal/repositories/role_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	inviteTokenBytes         = 24
+	defaultInviteExpiryHours = 72
+	maxInviteExpiryHours     = 24 * 30
+)
+
+type AdminInviteServiceImpl struct {
+	inviteRepo adminrepo.IAdminInviteRepository
+	roleRepo   rolerepo.IRoleRepository
+	audit      auditservice.IAuditService
+}
+
+func NewAdminInviteServiceImpl(inviteRepo adminrepo.IAdminInviteRepository, roleRepo rolerepo.IRoleRepository, auditService auditservice.IAuditService) IAdminInviteService {
+	return &AdminInviteServiceImpl{inviteRepo: inviteRepo, roleRepo: roleRepo, audit: auditService}
+}
+
+// CreateInvite implements IAdminInviteService.
+func (a *AdminInviteServiceImpl) CreateInvite(ctx context.Context, adminId uuid.UUID, req adminrequest.CreateAdminInviteRequest) (*adminresponse.AdminInviteCreatedResponse, error) {
+	if req.RoleID == uuid.Nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "role is required", 400)
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultInviteExpiryHours
+	}
+	if hours < 1 || hours > maxInviteExpiryHours {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "expires_in_hours must be between 1 and 720", 400)
+	}
+
+	role, err := a.roleRepo.FindById(ctx, req.RoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "role not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get role", 500)
+	}
+	// Akun siswa dibuat lewat registrasi/impor siswa, bukan undangan admin.
+	if strings.EqualFold(role.Name, "student") {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invite cannot be created for student role", 400)
+	}
+
+	token, err := utils.GenerateOpaqueToken(inviteTokenBytes)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate invite token", 500)
+	}
+
+	invite := &models.AdminInvite{
+		ID:        uuid.New(),
+		TokenHash: utils.HashToken(token),
+		RoleID:    role.ID,
+		Role:      *role,
+		Note:      strings.TrimSpace(req.Note),
+		CreatedBy: adminId,
+		ExpiresAt: time.Now().Add(time.Duration(hours) * time.Hour),
+	}
+	if err := a.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create invite", 500)
+	}
+	a.audit.Record(ctx, audit.ActionCreate, audit.EntityAdminInvite, invite.ID.String(), nil, map[string]any{
+		"role":       role.Name,
+		"note":       invite.Note,
+		"expires_at": invite.ExpiresAt,
+	})
+
+	return &adminresponse.AdminInviteCreatedResponse{
+		AdminInviteResponse: adminresponse.ToAdminInviteResponse(*invite),
+		Token:               token,
+	}, nil
+}
+
+// GetAllInvites implements IAdminInviteService.
+func (a *AdminInviteServiceImpl) GetAllInvites(ctx context.Context, status string, page, limit int) ([]*models.AdminInvite, int, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case "", "pending", "used", "revoked", "expired":
+	default:
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "status must be pending, used, revoked or expired", 400)
+	}
+
+	offset := (page - 1) * limit
+	invites, total, err := a.inviteRepo.FindAll(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get invites", 500)
+	}
+
+	return invites, total, nil
+}
+
+// RevokeInvite implements IAdminInviteService.
+func (a *AdminInviteServiceImpl) RevokeInvite(ctx context.Context, inviteId uuid.UUID) error {
+	invite, err := a.inviteRepo.FindByID(ctx, inviteId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "invite not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get invite", 500)
+	}
+	if invite.UsedAt != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invite has already been used", 400)
+	}
+	if invite.RevokedAt != nil {
+		return nil
+	}
+
+	if err := a.inviteRepo.Revoke(ctx, inviteId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke invite", 500)
+	}
+	a.audit.Record(ctx, audit.ActionRevoke, audit.EntityAdminInvite, inviteId.String(), nil, nil)
+
+	return nil
+}
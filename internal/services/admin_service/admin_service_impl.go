@@ -7,8 +7,12 @@ import (
 	"giat-cerika-service/configs"
 	datasources "giat-cerika-service/internal/dataSources"
 	adminrequest "giat-cerika-service/internal/dto/request/admin_request"
+	adminresponse "giat-cerika-service/internal/dto/response/admin_response"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
 	"giat-cerika-service/internal/models"
 	adminrepo "giat-cerika-service/internal/repositories/admin_repo"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	rabbitmq "giat-cerika-service/pkg/constant/rabbitMq"
 	"giat-cerika-service/pkg/utils"
@@ -24,13 +28,52 @@ import (
 )
 
 type AdminServiceImpl struct {
-	adminRepo adminrepo.IAdminRepository
-	rdb       *redis.Client
-	cld       datasources.CloudinaryService
+	adminRepo   adminrepo.IAdminRepository
+	inviteRepo  adminrepo.IAdminInviteRepository
+	userSession usersessionservice.IUserSessionService
+	loginGuard  loginguardservice.ILoginGuardService
+	rdb         *redis.Client
+	cld         datasources.CloudinaryService
 }
 
-func NewAdminServiceImpl(adminRepo adminrepo.IAdminRepository, rdb *redis.Client, cld datasources.CloudinaryService) IAdminService {
-	return &AdminServiceImpl{adminRepo: adminRepo, rdb: rdb, cld: cld}
+func NewAdminServiceImpl(adminRepo adminrepo.IAdminRepository, inviteRepo adminrepo.IAdminInviteRepository, userSession usersessionservice.IUserSessionService, loginGuard loginguardservice.ILoginGuardService, rdb *redis.Client, cld datasources.CloudinaryService) IAdminService {
+	return &AdminServiceImpl{adminRepo: adminRepo, inviteRepo: inviteRepo, userSession: userSession, loginGuard: loginGuard, rdb: rdb, cld: cld}
+}
+
+var errInviteAlreadyUsed = errors.New("invite already used")
+
+// resolveRegisterRole menentukan role akun baru: dari undangan, atau role admin bila belum ada admin
+// sama sekali (bootstrap). Selain kondisi bootstrap, registrasi tanpa undangan ditolak.
+func (a *AdminServiceImpl) resolveRegisterRole(ctx context.Context, inviteToken string) (*models.Role, *models.AdminInvite, error) {
+	inviteToken = strings.TrimSpace(inviteToken)
+	if inviteToken == "" {
+		count, err := a.adminRepo.CountAdmins(ctx)
+		if err != nil {
+			return nil, nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to count admin", 500)
+		}
+		if count > 0 {
+			return nil, nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "Registrasi admin memerlukan undangan", 403)
+		}
+
+		role, err := a.adminRepo.FindRoleAdmin(ctx)
+		if err != nil {
+			return nil, nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to get role admin", 500)
+		}
+		return role, nil, nil
+	}
+
+	invite, err := a.inviteRepo.FindByTokenHash(ctx, utils.HashToken(inviteToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Undangan tidak valid", 400)
+		}
+		return nil, nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to get invite", 500)
+	}
+	if status := adminresponse.InviteStatus(*invite, time.Now()); status != "pending" {
+		return nil, nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Undangan sudah "+status, 400)
+	}
+
+	return &invite.Role, invite, nil
 }
 
 func fileAdminToBytes(fh *multipart.FileHeader) ([]byte, error) {
@@ -74,14 +117,15 @@ func (a *AdminServiceImpl) Register(ctx context.Context, req adminrequest.Regist
 		return errorresponse.NewCustomError(errorresponse.ErrExists, "Username Already Exists", 409)
 	}
 
+	role, invite, err := a.resolveRegisterRole(ctx, req.InviteToken)
+	if err != nil {
+		return err
+	}
+
 	hashed, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Failed to hashing password", 400)
 	}
-	role, err := a.adminRepo.FindRoleAdmin(ctx)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to get role admin", 500)
-	}
 
 	admin := &models.User{
 		ID:       uuid.New(),
@@ -92,8 +136,34 @@ func (a *AdminServiceImpl) Register(ctx context.Context, req adminrequest.Regist
 		Status:   1,
 	}
 
-	if err := a.adminRepo.Create(ctx, admin); err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create admin", 500)
+	if invite == nil {
+		if err := a.adminRepo.Create(ctx, admin); err != nil {
+			return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create admin", 500)
+		}
+	} else {
+		// ── Transaction: buat akun & tandai undangan terpakai secara atomik (sekali pakai) ──
+		if err := configs.RunTransaction(ctx, func(tx *gorm.DB) error {
+			if err := tx.Create(admin).Error; err != nil {
+				return err
+			}
+
+			now := time.Now()
+			result := tx.Model(&models.AdminInvite{}).
+				Where("id = ? AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", invite.ID, now).
+				Updates(map[string]interface{}{"used_at": now, "used_by": admin.ID})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errInviteAlreadyUsed
+			}
+			return nil
+		}); err != nil {
+			if errors.Is(err, errInviteAlreadyUsed) {
+				return errorresponse.NewCustomError(errorresponse.ErrExists, "Undangan sudah digunakan", 409)
+			}
+			return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create admin", 500)
+		}
 	}
 
 	if req.Photo != nil {
@@ -114,44 +184,32 @@ func (a *AdminServiceImpl) Register(ctx context.Context, req adminrequest.Regist
 }
 
 // Login implements IAdminService.
-func (a *AdminServiceImpl) Login(ctx context.Context, req adminrequest.LoginAdminRequest) (string, error) {
+func (a *AdminServiceImpl) Login(ctx context.Context, req adminrequest.LoginAdminRequest) (*sessionresponse.TokenResponse, error) {
+	if err := a.loginGuard.Check(ctx, req.Username, req.IPAddress); err != nil {
+		return nil, err
+	}
+
 	admin, err := a.adminRepo.FindUsername(ctx, req.Username)
 	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid credentials", 400)
+		a.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, nil)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid credentials", 400)
 	}
 
 	isPassword := utils.CheckPasswordHash(req.Password, admin.Password)
 	if !isPassword {
-		return "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "password incorrect", 400)
-	}
-
-	token, err := utils.GenerateToken(admin.ID.String(), admin.Role.Name)
-	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate token", 500)
-	}
-
-	expiry, err := utils.GetExpiryFromToken(token)
-	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get expiry token", 500)
+		a.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, &admin.ID)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "password incorrect", 400)
 	}
-
-	redisKey := fmt.Sprintf("admin_token:%s", admin.ID)
-	err = configs.SetRedis(ctx, redisKey, token, time.Until(expiry))
-	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store token in cache", 400)
+	a.loginGuard.RecordSuccess(ctx, req.Username)
+	if admin.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "account is disabled", 403)
 	}
 
-	return token, nil
+	return a.userSession.CreateSession(ctx, admin, req.UserAgent, req.IPAddress)
 }
 
 // GetProfile implements IAdminService.
-func (a *AdminServiceImpl) GetProfile(ctx context.Context, adminId uuid.UUID, token string) (*models.User, error) {
-	cacheKey := fmt.Sprintf("admin_token:%s", adminId)
-	storedToken, err := configs.GetRedis(ctx, cacheKey)
-	if err != nil || storedToken != token {
-		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "invalid or expired session", 401)
-	}
-
+func (a *AdminServiceImpl) GetProfile(ctx context.Context, adminId uuid.UUID) (*models.User, error) {
 	admin, err := a.adminRepo.FindByAdminID(ctx, adminId)
 	if err != nil {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "admin not found", 404)
@@ -160,35 +218,15 @@ func (a *AdminServiceImpl) GetProfile(ctx context.Context, adminId uuid.UUID, to
 	return admin, nil
 }
 
-// CheckTokenBlacklisted implements IAdminService.
-func (a *AdminServiceImpl) CheckTokenBlacklisted(ctx context.Context, token string) (bool, error) {
-	blackListeed := fmt.Sprintf("blacklistToken_admin:%s", token)
-	val, err := configs.GetRedis(ctx, blackListeed)
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return false, nil
-		}
-		return false, errorresponse.NewCustomError(errorresponse.ErrNotFound, "blacklisted token not found", 404)
-	}
-	return val == "blacklister", nil
-}
-
 // Logout implements IAdminService.
-func (a *AdminServiceImpl) Logout(ctx context.Context, adminID uuid.UUID, token string) error {
-	expiry, err := utils.GetExpiryFromToken(token)
-	if err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get expiry token", 500)
+func (a *AdminServiceImpl) Logout(ctx context.Context, adminID uuid.UUID, claims *utils.JWTClaims) error {
+	if err := a.userSession.RevokeToken(ctx, claims); err != nil {
+		return err
 	}
 
-	blackListKey := fmt.Sprintf("blacklistToken_admin:%s", token)
-	err = configs.SetRedis(ctx, blackListKey, "blacklisted", time.Until(expiry))
-	if err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to blacklist token", 500)
-	}
-	cacheKey := fmt.Sprintf("admin_token:%s", adminID)
-	err = configs.DeleteRedis(ctx, cacheKey)
-	if err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete token", 500)
+	// Logout hanya mengakhiri sesi perangkat ini; sesi di perangkat lain tetap aktif.
+	if sessionId, err := uuid.Parse(claims.SessionID); err == nil {
+		return a.userSession.RevokeSession(ctx, adminID, sessionId)
 	}
 
 	return nil
@@ -0,0 +1,16 @@
+package adminservice
+
+import (
+	"context"
+	adminrequest "giat-cerika-service/internal/dto/request/admin_request"
+	adminresponse "giat-cerika-service/internal/dto/response/admin_response"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAdminInviteService interface {
+	CreateInvite(ctx context.Context, adminId uuid.UUID, req adminrequest.CreateAdminInviteRequest) (*adminresponse.AdminInviteCreatedResponse, error)
+	GetAllInvites(ctx context.Context, status string, page, limit int) ([]*models.AdminInvite, int, error)
+	RevokeInvite(ctx context.Context, inviteId uuid.UUID) error
+}
@@ -3,15 +3,16 @@ package adminservice
 import (
 	"context"
 	adminrequest "giat-cerika-service/internal/dto/request/admin_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
 	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
 
 	"github.com/google/uuid"
 )
 
 type IAdminService interface {
 	Register(ctx context.Context, req adminrequest.RegisterAdminRequest) error
-	Login(ctx context.Context, req adminrequest.LoginAdminRequest) (string, error)
-	GetProfile(ctx context.Context, adminId uuid.UUID, token string) (*models.User, error)
-	Logout(ctx context.Context, adminID uuid.UUID, token string) error
-	CheckTokenBlacklisted(ctx context.Context, token string) (bool, error)
+	Login(ctx context.Context, req adminrequest.LoginAdminRequest) (*sessionresponse.TokenResponse, error)
+	GetProfile(ctx context.Context, adminId uuid.UUID) (*models.User, error)
+	Logout(ctx context.Context, adminID uuid.UUID, claims *utils.JWTClaims) error
 }
@@ -0,0 +1,144 @@
+package alarmservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	alarmrequest "giat-cerika-service/internal/dto/request/alarm_request"
+	"giat-cerika-service/internal/models"
+	alarmrepo "giat-cerika-service/internal/repositories/alarm_repo"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type AlarmServiceImpl struct {
+	alarmRepo alarmrepo.IAlarmRepository
+	rdb       *redis.Client
+}
+
+func NewAlarmServiceImpl(alarmRepo alarmrepo.IAlarmRepository, rdb *redis.Client) IAlarmService {
+	return &AlarmServiceImpl{alarmRepo: alarmRepo, rdb: rdb}
+}
+
+// normalizeClock memvalidasi input jam alarm (HH:MM, 24 jam) dan mengembalikan format baku "15:04".
+// String kosong berarti alarm untuk sesi tersebut dimatikan.
+func normalizeClock(value string, label string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("format jam %s harus HH:MM", label), 400)
+	}
+
+	return t.Format("15:04"), nil
+}
+
+// CreateAlarm implements IAlarmService.
+func (a *AlarmServiceImpl) CreateAlarm(ctx context.Context, studentId uuid.UUID, req alarmrequest.CreateAlarmRequest) error {
+	if studentId == uuid.Nil {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "student id is required", 400)
+	}
+
+	existAlarm, err := a.alarmRepo.FindByUserID(ctx, studentId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get alarm", 500)
+	}
+	if existAlarm != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "alarm already exists", 409)
+	}
+
+	morning, err := normalizeClock(req.Morning, "pagi")
+	if err != nil {
+		return err
+	}
+	night, err := normalizeClock(req.Night, "malam")
+	if err != nil {
+		return err
+	}
+	if morning == "" && night == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "morning or night is required", 400)
+	}
+
+	newAlarm := &models.Alarm{
+		ID:      uuid.New(),
+		UserID:  studentId,
+		Morning: morning,
+		Night:   night,
+	}
+
+	if err := a.alarmRepo.Create(ctx, newAlarm); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create alarm", 500)
+	}
+
+	_ = configs.DeleteRedis(ctx, fmt.Sprintf("alarm:%s", studentId))
+
+	return nil
+}
+
+// GetMyAlarm implements IAlarmService.
+func (a *AlarmServiceImpl) GetMyAlarm(ctx context.Context, studentId uuid.UUID) (*models.Alarm, error) {
+	cacheKey := fmt.Sprintf("alarm:%s", studentId)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var alarm models.Alarm
+		if json.Unmarshal([]byte(cached), &alarm) == nil {
+			return &alarm, nil
+		}
+	}
+
+	alarm, err := a.alarmRepo.FindByUserID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "alarm not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get alarm", 500)
+	}
+
+	buf, _ := json.Marshal(alarm)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return alarm, nil
+}
+
+// UpdateAlarm implements IAlarmService.
+func (a *AlarmServiceImpl) UpdateAlarm(ctx context.Context, studentId uuid.UUID, req alarmrequest.UpdateAlarmRequest) error {
+	alarm, err := a.alarmRepo.FindByUserID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "alarm not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get alarm", 500)
+	}
+
+	if req.Morning != nil {
+		morning, err := normalizeClock(*req.Morning, "pagi")
+		if err != nil {
+			return err
+		}
+		alarm.Morning = morning
+	}
+	if req.Night != nil {
+		night, err := normalizeClock(*req.Night, "malam")
+		if err != nil {
+			return err
+		}
+		alarm.Night = night
+	}
+
+	if err := a.alarmRepo.Update(ctx, alarm.ID, alarm); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update alarm", 500)
+	}
+
+	_ = configs.DeleteRedis(ctx, fmt.Sprintf("alarm:%s", studentId))
+
+	return nil
+}
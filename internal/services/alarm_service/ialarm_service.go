@@ -0,0 +1,15 @@
+package alarmservice
+
+import (
+	"context"
+	alarmrequest "giat-cerika-service/internal/dto/request/alarm_request"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAlarmService interface {
+	CreateAlarm(ctx context.Context, studentId uuid.UUID, req alarmrequest.CreateAlarmRequest) error
+	GetMyAlarm(ctx context.Context, studentId uuid.UUID) (*models.Alarm, error)
+	UpdateAlarm(ctx context.Context, studentId uuid.UUID, req alarmrequest.UpdateAlarmRequest) error
+}
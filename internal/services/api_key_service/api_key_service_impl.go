@@ -0,0 +1,143 @@
+package apikeyservice
+
+import (
+	"context"
+	"errors"
+	apikeyrequest "giat-cerika-service/internal/dto/request/api_key_request"
+	apikeyresponse "giat-cerika-service/internal/dto/response/api_key_response"
+	"giat-cerika-service/internal/models"
+	apikeyrepo "giat-cerika-service/internal/repositories/api_key_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/permission"
+	"giat-cerika-service/pkg/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyBytes            = 32
+	apiKeyPrefixLength     = 8
+	defaultAPIKeyExpiryDay = 90
+	maxAPIKeyExpiryDay     = 365
+)
+
+type APIKeyServiceImpl struct {
+	apiKeyRepo apikeyrepo.IAPIKeyRepository
+	audit      auditservice.IAuditService
+	rdb        *redis.Client
+}
+
+func NewAPIKeyServiceImpl(apiKeyRepo apikeyrepo.IAPIKeyRepository, auditService auditservice.IAuditService, rdb *redis.Client) IAPIKeyService {
+	return &APIKeyServiceImpl{apiKeyRepo: apiKeyRepo, audit: auditService, rdb: rdb}
+}
+
+// CreateAPIKey implements IAPIKeyService.
+func (a *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, adminId uuid.UUID, req apikeyrequest.CreateAPIKeyRequest) (*apikeyresponse.APIKeyCreatedResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "name is required", 400)
+	}
+
+	scopes := []string{}
+	for _, scope := range req.Scopes {
+		scope = strings.TrimSpace(scope)
+		if !slices.Contains(permission.APIKeyScopes, scope) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "scope not allowed for api key: "+scope, 400)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "at least one scope is required", 400)
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = defaultAPIKeyExpiryDay
+	}
+	if days < 1 || days > maxAPIKeyExpiryDay {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "expires_in_days must be between 1 and 365", 400)
+	}
+
+	token, err := utils.GenerateOpaqueToken(apiKeyBytes)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate api key", 500)
+	}
+	rawKey := utils.APIKeyTokenPrefix + token
+
+	key := &models.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    rawKey[:len(utils.APIKeyTokenPrefix)+apiKeyPrefixLength],
+		KeyHash:   utils.HashToken(rawKey),
+		Scopes:    pq.StringArray(scopes),
+		CreatedBy: adminId,
+		ExpiresAt: time.Now().Add(time.Duration(days) * 24 * time.Hour),
+	}
+	if err := a.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create api key", 500)
+	}
+	a.audit.Record(ctx, audit.ActionCreate, audit.EntityAPIKey, key.ID.String(), nil, map[string]any{
+		"name":       key.Name,
+		"prefix":     key.Prefix,
+		"scopes":     scopes,
+		"expires_at": key.ExpiresAt,
+	})
+
+	return &apikeyresponse.APIKeyCreatedResponse{
+		APIKeyResponse: apikeyresponse.ToAPIKeyResponse(*key),
+		Key:            rawKey,
+	}, nil
+}
+
+// GetAllAPIKeys implements IAPIKeyService.
+func (a *APIKeyServiceImpl) GetAllAPIKeys(ctx context.Context, status string, page, limit int) ([]*models.APIKey, int, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case "", "active", "revoked", "expired":
+	default:
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "status must be active, revoked or expired", 400)
+	}
+
+	offset := (page - 1) * limit
+	keys, total, err := a.apiKeyRepo.FindAll(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get api keys", 500)
+	}
+
+	return keys, total, nil
+}
+
+// RevokeAPIKey implements IAPIKeyService.
+func (a *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, keyId uuid.UUID) error {
+	key, err := a.apiKeyRepo.FindByID(ctx, keyId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "api key not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get api key", 500)
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	if err := a.apiKeyRepo.Revoke(ctx, keyId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke api key", 500)
+	}
+	a.rdb.Del(ctx, utils.APIKeyCacheKey(key.KeyHash))
+	a.audit.Record(ctx, audit.ActionRevoke, audit.EntityAPIKey, keyId.String(), nil, map[string]any{
+		"name":   key.Name,
+		"prefix": key.Prefix,
+	})
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package apikeyservice
+
+import (
+	"context"
+	apikeyrequest "giat-cerika-service/internal/dto/request/api_key_request"
+	apikeyresponse "giat-cerika-service/internal/dto/response/api_key_response"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IAPIKeyService interface {
+	CreateAPIKey(ctx context.Context, adminId uuid.UUID, req apikeyrequest.CreateAPIKeyRequest) (*apikeyresponse.APIKeyCreatedResponse, error)
+	GetAllAPIKeys(ctx context.Context, status string, page, limit int) ([]*models.APIKey, int, error)
+	// RevokeAPIKey langsung menghapus cache lookup sehingga key tidak bisa dipakai lagi di request berikutnya.
+	RevokeAPIKey(ctx context.Context, keyId uuid.UUID) error
+}
@@ -0,0 +1,71 @@
+package auditservice
+
+import (
+	"context"
+	auditrequest "giat-cerika-service/internal/dto/request/audit_request"
+	"giat-cerika-service/internal/models"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type AuditServiceImpl struct {
+	auditRepo auditrepo.IAuditRepository
+}
+
+func NewAuditServiceImpl(auditRepo auditrepo.IAuditRepository) IAuditService {
+	return &AuditServiceImpl{auditRepo: auditRepo}
+}
+
+// Record implements IAuditService.
+func (a *AuditServiceImpl) Record(ctx context.Context, action string, entityType string, entityId string, before any, after any) {
+	beforeJSON, afterJSON := utils.AuditDiff(before, after)
+
+	entry := &models.AuditLog{
+		ID:         uuid.New(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityId,
+		Before:     beforeJSON,
+		After:      afterJSON,
+	}
+	if actor, ok := utils.AuditActorFromContext(ctx); ok {
+		if actorId, err := uuid.Parse(actor.UserID); err == nil {
+			entry.ActorID = &actorId
+		}
+		entry.ActorRole = actor.Role
+		entry.IPAddress = actor.IPAddress
+	}
+
+	// context request bisa sudah dibatalkan setelah respons dikirim; audit tetap harus tersimpan
+	if err := a.auditRepo.Create(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("failed to record audit log %s %s %s: %v", action, entityType, entityId, err)
+	}
+}
+
+// Search implements IAuditService.
+func (a *AuditServiceImpl) Search(ctx context.Context, req auditrequest.SearchAuditLogRequest, page int, limit int) ([]*models.AuditLog, int, error) {
+	offset := (page - 1) * limit
+	filter := auditrepo.AuditLogFilter{
+		ActorID:    req.ActorID,
+		Action:     strings.TrimSpace(req.Action),
+		EntityType: strings.TrimSpace(req.EntityType),
+		EntityID:   strings.TrimSpace(req.EntityID),
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+	}
+
+	items, total, err := a.auditRepo.FindAll(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get audit logs", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.AuditLog{}
+	}
+
+	return items, total, nil
+}
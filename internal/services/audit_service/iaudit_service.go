@@ -0,0 +1,14 @@
+package auditservice
+
+import (
+	"context"
+	auditrequest "giat-cerika-service/internal/dto/request/audit_request"
+	"giat-cerika-service/internal/models"
+)
+
+type IAuditService interface {
+	// Record mencatat mutasi oleh aktor pada context (diisi JWTMiddleware). before/after boleh nil
+	// untuk create/delete. Kegagalan hanya di-log agar tidak membatalkan operasi yang sudah berhasil.
+	Record(ctx context.Context, action, entityType, entityId string, before, after any)
+	Search(ctx context.Context, req auditrequest.SearchAuditLogRequest, page, limit int) ([]*models.AuditLog, int, error)
+}
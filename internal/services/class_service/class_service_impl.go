@@ -7,9 +7,15 @@ import (
 	"fmt"
 	"giat-cerika-service/configs"
 	classrequest "giat-cerika-service/internal/dto/request/class_request"
+	classresponse "giat-cerika-service/internal/dto/response/class_response"
+	toothbrushresponse "giat-cerika-service/internal/dto/response/toothbrush_response"
 	"giat-cerika-service/internal/models"
 	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,11 +26,12 @@ import (
 
 type ClassServiceImpl struct {
 	classRepo classrepo.IClassRepository
+	audit     auditservice.IAuditService
 	rdb       *redis.Client
 }
 
-func NewClassServiceImpl(classRepo classrepo.IClassRepository, rdb *redis.Client) IClassService {
-	return &ClassServiceImpl{classRepo: classRepo, rdb: rdb}
+func NewClassServiceImpl(classRepo classrepo.IClassRepository, auditService auditservice.IAuditService, rdb *redis.Client) IClassService {
+	return &ClassServiceImpl{classRepo: classRepo, audit: auditService, rdb: rdb}
 }
 
 func (c *ClassServiceImpl) invalidateCacheClass(ctx context.Context) {
@@ -73,6 +80,7 @@ func (c *ClassServiceImpl) CreateClass(ctx context.Context, req classrequest.Cre
 	}
 
 	c.invalidateCacheClass(ctx)
+	c.audit.Record(ctx, audit.ActionCreate, audit.EntityClass, newClass.ID.String(), nil, newClass)
 
 	return nil
 
@@ -80,7 +88,11 @@ func (c *ClassServiceImpl) CreateClass(ctx context.Context, req classrequest.Cre
 
 // GetAllClass implements IClassService.
 func (c *ClassServiceImpl) GetAllClass(ctx context.Context, page int, limit int, search string) ([]*models.Class, int, error) {
+	teacherId := utils.TeacherScopeFromContext(ctx)
 	cacheKey := fmt.Sprintf("classes:search:%s:page:%d:limit:%d", search, page, limit)
+	if teacherId != nil {
+		cacheKey = fmt.Sprintf("%s:teacher:%s", cacheKey, *teacherId)
+	}
 	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
 		var result struct {
 			Data  []*models.Class `json:"data"`
@@ -93,7 +105,7 @@ func (c *ClassServiceImpl) GetAllClass(ctx context.Context, page int, limit int,
 
 	offset := (page - 1) * limit
 
-	items, total, err := c.classRepo.FindAll(ctx, limit, offset, search)
+	items, total, err := c.classRepo.FindAll(ctx, limit, offset, search, teacherId)
 	if err != nil {
 		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
 	}
@@ -142,6 +154,7 @@ func (c *ClassServiceImpl) UpdateClass(ctx context.Context, classId uuid.UUID, r
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
 	}
+	before := *class
 
 	existsClass, err := c.classRepo.FindByNameClass(ctx, req.NameClass)
 	if err == nil && existsClass.ID != classId {
@@ -164,13 +177,14 @@ func (c *ClassServiceImpl) UpdateClass(ctx context.Context, classId uuid.UUID, r
 	}
 
 	c.invalidateCacheClass(ctx)
+	c.audit.Record(ctx, audit.ActionUpdate, audit.EntityClass, classId.String(), before, class)
 
 	return nil
 }
 
 // DeleteClass implements IClassService.
 func (c *ClassServiceImpl) DeleteClass(ctx context.Context, classId uuid.UUID) error {
-	_, err := c.classRepo.FindById(ctx, classId)
+	class, err := c.classRepo.FindById(ctx, classId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "class not found", 404)
@@ -184,6 +198,82 @@ func (c *ClassServiceImpl) DeleteClass(ctx context.Context, classId uuid.UUID) e
 	}
 
 	c.invalidateCacheClass(ctx)
+	c.audit.Record(ctx, audit.ActionDelete, audit.EntityClass, classId.String(), class, nil)
+
+	return nil
+}
+
+// invalidateCacheTeacherScope menghapus cache daftar yang dibatasi per guru setelah relasi guru-kelas berubah.
+func (c *ClassServiceImpl) invalidateCacheTeacherScope(ctx context.Context) {
+	for _, pattern := range []string{"quizHistory:all:teacher:*", "predictions:*:teacher:*", "classes:*:teacher:*", "toothbrush:class-compliance:*:teacher:*"} {
+		iter := c.rdb.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			c.rdb.Del(ctx, iter.Val())
+		}
+	}
+}
+
+// GetClassTeachers implements IClassService.
+func (c *ClassServiceImpl) GetClassTeachers(ctx context.Context, classId uuid.UUID) ([]*models.User, error) {
+	if _, err := c.classRepo.FindById(ctx, classId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "class not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
+	}
+
+	teachers, err := c.classRepo.FindTeachersByClass(ctx, classId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class teachers", 500)
+	}
+
+	return teachers, nil
+}
+
+// UpdateClassTeachers implements IClassService.
+func (c *ClassServiceImpl) UpdateClassTeachers(ctx context.Context, classId uuid.UUID, req classrequest.UpdateClassTeachersRequest) error {
+	if _, err := c.classRepo.FindById(ctx, classId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "class not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.TeacherIds))
+	seen := make(map[uuid.UUID]bool, len(req.TeacherIds))
+	for _, id := range req.TeacherIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	teachers, err := c.classRepo.FindTeachersByIds(ctx, ids)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get teachers", 500)
+	}
+	if len(teachers) != len(ids) {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "some teachers not found", 404)
+	}
+
+	current, err := c.classRepo.FindTeachersByClass(ctx, classId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class teachers", 500)
+	}
+	before := make([]uuid.UUID, len(current))
+	for i, teacher := range current {
+		before[i] = teacher.ID
+	}
+
+	if err := c.classRepo.ReplaceTeachers(ctx, classId, ids); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update class teachers", 500)
+	}
+
+	c.invalidateCacheTeacherScope(ctx)
+	c.audit.Record(ctx, audit.ActionUpdate, audit.EntityClass, classId.String(),
+		map[string]any{"teacher_ids": before},
+		map[string]any{"teacher_ids": ids},
+	)
 
 	return nil
 }
@@ -211,3 +301,255 @@ func (c *ClassServiceImpl) GetAllPublic(ctx context.Context) ([]*models.Class, e
 	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*60)
 	return items, nil
 }
+
+// GetToothBrushCompliance implements IClassService.
+// Hari "terlewat" adalah hari tanpa satu pun sesi sikat gigi tercatat. Hari sebelum siswa terdaftar tidak dihitung.
+func (c *ClassServiceImpl) GetToothBrushCompliance(ctx context.Context, classId *uuid.UUID, startDate, endDate time.Time, missedDays int) (*classresponse.ClassToothBrushComplianceResponse, error) {
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	nowJakarta := time.Now().In(locJakarta)
+	today := time.Date(nowJakarta.Year(), nowJakarta.Month(), nowJakarta.Day(), 0, 0, 0, 0, time.UTC)
+
+	if endDate.IsZero() {
+		endDate = today
+	}
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -6)
+	}
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	if missedDays <= 0 {
+		missedDays = 3
+	}
+
+	if startDate.After(endDate) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "start date must be before end date", 400)
+	}
+	if endDate.Sub(startDate) > 366*24*time.Hour {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "date range maximum 366 days", 400)
+	}
+
+	// guru hanya melihat kelas yang diampunya; tanpa class_id rekap dibatasi ke kelas-kelas tersebut
+	teacherId := utils.TeacherScopeFromContext(ctx)
+	classKey := "all"
+	if classId != nil {
+		if _, err := c.classRepo.FindById(ctx, *classId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "class not found", 404)
+			}
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
+		}
+		if teacherId != nil {
+			isTeacher, err := c.classRepo.IsClassTeacher(ctx, *classId, *teacherId)
+			if err != nil {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check class teacher", 500)
+			}
+			if !isTeacher {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "class is outside your teaching scope", 403)
+			}
+		}
+		classKey = classId.String()
+	} else if teacherId != nil {
+		classKey = fmt.Sprintf("all:teacher:%s", *teacherId)
+	}
+
+	// prefix toothbrush:* agar ikut terhapus saat ada log sikat gigi baru
+	cacheKey := fmt.Sprintf("toothbrush:class-compliance:%s:start:%s:end:%s:missed:%d:today:%s",
+		classKey, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), missedDays, today.Format("2006-01-02"))
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result classresponse.ClassToothBrushComplianceResponse
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return &result, nil
+		}
+	}
+
+	students, err := c.classRepo.FindStudentsByClass(ctx, classId, teacherId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get students", 500)
+	}
+
+	effectiveEnd := endDate
+	if effectiveEnd.After(today) {
+		effectiveEnd = today
+	}
+
+	days := []time.Time{}
+	for d := startDate; !d.After(effectiveEnd); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+
+	// user → tanggal (YYYY-MM-DD) → time_type → tercatat
+	sessions := make(map[uuid.UUID]map[string]map[string]bool)
+	if len(days) > 0 {
+		logs, err := c.classRepo.FindToothBrushLogsByClass(ctx, classId, teacherId, startDate, effectiveEnd)
+		if err != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get toothbrush logs", 500)
+		}
+		for _, l := range logs {
+			d, err := utils.ParseLogDate(l.LogDate)
+			if err != nil {
+				continue
+			}
+			key := d.Format("2006-01-02")
+			if sessions[l.UserID] == nil {
+				sessions[l.UserID] = make(map[string]map[string]bool)
+			}
+			if sessions[l.UserID][key] == nil {
+				sessions[l.UserID][key] = make(map[string]bool)
+			}
+			sessions[l.UserID][key][l.TimeType] = true
+		}
+	}
+
+	trend := make([]classresponse.DailyComplianceTrendResponse, len(days))
+	for i, d := range days {
+		trend[i].Date = utils.FormatOnlyDate(d)
+	}
+
+	type classAggregate struct {
+		class    models.Class
+		students int
+		morning  int
+		night    int
+		expected int
+	}
+	classAgg := make(map[uuid.UUID]*classAggregate)
+
+	studentRes := make([]classresponse.StudentComplianceResponse, 0, len(students))
+	missedRes := []classresponse.MissedStudentResponse{}
+
+	for _, st := range students {
+		joinedJakarta := st.CreatedAt.In(locJakarta)
+		joined := time.Date(joinedJakarta.Year(), joinedJakarta.Month(), joinedJakarta.Day(), 0, 0, 0, 0, time.UTC)
+
+		morning, night, expected := 0, 0, 0
+		longestMissed, currentMissed := 0, 0
+		lastLog := ""
+		for i, d := range days {
+			if d.Before(joined) {
+				continue
+			}
+			expected++
+			trend[i].ActiveStudents++
+
+			types := sessions[st.ID][d.Format("2006-01-02")]
+			if types["MORNING"] {
+				morning++
+				trend[i].MorningCount++
+			}
+			if types["NIGHT"] {
+				night++
+				trend[i].NightCount++
+			}
+
+			if len(types) == 0 {
+				currentMissed++
+				if currentMissed > longestMissed {
+					longestMissed = currentMissed
+				}
+			} else {
+				currentMissed = 0
+				lastLog = utils.FormatOnlyDate(d)
+			}
+		}
+
+		var name, nisn string
+		if st.Name != nil {
+			name = *st.Name
+		}
+		if st.Nisn != nil {
+			nisn = *st.Nisn
+		}
+
+		studentRes = append(studentRes, classresponse.StudentComplianceResponse{
+			StudentID: st.ID,
+			Name:      name,
+			Nisn:      nisn,
+			Class:     st.Class.NameClass,
+			Morning: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: morning, Expected: expected, Rate: utils.ComplianceRate(morning, expected),
+			},
+			Night: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: night, Expected: expected, Rate: utils.ComplianceRate(night, expected),
+			},
+			Overall: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: morning + night, Expected: expected * 2, Rate: utils.ComplianceRate(morning+night, expected*2),
+			},
+			LongestMissedRun: longestMissed,
+			CurrentMissedRun: currentMissed,
+			LastLogDate:      lastLog,
+		})
+
+		if longestMissed >= missedDays {
+			missedRes = append(missedRes, classresponse.MissedStudentResponse{
+				StudentID:        st.ID,
+				Name:             name,
+				Nisn:             nisn,
+				Class:            st.Class.NameClass,
+				LongestMissedRun: longestMissed,
+				CurrentMissedRun: currentMissed,
+				LastLogDate:      lastLog,
+			})
+		}
+
+		if st.ClassID == nil {
+			continue
+		}
+		agg, ok := classAgg[*st.ClassID]
+		if !ok {
+			agg = &classAggregate{class: st.Class}
+			classAgg[*st.ClassID] = agg
+		}
+		agg.students++
+		agg.morning += morning
+		agg.night += night
+		agg.expected += expected
+	}
+
+	for i := range trend {
+		trend[i].Rate = utils.ComplianceRate(trend[i].MorningCount+trend[i].NightCount, trend[i].ActiveStudents*2)
+	}
+
+	classRes := make([]classresponse.ClassComplianceResponse, 0, len(classAgg))
+	for id, agg := range classAgg {
+		classRes = append(classRes, classresponse.ClassComplianceResponse{
+			ClassID:       id,
+			NameClass:     agg.class.NameClass,
+			Grade:         agg.class.Grade,
+			TotalStudents: agg.students,
+			Morning: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: agg.morning, Expected: agg.expected, Rate: utils.ComplianceRate(agg.morning, agg.expected),
+			},
+			Night: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: agg.night, Expected: agg.expected, Rate: utils.ComplianceRate(agg.night, agg.expected),
+			},
+			Overall: toothbrushresponse.ToothBrushComplianceResponse{
+				Logged: agg.morning + agg.night, Expected: agg.expected * 2, Rate: utils.ComplianceRate(agg.morning+agg.night, agg.expected*2),
+			},
+		})
+	}
+	sort.Slice(classRes, func(i, j int) bool { return classRes[i].NameClass < classRes[j].NameClass })
+
+	// siswa yang masih belum absen hingga hari terakhir ditampilkan paling atas
+	sort.SliceStable(missedRes, func(i, j int) bool {
+		if missedRes[i].CurrentMissedRun != missedRes[j].CurrentMissedRun {
+			return missedRes[i].CurrentMissedRun > missedRes[j].CurrentMissedRun
+		}
+		return missedRes[i].LongestMissedRun > missedRes[j].LongestMissedRun
+	})
+
+	result := &classresponse.ClassToothBrushComplianceResponse{
+		StartDate:      utils.FormatOnlyDate(startDate),
+		EndDate:        utils.FormatOnlyDate(endDate),
+		TotalDays:      len(days),
+		MissedDays:     missedDays,
+		Classes:        classRes,
+		Students:       studentRes,
+		MissedStudents: missedRes,
+		DailyTrend:     trend,
+	}
+
+	buf, _ := json.Marshal(result)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return result, nil
+}
@@ -3,7 +3,9 @@ package classservice
 import (
 	"context"
 	classrequest "giat-cerika-service/internal/dto/request/class_request"
+	classresponse "giat-cerika-service/internal/dto/response/class_response"
 	"giat-cerika-service/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,5 +17,10 @@ type IClassService interface {
 	UpdateClass(ctx context.Context, classId uuid.UUID, req classrequest.UpdateClassRequest) error
 	DeleteClass(ctx context.Context, classId uuid.UUID) error
 
+	GetClassTeachers(ctx context.Context, classId uuid.UUID) ([]*models.User, error)
+	UpdateClassTeachers(ctx context.Context, classId uuid.UUID, req classrequest.UpdateClassTeachersRequest) error
+
 	GetAllPublic(ctx context.Context) ([]*models.Class, error)
+
+	GetToothBrushCompliance(ctx context.Context, classId *uuid.UUID, startDate, endDate time.Time, missedDays int) (*classresponse.ClassToothBrushComplianceResponse, error)
 }
@@ -0,0 +1,353 @@
+package guardianservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	guardianrequest "giat-cerika-service/internal/dto/request/guardian_request"
+	guardianresponse "giat-cerika-service/internal/dto/response/guardian_response"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	"giat-cerika-service/internal/models"
+	guardianrepo "giat-cerika-service/internal/repositories/guardian_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	linkedViaNisnDob = "nisn_dob"
+	linkedViaCode    = "code"
+
+	// linkCodeTTL memberi waktu orang tua menerima kode dari sekolah (mis. lewat buku penghubung).
+	linkCodeTTL          = 7 * 24 * time.Hour
+	linkCodeLength       = 8
+	linkMaxAttempts      = 5
+	linkMaxIPAttempts    = 20
+	linkAttemptWindow    = 15 * time.Minute
+	guardianRoleName     = "guardian"
+	studentRoleName      = "student"
+	guardianStatusActive = 1
+)
+
+type GuardianServiceImpl struct {
+	guardianRepo guardianrepo.IGuardianRepository
+	studentRepo  studentrepo.IStudentRepository
+	userSession  usersessionservice.IUserSessionService
+	loginGuard   loginguardservice.ILoginGuardService
+	audit        auditservice.IAuditService
+	rdb          *redis.Client
+}
+
+func NewGuardianServiceImpl(guardianRepo guardianrepo.IGuardianRepository, studentRepo studentrepo.IStudentRepository, userSession usersessionservice.IUserSessionService, loginGuard loginguardservice.ILoginGuardService, auditService auditservice.IAuditService, rdb *redis.Client) IGuardianService {
+	return &GuardianServiceImpl{guardianRepo: guardianRepo, studentRepo: studentRepo, userSession: userSession, loginGuard: loginGuard, audit: auditService, rdb: rdb}
+}
+
+func linkCodeKey(codeHash string) string {
+	return fmt.Sprintf("guardian_link_code:%s", codeHash)
+}
+
+func linkCodeStudentKey(studentId uuid.UUID) string {
+	return fmt.Sprintf("guardian_link_code_student:%s", studentId)
+}
+
+func linkAttemptKey(scope, value string) string {
+	return fmt.Sprintf("guardian_link_attempt:%s:%s", scope, value)
+}
+
+// checkLinkAttempts menolak permintaan bila percobaan gagal pada key ini sudah mencapai limit.
+func (g *GuardianServiceImpl) checkLinkAttempts(ctx context.Context, key string, limit int) error {
+	count, err := g.rdb.Get(ctx, key).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get cache", 500)
+	}
+	if count >= limit {
+		return errorresponse.NewCustomError(errorresponse.ErrTooManyRequests, "too many attempts, please try again later", 429)
+	}
+	return nil
+}
+
+func (g *GuardianServiceImpl) recordLinkFailure(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		count, err := g.rdb.Incr(ctx, key).Result()
+		if err == nil && count == 1 {
+			g.rdb.Expire(ctx, key, linkAttemptWindow)
+		}
+	}
+}
+
+// Register implements IGuardianService.
+func (g *GuardianServiceImpl) Register(ctx context.Context, req guardianrequest.RegisterGuardianRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Name is required", 400)
+	}
+	if strings.TrimSpace(req.Username) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Username is required", 400)
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Password is required", 400)
+	}
+	if req.Password != req.ConfirmPassword {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Password dan konfirmasi password tidak cocok", 400)
+	}
+
+	uniqueUsername, err := g.studentRepo.FindUsernameUnique(ctx, req.Username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get unique username", 500)
+	}
+	if uniqueUsername != "" {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "Username sudah terdaftar", 409)
+	}
+
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "failed to hashing password", 400)
+	}
+
+	role, err := g.guardianRepo.FindRoleGuardian(ctx)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get role", 500)
+	}
+
+	newGuardian := &models.User{
+		ID:       uuid.New(),
+		Name:     &req.Name,
+		Username: req.Username,
+		Password: hashed,
+		RoleID:   role.ID,
+		Status:   guardianStatusActive,
+	}
+
+	if err := g.guardianRepo.Create(ctx, newGuardian); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create guardian", 500)
+	}
+
+	return nil
+}
+
+// Login implements IGuardianService.
+func (g *GuardianServiceImpl) Login(ctx context.Context, req guardianrequest.LoginGuardianRequest) (*sessionresponse.TokenResponse, error) {
+	if err := g.loginGuard.Check(ctx, req.Username, req.IPAddress); err != nil {
+		return nil, err
+	}
+
+	guardian, err := g.guardianRepo.FindByUsername(ctx, req.Username)
+	if err != nil || guardian.Role.Name != guardianRoleName {
+		g.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, nil)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid credential", 400)
+	}
+
+	if !utils.CheckPasswordHash(req.Password, guardian.Password) {
+		g.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, &guardian.ID)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "password incorrect", 400)
+	}
+	g.loginGuard.RecordSuccess(ctx, req.Username)
+	if guardian.Status != guardianStatusActive {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "akun dinonaktifkan, hubungi admin sekolah", 403)
+	}
+
+	return g.userSession.CreateSession(ctx, guardian, req.UserAgent, req.IPAddress)
+}
+
+// GetProfile implements IGuardianService.
+func (g *GuardianServiceImpl) GetProfile(ctx context.Context, guardianId uuid.UUID) (*models.User, error) {
+	guardian, err := g.guardianRepo.FindById(ctx, guardianId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "guardian not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get guardian", 500)
+	}
+
+	return guardian, nil
+}
+
+// Logout implements IGuardianService.
+func (g *GuardianServiceImpl) Logout(ctx context.Context, guardianId uuid.UUID, claims *utils.JWTClaims) error {
+	if err := g.userSession.RevokeToken(ctx, claims); err != nil {
+		return err
+	}
+
+	if sessionId, err := uuid.Parse(claims.SessionID); err == nil {
+		return g.userSession.RevokeSession(ctx, guardianId, sessionId)
+	}
+	return nil
+}
+
+// LinkStudent implements IGuardianService.
+func (g *GuardianServiceImpl) LinkStudent(ctx context.Context, guardianId uuid.UUID, req guardianrequest.LinkStudentRequest) (*models.User, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	nisn := strings.TrimSpace(req.Nisn)
+	if code == "" && (nisn == "" || req.DateOfBirth.IsZero()) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "code or nisn and date of birth is required", 400)
+	}
+
+	guardianKey := linkAttemptKey("guardian", guardianId.String())
+	ipKey := linkAttemptKey("ip", req.IPAddress)
+	if err := g.checkLinkAttempts(ctx, guardianKey, linkMaxAttempts); err != nil {
+		return nil, err
+	}
+	if err := g.checkLinkAttempts(ctx, ipKey, linkMaxIPAttempts); err != nil {
+		return nil, err
+	}
+
+	var (
+		student   *models.User
+		linkedVia string
+		err       error
+	)
+	notFoundErr := errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+
+	if code != "" {
+		linkedVia = linkedViaCode
+		// kode dipakai sekali; GETDEL memastikan dua permintaan bersamaan tidak bisa memakai kode yang sama
+		studentIdStr, getErr := g.rdb.GetDel(ctx, linkCodeKey(utils.HashToken(code))).Result()
+		if getErr != nil {
+			if errors.Is(getErr, redis.Nil) {
+				g.recordLinkFailure(ctx, guardianKey, ipKey)
+				return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid or expired link code", 400)
+			}
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get cache", 500)
+		}
+		studentId, parseErr := uuid.Parse(studentIdStr)
+		if parseErr != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid or expired link code", 400)
+		}
+		g.rdb.Del(ctx, linkCodeStudentKey(studentId))
+		student, err = g.studentRepo.FindByStudentID(ctx, studentId)
+	} else {
+		linkedVia = linkedViaNisnDob
+		student, err = g.studentRepo.CheckNisnAndDateOfBirth(ctx, nisn, req.DateOfBirth)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			g.recordLinkFailure(ctx, guardianKey, ipKey)
+			return nil, notFoundErr
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+	if student.Role.Name != studentRoleName {
+		g.recordLinkFailure(ctx, guardianKey, ipKey)
+		return nil, notFoundErr
+	}
+
+	if err := g.guardianRepo.LinkStudent(ctx, &models.GuardianStudent{
+		GuardianID: guardianId,
+		StudentID:  student.ID,
+		LinkedVia:  linkedVia,
+	}); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to link student", 500)
+	}
+	g.rdb.Del(ctx, guardianKey)
+
+	g.audit.Record(ctx, audit.ActionLink, audit.EntityGuardianLink, student.ID.String(), nil, map[string]any{
+		"guardian_id": guardianId,
+		"student_id":  student.ID,
+		"linked_via":  linkedVia,
+	})
+
+	return student, nil
+}
+
+// GetLinkedStudents implements IGuardianService.
+func (g *GuardianServiceImpl) GetLinkedStudents(ctx context.Context, guardianId uuid.UUID) ([]*models.User, error) {
+	students, err := g.guardianRepo.FindLinkedStudents(ctx, guardianId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get linked students", 500)
+	}
+
+	return students, nil
+}
+
+// UnlinkStudent implements IGuardianService.
+func (g *GuardianServiceImpl) UnlinkStudent(ctx context.Context, guardianId, studentId uuid.UUID) error {
+	affected, err := g.guardianRepo.UnlinkStudent(ctx, guardianId, studentId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to unlink student", 500)
+	}
+	if affected == 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+	}
+
+	g.audit.Record(ctx, audit.ActionUnlink, audit.EntityGuardianLink, studentId.String(), map[string]any{
+		"guardian_id": guardianId,
+		"student_id":  studentId,
+	}, nil)
+
+	return nil
+}
+
+// EnsureLinked implements IGuardianService.
+func (g *GuardianServiceImpl) EnsureLinked(ctx context.Context, guardianId, studentId uuid.UUID) error {
+	linked, err := g.guardianRepo.IsLinked(ctx, guardianId, studentId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check guardian link", 500)
+	}
+	if !linked {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+	}
+
+	return nil
+}
+
+// IssueLinkCode implements IGuardianService.
+func (g *GuardianServiceImpl) IssueLinkCode(ctx context.Context, studentId uuid.UUID) (*guardianresponse.GuardianLinkCodeResponse, error) {
+	student, err := g.studentRepo.FindByStudentID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+	if student.Role.Name != studentRoleName {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+	}
+
+	code, err := utils.GenerateRandomPassword(linkCodeLength)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate link code", 500)
+	}
+	code = strings.ToUpper(code)
+	codeHash := utils.HashToken(code)
+
+	if oldHash, err := g.rdb.Get(ctx, linkCodeStudentKey(student.ID)).Result(); err == nil {
+		g.rdb.Del(ctx, linkCodeKey(oldHash))
+	}
+
+	pipe := g.rdb.TxPipeline()
+	pipe.Set(ctx, linkCodeKey(codeHash), student.ID.String(), linkCodeTTL)
+	pipe.Set(ctx, linkCodeStudentKey(student.ID), codeHash, linkCodeTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store link code", 500)
+	}
+
+	expiresAt := time.Now().Add(linkCodeTTL)
+	// kode tautan tidak pernah ikut dicatat di audit log
+	g.audit.Record(ctx, audit.ActionCreate, audit.EntityGuardianLinkCode, student.ID.String(), nil, map[string]any{
+		"username":   student.Username,
+		"expires_at": expiresAt,
+	})
+
+	nisn := ""
+	if student.Nisn != nil {
+		nisn = *student.Nisn
+	}
+
+	return &guardianresponse.GuardianLinkCodeResponse{
+		StudentID: student.ID,
+		Name:      student.Name,
+		Nisn:      nisn,
+		Class:     student.Class.NameClass,
+		Code:      code,
+		ExpiresAt: utils.FormatDate(expiresAt),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package guardianservice
+
+import (
+	"context"
+	guardianrequest "giat-cerika-service/internal/dto/request/guardian_request"
+	guardianresponse "giat-cerika-service/internal/dto/response/guardian_response"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type IGuardianService interface {
+	Register(ctx context.Context, req guardianrequest.RegisterGuardianRequest) error
+	Login(ctx context.Context, req guardianrequest.LoginGuardianRequest) (*sessionresponse.TokenResponse, error)
+	GetProfile(ctx context.Context, guardianId uuid.UUID) (*models.User, error)
+	Logout(ctx context.Context, guardianId uuid.UUID, claims *utils.JWTClaims) error
+
+	// LinkStudent menghubungkan wali dengan anaknya lewat NISN + tanggal lahir atau kode tautan dari sekolah.
+	LinkStudent(ctx context.Context, guardianId uuid.UUID, req guardianrequest.LinkStudentRequest) (*models.User, error)
+	GetLinkedStudents(ctx context.Context, guardianId uuid.UUID) ([]*models.User, error)
+	UnlinkStudent(ctx context.Context, guardianId, studentId uuid.UUID) error
+	// EnsureLinked mengembalikan 404 bila siswa tidak terhubung dengan wali, dipakai sebelum membaca data anak.
+	EnsureLinked(ctx context.Context, guardianId, studentId uuid.UUID) error
+
+	// IssueLinkCode membuat kode tautan sekali pakai untuk siswa; kode sebelumnya otomatis batal.
+	IssueLinkCode(ctx context.Context, studentId uuid.UUID) (*guardianresponse.GuardianLinkCodeResponse, error)
+}
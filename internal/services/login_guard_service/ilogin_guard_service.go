@@ -0,0 +1,21 @@
+package loginguardservice
+
+import (
+	"context"
+	loginguardrequest "giat-cerika-service/internal/dto/request/login_guard_request"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type ILoginGuardService interface {
+	// Check dipanggil sebelum memeriksa password; menolak bila username/IP sedang dikunci atau masih dalam jeda.
+	Check(ctx context.Context, username, ipAddress string) error
+	// RecordFailure menaikkan counter gagal per username & IP, memberi jeda bertahap, lalu mengunci sementara.
+	RecordFailure(ctx context.Context, username, ipAddress string, userId *uuid.UUID)
+	// RecordSuccess mereset counter gagal milik username setelah login berhasil.
+	RecordSuccess(ctx context.Context, username string)
+
+	GetLockouts(ctx context.Context, search string, activeOnly bool, page, limit int) ([]*models.LoginLockout, int, error)
+	Unlock(ctx context.Context, adminId uuid.UUID, req loginguardrequest.UnlockLoginRequest) error
+}
@@ -0,0 +1,227 @@
+package loginguardservice
+
+import (
+	"context"
+	"fmt"
+	loginguardrequest "giat-cerika-service/internal/dto/request/login_guard_request"
+	"giat-cerika-service/internal/models"
+	loginlockoutrepo "giat-cerika-service/internal/repositories/login_lockout_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ScopeUsername = "username"
+	ScopeIP       = "ip"
+
+	// failWindow adalah rentang waktu counter login gagal sebelum direset otomatis.
+	failWindow = 15 * time.Minute
+	// freeAttempts adalah jumlah salah password sebelum jeda bertahap mulai berlaku.
+	freeAttempts = 3
+	maxDelay     = 30 * time.Second
+	// usernameLockThreshold rendah karena NISN mudah ditebak; ipLockThreshold tinggi karena
+	// satu sekolah biasanya keluar lewat satu IP publik yang sama.
+	usernameLockThreshold = 10
+	ipLockThreshold       = 100
+	lockDuration          = 15 * time.Minute
+)
+
+type LoginGuardServiceImpl struct {
+	lockoutRepo loginlockoutrepo.ILoginLockoutRepository
+	audit       auditservice.IAuditService
+	rdb         *redis.Client
+}
+
+func NewLoginGuardServiceImpl(lockoutRepo loginlockoutrepo.ILoginLockoutRepository, auditService auditservice.IAuditService, rdb *redis.Client) ILoginGuardService {
+	return &LoginGuardServiceImpl{lockoutRepo: lockoutRepo, audit: auditService, rdb: rdb}
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func failKey(scope, value string) string {
+	return fmt.Sprintf("login_fail:%s:%s", scope, value)
+}
+
+func lockKey(scope, value string) string {
+	return fmt.Sprintf("login_lock:%s:%s", scope, value)
+}
+
+func delayKey(username string) string {
+	return fmt.Sprintf("login_delay:%s", username)
+}
+
+// progressiveDelay: 1s, 2s, 4s, ... setelah freeAttempts, maksimal maxDelay.
+func progressiveDelay(failures int) time.Duration {
+	if failures <= freeAttempts {
+		return 0
+	}
+	delay := time.Duration(math.Pow(2, float64(failures-freeAttempts-1))) * time.Second
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func retryMessage(prefix string, ttl time.Duration) string {
+	if ttl >= time.Minute {
+		return fmt.Sprintf("%s, try again in %d minute(s)", prefix, int(math.Ceil(ttl.Minutes())))
+	}
+	return fmt.Sprintf("%s, try again in %d second(s)", prefix, int(math.Ceil(ttl.Seconds())))
+}
+
+// Check implements ILoginGuardService.
+func (l *LoginGuardServiceImpl) Check(ctx context.Context, username string, ipAddress string) error {
+	username = normalizeUsername(username)
+
+	pipe := l.rdb.Pipeline()
+	userLock := pipe.TTL(ctx, lockKey(ScopeUsername, username))
+	ipLock := pipe.TTL(ctx, lockKey(ScopeIP, ipAddress))
+	userDelay := pipe.PTTL(ctx, delayKey(username))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check login attempts", 500)
+	}
+
+	if ttl := userLock.Val(); ttl > 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrTooManyRequests, retryMessage("account is temporarily locked", ttl), 429)
+	}
+	if ttl := ipLock.Val(); ttl > 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrTooManyRequests, retryMessage("too many failed logins from this network", ttl), 429)
+	}
+	if ttl := userDelay.Val(); ttl > 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrTooManyRequests, retryMessage("too many failed attempts", ttl), 429)
+	}
+
+	return nil
+}
+
+// RecordFailure implements ILoginGuardService.
+func (l *LoginGuardServiceImpl) RecordFailure(ctx context.Context, username string, ipAddress string, userId *uuid.UUID) {
+	username = normalizeUsername(username)
+
+	userFailures := l.incrFailure(ctx, ScopeUsername, username)
+	switch {
+	case userFailures >= usernameLockThreshold:
+		l.lock(ctx, ScopeUsername, username, ipAddress, userId, userFailures)
+	case progressiveDelay(userFailures) > 0:
+		l.rdb.Set(ctx, delayKey(username), userFailures, progressiveDelay(userFailures))
+	}
+
+	if ipAddress == "" {
+		return
+	}
+	if ipFailures := l.incrFailure(ctx, ScopeIP, ipAddress); ipFailures >= ipLockThreshold {
+		l.lock(ctx, ScopeIP, username, ipAddress, nil, ipFailures)
+	}
+}
+
+func (l *LoginGuardServiceImpl) incrFailure(ctx context.Context, scope, value string) int {
+	key := failKey(scope, value)
+	count, err := l.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0
+	}
+	if count == 1 {
+		l.rdb.Expire(ctx, key, failWindow)
+	}
+	return int(count)
+}
+
+// lock memasang kunci sementara, mereset counter, dan mencatat kejadiannya ke database.
+func (l *LoginGuardServiceImpl) lock(ctx context.Context, scope, username, ipAddress string, userId *uuid.UUID, failures int) {
+	value := username
+	if scope == ScopeIP {
+		value = ipAddress
+	}
+
+	pipe := l.rdb.TxPipeline()
+	pipe.Set(ctx, lockKey(scope, value), failures, lockDuration)
+	pipe.Del(ctx, failKey(scope, value))
+	if scope == ScopeUsername {
+		pipe.Del(ctx, delayKey(username))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("failed to lock login %s %s: %v", scope, value, err)
+		return
+	}
+
+	event := &models.LoginLockout{
+		ID:             uuid.New(),
+		Scope:          scope,
+		Username:       username,
+		UserID:         userId,
+		IPAddress:      ipAddress,
+		FailedAttempts: failures,
+		LockedUntil:    time.Now().Add(lockDuration),
+	}
+	if err := l.lockoutRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record login lockout %s %s: %v", scope, value, err)
+	}
+}
+
+// RecordSuccess implements ILoginGuardService.
+func (l *LoginGuardServiceImpl) RecordSuccess(ctx context.Context, username string) {
+	username = normalizeUsername(username)
+	l.rdb.Del(ctx, failKey(ScopeUsername, username), delayKey(username))
+}
+
+// GetLockouts implements ILoginGuardService.
+func (l *LoginGuardServiceImpl) GetLockouts(ctx context.Context, search string, activeOnly bool, page int, limit int) ([]*models.LoginLockout, int, error) {
+	offset := (page - 1) * limit
+
+	items, total, err := l.lockoutRepo.FindAll(ctx, strings.TrimSpace(search), activeOnly, limit, offset)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get login lockouts", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.LoginLockout{}
+	}
+
+	return items, total, nil
+}
+
+// Unlock implements ILoginGuardService.
+func (l *LoginGuardServiceImpl) Unlock(ctx context.Context, adminId uuid.UUID, req loginguardrequest.UnlockLoginRequest) error {
+	username := normalizeUsername(req.Username)
+	ipAddress := strings.TrimSpace(req.IPAddress)
+	if username == "" && ipAddress == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "username or ip_address is required", 400)
+	}
+
+	keys := []string{}
+	if username != "" {
+		keys = append(keys, lockKey(ScopeUsername, username), failKey(ScopeUsername, username), delayKey(username))
+	}
+	if ipAddress != "" {
+		keys = append(keys, lockKey(ScopeIP, ipAddress), failKey(ScopeIP, ipAddress))
+	}
+	if err := l.rdb.Del(ctx, keys...).Err(); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to unlock login", 500)
+	}
+
+	if username != "" {
+		if err := l.lockoutRepo.MarkUnlocked(ctx, ScopeUsername, username, adminId); err != nil {
+			return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update login lockout", 500)
+		}
+	}
+	if ipAddress != "" {
+		if err := l.lockoutRepo.MarkUnlocked(ctx, ScopeIP, ipAddress, adminId); err != nil {
+			return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update login lockout", 500)
+		}
+	}
+
+	l.audit.Record(ctx, audit.ActionUnlock, audit.EntityLoginLockout, "", nil, map[string]any{
+		"username":   username,
+		"ip_address": ipAddress,
+	})
+	return nil
+}
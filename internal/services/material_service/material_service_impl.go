@@ -11,6 +11,8 @@ import (
 	"giat-cerika-service/internal/models"
 	adminrepo "giat-cerika-service/internal/repositories/admin_repo"
 	materialrepo "giat-cerika-service/internal/repositories/material_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	rabbitmq "giat-cerika-service/pkg/constant/rabbitMq"
 	"giat-cerika-service/pkg/utils"
@@ -28,12 +30,22 @@ import (
 type MaterialServiceImpl struct {
 	materialRepo materialrepo.IMaterialRepository
 	adminRepo    adminrepo.IAdminRepository
+	audit        auditservice.IAuditService
 	rdb          *redis.Client
 	cld          datasources.CloudinaryService
 }
 
-func NewMaterialServiceImpl(materialRepo materialrepo.IMaterialRepository, adminRepo adminrepo.IAdminRepository, rdb *redis.Client, cld datasources.CloudinaryService) IMaterialService {
-	return &MaterialServiceImpl{materialRepo: materialRepo, adminRepo: adminRepo, rdb: rdb, cld: cld}
+func NewMaterialServiceImpl(materialRepo materialrepo.IMaterialRepository, adminRepo adminrepo.IAdminRepository, auditService auditservice.IAuditService, rdb *redis.Client, cld datasources.CloudinaryService) IMaterialService {
+	return &MaterialServiceImpl{materialRepo: materialRepo, adminRepo: adminRepo, audit: auditService, rdb: rdb, cld: cld}
+}
+
+// materialAuditData hanya mengambil field utama materi; galeri diunggah async sehingga tidak dicatat.
+func materialAuditData(material *models.Materials) map[string]any {
+	return map[string]any{
+		"title":       material.Title,
+		"description": material.Description,
+		"cover":       material.Cover,
+	}
 }
 
 func (c *MaterialServiceImpl) invalidateCacheMaterial(ctx context.Context) {
@@ -138,6 +150,7 @@ func (c *MaterialServiceImpl) CreateMaterial(ctx context.Context, adminId uuid.U
 	}
 
 	c.invalidateCacheMaterial(ctx)
+	c.audit.Record(ctx, audit.ActionCreate, audit.EntityMaterial, materi.ID.String(), nil, materialAuditData(materi))
 	return nil
 }
 
@@ -205,6 +218,7 @@ func (c *MaterialServiceImpl) UpdateMaterial(ctx context.Context, materialId uui
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get material", 500)
 	}
+	before := materialAuditData(material)
 
 	existsMaterial, err := c.materialRepo.FindByTitle(ctx, req.Title)
 	if err == nil && existsMaterial.ID != materialId {
@@ -285,6 +299,7 @@ func (c *MaterialServiceImpl) UpdateMaterial(ctx context.Context, materialId uui
 	}
 
 	c.invalidateCacheMaterial(ctx)
+	c.audit.Record(ctx, audit.ActionUpdate, audit.EntityMaterial, materialId.String(), before, materialAuditData(material))
 
 	return nil
 }
@@ -318,6 +333,7 @@ func (c *MaterialServiceImpl) DeleteMaterial(ctx context.Context, materialId uui
 	}
 
 	c.invalidateCacheMaterial(ctx)
+	c.audit.Record(ctx, audit.ActionDelete, audit.EntityMaterial, materialId.String(), materialAuditData(materi), nil)
 
 	return nil
 }
@@ -0,0 +1,20 @@
+package permissionservice
+
+import (
+	"context"
+	permissionrequest "giat-cerika-service/internal/dto/request/permission_request"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IPermissionService interface {
+	CreatePermission(ctx context.Context, req permissionrequest.CreatePermissionRequest) error
+	GetAllPermission(ctx context.Context, page, limit int, search string) ([]*models.Permission, int, error)
+	GetByIdPermission(ctx context.Context, permissionId uuid.UUID) (*models.Permission, error)
+	UpdatePermission(ctx context.Context, permissionId uuid.UUID, req permissionrequest.UpdatePermissionRequest) error
+	DeletePermission(ctx context.Context, permissionId uuid.UUID) error
+
+	GetRolePermissions(ctx context.Context, roleId uuid.UUID) (*models.Role, error)
+	UpdateRolePermissions(ctx context.Context, roleId uuid.UUID, req permissionrequest.UpdateRolePermissionsRequest) error
+}
@@ -0,0 +1,277 @@
+package permissionservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	permissionrequest "giat-cerika-service/internal/dto/request/permission_request"
+	"giat-cerika-service/internal/models"
+	permissionrepo "giat-cerika-service/internal/repositories/permission_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/permission"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+var permissionCodePattern = regexp.MustCompile(`^[a-z][a-z_]*:[a-z][a-z_]*$`)
+
+type PermissionServiceImpl struct {
+	permissionRepo permissionrepo.IPermissionRepository
+	audit          auditservice.IAuditService
+	rdb            *redis.Client
+}
+
+func NewPermissionServiceImpl(permissionRepo permissionrepo.IPermissionRepository, auditService auditservice.IAuditService, rdb *redis.Client) IPermissionService {
+	return &PermissionServiceImpl{permissionRepo: permissionRepo, audit: auditService, rdb: rdb}
+}
+
+func (p *PermissionServiceImpl) invalidateCachePermission(ctx context.Context) {
+	for _, pattern := range []string{"permissions:*", "permission:*", permission.CacheKeyPattern} {
+		iter := p.rdb.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			p.rdb.Del(ctx, iter.Val())
+		}
+	}
+}
+
+// isBuiltIn menandai permission dari katalog yang dipakai langsung oleh route.
+// Kodenya tidak boleh diubah/dihapus agar pengecekan akses tidak rusak.
+func isBuiltIn(code string) bool {
+	for _, def := range permission.Catalog {
+		if def.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionCodes(permissions []models.Permission) []string {
+	codes := make([]string, len(permissions))
+	for i, perm := range permissions {
+		codes[i] = perm.Code
+	}
+	return codes
+}
+
+// CreatePermission implements IPermissionService.
+func (p *PermissionServiceImpl) CreatePermission(ctx context.Context, req permissionrequest.CreatePermissionRequest) error {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if code == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Code is required", 400)
+	}
+	if !permissionCodePattern.MatchString(code) {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Code must be in resource:action format", 400)
+	}
+
+	existPermission, err := p.permissionRepo.FindByCode(ctx, code)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to Get Permission Code", 500)
+	}
+	if existPermission != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "Permission Code Already Exists", 409)
+	}
+
+	newPermission := &models.Permission{
+		ID:          uuid.New(),
+		Code:        code,
+		Description: strings.TrimSpace(req.Description),
+	}
+	if err := p.permissionRepo.Create(ctx, newPermission); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to create permission", 500)
+	}
+
+	p.invalidateCachePermission(ctx)
+	p.audit.Record(ctx, audit.ActionCreate, audit.EntityPermission, newPermission.ID.String(), nil, newPermission)
+
+	return nil
+}
+
+// GetAllPermission implements IPermissionService.
+func (p *PermissionServiceImpl) GetAllPermission(ctx context.Context, page int, limit int, search string) ([]*models.Permission, int, error) {
+	cacheKey := fmt.Sprintf("permissions:search:%s:page:%d:limit:%d", search, page, limit)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result struct {
+			Data  []*models.Permission `json:"data"`
+			Total int                  `json:"total"`
+		}
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return result.Data, result.Total, nil
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	items, total, err := p.permissionRepo.FindAll(ctx, limit, offset, search)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to get permission", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.Permission{}
+	}
+
+	buf, _ := json.Marshal(map[string]any{
+		"data":  items,
+		"total": total,
+	})
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return items, total, nil
+}
+
+// GetByIdPermission implements IPermissionService.
+func (p *PermissionServiceImpl) GetByIdPermission(ctx context.Context, permissionId uuid.UUID) (*models.Permission, error) {
+	cacheKey := fmt.Sprintf("permission:%s", permissionId)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var data models.Permission
+		if json.Unmarshal([]byte(cached), &data) == nil {
+			return &data, nil
+		}
+	}
+
+	data, err := p.permissionRepo.FindById(ctx, permissionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "permission not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get permission", 500)
+	}
+
+	buf, _ := json.Marshal(data)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+	return data, nil
+}
+
+// UpdatePermission implements IPermissionService.
+func (p *PermissionServiceImpl) UpdatePermission(ctx context.Context, permissionId uuid.UUID, req permissionrequest.UpdatePermissionRequest) error {
+	data, err := p.permissionRepo.FindById(ctx, permissionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "permission not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get permission", 500)
+	}
+	before := *data
+
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if code != "" && code != data.Code {
+		if isBuiltIn(data.Code) {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Built-in permission code cannot be changed", 400)
+		}
+		if !permissionCodePattern.MatchString(code) {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Code must be in resource:action format", 400)
+		}
+		existPermission, err := p.permissionRepo.FindByCode(ctx, code)
+		if err == nil && existPermission.ID != permissionId {
+			return errorresponse.NewCustomError(errorresponse.ErrExists, "Permission Code Already Exists", 409)
+		}
+		data.Code = code
+	}
+	if req.Description != "" {
+		data.Description = strings.TrimSpace(req.Description)
+	}
+
+	if err := p.permissionRepo.Update(ctx, data); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to update permission", 500)
+	}
+	p.invalidateCachePermission(ctx)
+	p.audit.Record(ctx, audit.ActionUpdate, audit.EntityPermission, permissionId.String(), before, data)
+
+	return nil
+}
+
+// DeletePermission implements IPermissionService.
+func (p *PermissionServiceImpl) DeletePermission(ctx context.Context, permissionId uuid.UUID) error {
+	data, err := p.permissionRepo.FindById(ctx, permissionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "permission not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get permission", 500)
+	}
+	if isBuiltIn(data.Code) {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Built-in permission cannot be deleted", 400)
+	}
+
+	if err := p.permissionRepo.Delete(ctx, permissionId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete permission", 500)
+	}
+	p.invalidateCachePermission(ctx)
+	p.audit.Record(ctx, audit.ActionDelete, audit.EntityPermission, permissionId.String(), data, nil)
+
+	return nil
+}
+
+// GetRolePermissions implements IPermissionService.
+func (p *PermissionServiceImpl) GetRolePermissions(ctx context.Context, roleId uuid.UUID) (*models.Role, error) {
+	role, err := p.permissionRepo.FindRoleWithPermissions(ctx, roleId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "role not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get role permissions", 500)
+	}
+	return role, nil
+}
+
+// UpdateRolePermissions implements IPermissionService.
+func (p *PermissionServiceImpl) UpdateRolePermissions(ctx context.Context, roleId uuid.UUID, req permissionrequest.UpdateRolePermissionsRequest) error {
+	role, err := p.permissionRepo.FindRoleWithPermissions(ctx, roleId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "role not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get role permissions", 500)
+	}
+	before := permissionCodes(role.Permissions)
+
+	ids := make([]uuid.UUID, 0, len(req.PermissionIds))
+	seen := make(map[uuid.UUID]bool, len(req.PermissionIds))
+	for _, id := range req.PermissionIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	permissions, err := p.permissionRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get permission", 500)
+	}
+	if len(permissions) != len(ids) {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "some permissions not found", 404)
+	}
+
+	// Role admin harus tetap bisa mengelola role agar akses tidak terkunci permanen.
+	if role.Name == "admin" {
+		hasRoleManage := false
+		for _, perm := range permissions {
+			if perm.Code == permission.RoleManage {
+				hasRoleManage = true
+				break
+			}
+		}
+		if !hasRoleManage {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "admin role must keep role:manage permission", 400)
+		}
+	}
+
+	if err := p.permissionRepo.ReplaceRolePermissions(ctx, role, permissions); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update role permissions", 500)
+	}
+	p.invalidateCachePermission(ctx)
+	p.audit.Record(ctx, audit.ActionUpdate, audit.EntityRolePermission, roleId.String(),
+		map[string]any{"permissions": before},
+		map[string]any{"permissions": permissionCodes(permissions)},
+	)
+
+	return nil
+}
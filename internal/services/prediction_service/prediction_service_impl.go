@@ -10,7 +10,10 @@ import (
 	"giat-cerika-service/internal/models"
 	predictionrepo "giat-cerika-service/internal/repositories/prediction_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,11 +24,12 @@ import (
 type PredictionServiceImpl struct {
 	predictionRepo predictionrepo.IPredictionRepository
 	studentRepo    studentrepo.IStudentRepository
+	audit          auditservice.IAuditService
 	rdb            *redis.Client
 }
 
-func NewPredictionServiceImpl(predicRepo predictionrepo.IPredictionRepository, studentRepo studentrepo.IStudentRepository, rdb *redis.Client) IPredictionService {
-	return &PredictionServiceImpl{predictionRepo: predicRepo, studentRepo: studentRepo, rdb: rdb}
+func NewPredictionServiceImpl(predicRepo predictionrepo.IPredictionRepository, studentRepo studentrepo.IStudentRepository, auditService auditservice.IAuditService, rdb *redis.Client) IPredictionService {
+	return &PredictionServiceImpl{predictionRepo: predicRepo, studentRepo: studentRepo, audit: auditService, rdb: rdb}
 }
 
 func (p *PredictionServiceImpl) invalidateCachePrediction(ctx context.Context) {
@@ -48,7 +52,8 @@ func (p *PredictionServiceImpl) Create(ctx context.Context, req predictionreques
 	repoImpl := p.predictionRepo.(*predictionrepo.PredictionRepositoryImpl)
 	db := repoImpl.DB()
 
-	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var prediction models.Prediction
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		confidence := models.ConfidenceDetail{
 			ID:     uuid.New(),
 			Low:    req.ConfidenceDetail.Low,
@@ -143,7 +148,7 @@ func (p *PredictionServiceImpl) Create(ctx context.Context, req predictionreques
 		// ===============================
 		locJakarta, _ := time.LoadLocation("Asia/Jakarta")
 		nowJakarta := time.Now().In(locJakarta)
-		prediction := models.Prediction{
+		prediction = models.Prediction{
 			ID:                 uuid.New(),
 			PatientName:        req.PatientName,
 			Age:                req.Age,
@@ -164,11 +169,21 @@ func (p *PredictionServiceImpl) Create(ctx context.Context, req predictionreques
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	p.audit.Record(ctx, audit.ActionCreate, audit.EntityPrediction, prediction.ID.String(), nil, prediction)
+	return nil
 }
 
 // GetAllPrediction implements [IPredictionService].
 func (p *PredictionServiceImpl) GetAllPrediction(ctx context.Context, page int, limit int, search string) ([]*models.Prediction, int, error) {
+	teacherId := utils.TeacherScopeFromContext(ctx)
 	cacheKey := fmt.Sprintf("predictions:search:%s:page:%d:limit:%d", search, page, limit)
+	if teacherId != nil {
+		cacheKey = fmt.Sprintf("%s:teacher:%s", cacheKey, *teacherId)
+	}
 	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
 		var result struct {
 			Data  []*models.Prediction `json:"data"`
@@ -181,7 +196,7 @@ func (p *PredictionServiceImpl) GetAllPrediction(ctx context.Context, page int,
 
 	offset := (page - 1) * limit
 
-	items, total, err := p.predictionRepo.GetAllPrediction(ctx, limit, offset, search)
+	items, total, err := p.predictionRepo.GetAllPrediction(ctx, limit, offset, search, teacherId)
 	if err != nil {
 		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get predictions", 500)
 	}
@@ -216,6 +231,7 @@ func (p *PredictionServiceImpl) DeletePrediction(ctx context.Context, prediction
 	}
 
 	p.invalidateCachePrediction(ctx)
+	p.audit.Record(ctx, audit.ActionDelete, audit.EntityPrediction, predictionId.String(), prediction, nil)
 
 	return nil
 }
@@ -259,6 +275,12 @@ func (p *PredictionServiceImpl) SendPredictToStudent(ctx context.Context, req pr
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to save response", 500)
 	}
 
+	p.audit.Record(ctx, audit.ActionSend, audit.EntityPrediction, prediction.ID.String(), nil, map[string]any{
+		"predict_history_id": newData.ID,
+		"user_id":            user.ID,
+		"suggestion":         req.Suggestion,
+	})
+
 	return nil
 }
 
@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"giat-cerika-service/configs"
 	datasources "giat-cerika-service/internal/dataSources"
+	answerrequest "giat-cerika-service/internal/dto/request/answer_request"
 	questionrequest "giat-cerika-service/internal/dto/request/question_request"
 	"giat-cerika-service/internal/models"
 	answerrepo "giat-cerika-service/internal/repositories/answer_repo"
 	questionrepo "giat-cerika-service/internal/repositories/question_repo"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	rabbitmq "giat-cerika-service/pkg/constant/rabbitMq"
 	"giat-cerika-service/pkg/utils"
@@ -30,6 +33,7 @@ type QuestionServiceImpl struct {
 	questionRepo questionrepo.IQuestionRepository
 	quizRepo     quizrepo.IQuizRepository
 	answerRepo   answerrepo.IAnswerRepository
+	audit        auditservice.IAuditService
 	rdb          *redis.Client
 	cld          datasources.CloudinaryService
 }
@@ -38,6 +42,7 @@ func NewQuestionServiceImpl(
 	questionRepo questionrepo.IQuestionRepository,
 	quizRepo quizrepo.IQuizRepository,
 	answerRepo answerrepo.IAnswerRepository,
+	auditService auditservice.IAuditService,
 	rdb *redis.Client,
 	cld datasources.CloudinaryService,
 ) IQuestionService {
@@ -45,11 +50,86 @@ func NewQuestionServiceImpl(
 		questionRepo: questionRepo,
 		answerRepo:   answerRepo,
 		quizRepo:     quizRepo,
+		audit:        auditService,
 		rdb:          rdb,
 		cld:          cld,
 	}
 }
 
+// questionAuditData meringkas soal + jawaban untuk audit log tanpa relasi Quiz yang besar.
+func questionAuditData(question *models.Question, answers []models.Answer) map[string]any {
+	answerData := make([]map[string]any, len(answers))
+	for i, ans := range answers {
+		answerData[i] = map[string]any{
+			"answer_text": ans.AnswerText,
+			"score_value": ans.ScoreValue,
+			"match_text":  ans.MatchText,
+		}
+	}
+
+	return map[string]any{
+		"quiz_id":        question.QuizID,
+		"question_text":  question.QuestionText,
+		"question_image": question.QuestionImage,
+		"question_type":  question.QuestionType,
+		"tag":            question.Tag,
+		"difficulty":     question.Difficulty,
+		"answers":        answerData,
+	}
+}
+
+// validateQuestionAnswers memastikan bentuk opsi sesuai tipe soal.
+func validateQuestionAnswers(questionType models.QuestionType, answers []answerrequest.CreateAnswerRequest) error {
+	if !questionType.IsValid() {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid question type", 400)
+	}
+
+	positive := 0
+	for _, ans := range answers {
+		if strings.TrimSpace(ans.AnswerText) == "" {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "answer text cannot be empty", 400)
+		}
+		if ans.ScoreValue > 0 {
+			positive++
+		}
+	}
+
+	switch questionType {
+	case models.QuestionTrueFalse:
+		if len(answers) != 2 || positive != 1 {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "true/false question must have exactly 2 answers with one correct answer", 400)
+		}
+	case models.QuestionMultiSelect:
+		if len(answers) < 2 || positive == 0 {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "multi select question must have at least 2 answers with a correct answer", 400)
+		}
+	case models.QuestionShortAnswer:
+		if positive == 0 {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "short answer question must have at least one accepted answer", 400)
+		}
+	case models.QuestionMatching:
+		if len(answers) < 2 {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "matching question must have at least 2 pairs", 400)
+		}
+		for _, ans := range answers {
+			if strings.TrimSpace(ans.MatchText) == "" {
+				return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "match text cannot be empty", 400)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDifficulty menerima difficulty kosong (soal tanpa strata) atau easy/medium/hard.
+func validateDifficulty(difficulty models.QuestionDifficulty) error {
+	switch difficulty {
+	case "", models.QuestionDifficultyEasy, models.QuestionDifficultyMedium, models.QuestionDifficultyHard:
+		return nil
+	}
+	return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "difficulty must be easy, medium or hard", 400)
+}
+
 func (q *QuestionServiceImpl) invalidateCacheQuestion(ctx context.Context) {
 	iter := q.rdb.Scan(ctx, 0, "questions:*", 0).Iterator()
 	for iter.Next(ctx) {
@@ -113,13 +193,27 @@ func (q *QuestionServiceImpl) CreateQuestion(ctx context.Context, req questionre
 	if len(req.Answers) == 0 {
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "answers cannot be empty", 400)
 	}
+	if req.QuestionType == "" {
+		req.QuestionType = models.QuestionSingleChoice
+	}
+	if err := validateQuestionAnswers(req.QuestionType, req.Answers); err != nil {
+		return err
+	}
+	if err := validateDifficulty(req.Difficulty); err != nil {
+		return err
+	}
 
 	question := &models.Question{
 		ID:           uuid.New(),
 		QuizID:       quiz.ID,
 		QuestionText: req.QuestionText,
+		QuestionType: req.QuestionType,
+		Tag:          strings.TrimSpace(req.Tag),
+		Difficulty:   req.Difficulty,
 	}
 
+	answers := make([]models.Answer, 0, len(req.Answers))
+
 	// ── Transaction: simpan question + answers secara atomik ──
 	if err := configs.RunTransaction(ctx, func(tx *gorm.DB) error {
 		if err := tx.Create(question).Error; err != nil {
@@ -132,10 +226,12 @@ func (q *QuestionServiceImpl) CreateQuestion(ctx context.Context, req questionre
 				QuestionID: question.ID,
 				AnswerText: ansReq.AnswerText,
 				ScoreValue: ansReq.ScoreValue,
+				MatchText:  ansReq.MatchText,
 			}
 			if err := tx.Create(answer).Error; err != nil {
 				return err
 			}
+			answers = append(answers, *answer)
 		}
 
 		return nil
@@ -165,6 +261,7 @@ func (q *QuestionServiceImpl) CreateQuestion(ctx context.Context, req questionre
 
 	q.invalidateCacheQuestion(ctx)
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuestion, question.ID.String(), nil, questionAuditData(question, answers))
 	return nil
 }
 
@@ -272,6 +369,8 @@ func (q *QuestionServiceImpl) UpdateQuestion(ctx context.Context, questionId uui
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get question", 500)
 	}
+	before := questionAuditData(question, question.Answers)
+	answers := question.Answers
 
 	quiz, err := q.quizRepo.FindById(ctx, req.QuizId)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -284,6 +383,35 @@ func (q *QuestionServiceImpl) UpdateQuestion(ctx context.Context, questionId uui
 	if strings.TrimSpace(req.QuestionText) != "" {
 		question.QuestionText = req.QuestionText
 	}
+	if req.QuestionType != "" {
+		question.QuestionType = req.QuestionType
+	}
+	if strings.TrimSpace(req.Tag) != "" {
+		question.Tag = strings.TrimSpace(req.Tag)
+	}
+	if req.Difficulty != "" {
+		if err := validateDifficulty(req.Difficulty); err != nil {
+			return err
+		}
+		question.Difficulty = req.Difficulty
+	}
+	if req.QuestionType != "" || len(req.Answers) > 0 {
+		// tipe baru divalidasi terhadap opsi baru, atau opsi lama bila opsi tidak diganti
+		toValidate := req.Answers
+		if len(toValidate) == 0 {
+			toValidate = make([]answerrequest.CreateAnswerRequest, len(question.Answers))
+			for i, ans := range question.Answers {
+				toValidate[i] = answerrequest.CreateAnswerRequest{
+					AnswerText: ans.AnswerText,
+					ScoreValue: ans.ScoreValue,
+					MatchText:  ans.MatchText,
+				}
+			}
+		}
+		if err := validateQuestionAnswers(question.QuestionType, toValidate); err != nil {
+			return err
+		}
+	}
 
 	if req.QuestionImage != nil {
 		if question.QuestionImage != "" {
@@ -315,6 +443,7 @@ func (q *QuestionServiceImpl) UpdateQuestion(ctx context.Context, questionId uui
 			if err := tx.Where("question_id = ?", question.ID).Delete(&models.Answer{}).Error; err != nil {
 				return err
 			}
+			answers = make([]models.Answer, 0, len(req.Answers))
 
 			for _, ans := range req.Answers {
 				newAnswer := &models.Answer{
@@ -322,10 +451,12 @@ func (q *QuestionServiceImpl) UpdateQuestion(ctx context.Context, questionId uui
 					QuestionID: question.ID,
 					AnswerText: ans.AnswerText,
 					ScoreValue: ans.ScoreValue,
+					MatchText:  ans.MatchText,
 				}
 				if err := tx.Create(newAnswer).Error; err != nil {
 					return err
 				}
+				answers = append(answers, *newAnswer)
 			}
 		}
 
@@ -335,6 +466,7 @@ func (q *QuestionServiceImpl) UpdateQuestion(ctx context.Context, questionId uui
 	}
 
 	q.invalidateCacheQuestion(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuestion, questionId.String(), before, questionAuditData(question, answers))
 
 	return nil
 }
@@ -371,5 +503,6 @@ func (q *QuestionServiceImpl) DeleteQuestion(ctx context.Context, questionId uui
 	}(question.QuizID)
 	q.invalidateCacheQuestion(ctx)
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuestion, questionId.String(), questionAuditData(question, question.Answers), nil)
 	return nil
 }
@@ -0,0 +1,29 @@
+package questionnaireservice
+
+import (
+	"context"
+	questionnairerequest "giat-cerika-service/internal/dto/request/questionnaire_request"
+	questionnaireresponse "giat-cerika-service/internal/dto/response/questionnaire_response"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IQuestionnaireService interface {
+	CreateQuestionnaire(ctx context.Context, req questionnairerequest.CreateQuestionnaireRequest) error
+	GetAllQuestionnaire(ctx context.Context, page, limit int, search, questionnaireType string) ([]*models.Questionnaire, int, error)
+	GetQuestionnaireById(ctx context.Context, questionnaireId uuid.UUID) (*models.Questionnaire, error)
+	UpdateQuestionnaire(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.UpdateQuestionnaireRequest) error
+	DeleteQuestionnaire(ctx context.Context, questionnaireId uuid.UUID) error
+	UpdateStatusQuestionnaire(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.UpdateStatusQuestionnaireRequest) error
+
+	CreateItem(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.CreateQuestionnaireItemRequest) error
+	UpdateItem(ctx context.Context, questionnaireId, itemId uuid.UUID, req questionnairerequest.UpdateQuestionnaireItemRequest) error
+	DeleteItem(ctx context.Context, questionnaireId, itemId uuid.UUID) error
+
+	GetAllAvailable(ctx context.Context, studentId uuid.UUID, search string) ([]questionnaireresponse.AvailableQuestionnaireResponse, error)
+	GetAvailableById(ctx context.Context, studentId, questionnaireId uuid.UUID) (*questionnaireresponse.AvailableQuestionnaireResponse, error)
+	SubmitQuestionnaire(ctx context.Context, studentId, questionnaireId uuid.UUID, req questionnairerequest.SubmitQuestionnaireRequest) error
+
+	GetResultByClass(ctx context.Context, questionnaireId uuid.UUID, period string, classId *uuid.UUID) (*questionnaireresponse.QuestionnaireResultResponse, error)
+}
@@ -0,0 +1,682 @@
+package questionnaireservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	questionnairerequest "giat-cerika-service/internal/dto/request/questionnaire_request"
+	questionnaireresponse "giat-cerika-service/internal/dto/response/questionnaire_response"
+	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	questionnairerepo "giat-cerika-service/internal/repositories/questionnaire_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type QuestionnaireServiceImpl struct {
+	questionnaireRepo questionnairerepo.IQuestionnaireRepository
+	studentRepo       studentrepo.IStudentRepository
+	classRepo         classrepo.IClassRepository
+	audit             auditservice.IAuditService
+	rdb               *redis.Client
+}
+
+func NewQuestionnaireServiceImpl(questionnaireRepo questionnairerepo.IQuestionnaireRepository, studentRepo studentrepo.IStudentRepository, classRepo classrepo.IClassRepository, auditService auditservice.IAuditService, rdb *redis.Client) IQuestionnaireService {
+	return &QuestionnaireServiceImpl{questionnaireRepo: questionnaireRepo, studentRepo: studentRepo, classRepo: classRepo, audit: auditService, rdb: rdb}
+}
+
+func (q *QuestionnaireServiceImpl) invalidateCacheQuestionnaire(ctx context.Context) {
+	for _, pattern := range []string{"questionnaires:*", "questionnaire:*"} {
+		iter := q.rdb.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			q.rdb.Del(ctx, iter.Val())
+		}
+	}
+}
+
+func normalizeQuestionnaireType(value string) (models.QuestionnaireType, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "pengetahuan":
+		return models.QuestionnairePengetahuan, true
+	case "perilaku":
+		return models.QuestionnairePerilaku, true
+	}
+	return "", false
+}
+
+func normalizePeriod(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+func validateScale(min, max int) error {
+	if min < 0 || max <= min {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "scale max must be greater than scale min", 400)
+	}
+	if max-min > 10 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "likert scale can have at most 11 points", 400)
+	}
+	return nil
+}
+
+// likertScore mengembalikan skor item; item dengan pernyataan negatif dibalik.
+func likertScore(value, min, max int, reversed bool) int {
+	if reversed {
+		return max + min - value
+	}
+	return value
+}
+
+func (q *QuestionnaireServiceImpl) findQuestionnaire(ctx context.Context, questionnaireId uuid.UUID) (*models.Questionnaire, error) {
+	questionnaire, err := q.questionnaireRepo.FindById(ctx, questionnaireId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "questionnaire not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get questionnaire", 500)
+	}
+	return questionnaire, nil
+}
+
+// ensureNoRespondents menolak perubahan item/skala bila kuesioner sudah diisi,
+// agar jawaban yang tersimpan tetap sebanding antar periode.
+func (q *QuestionnaireServiceImpl) ensureNoRespondents(ctx context.Context, questionnaireId uuid.UUID) error {
+	count, err := q.questionnaireRepo.CountRespondents(ctx, questionnaireId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to count respondents", 500)
+	}
+	if count > 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "questionnaire already has respondents and can no longer be changed", 400)
+	}
+	return nil
+}
+
+// CreateQuestionnaire implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) CreateQuestionnaire(ctx context.Context, req questionnairerequest.CreateQuestionnaireRequest) error {
+	if strings.TrimSpace(req.Code) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "code is required", 400)
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "title is required", 400)
+	}
+	questionnaireType, ok := normalizeQuestionnaireType(req.Type)
+	if !ok {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "type only 'Pengetahuan' or 'Perilaku'", 400)
+	}
+
+	scaleMin, scaleMax := req.ScaleMin, req.ScaleMax
+	if scaleMin == 0 && scaleMax == 0 {
+		scaleMin, scaleMax = 1, 5
+	}
+	if err := validateScale(scaleMin, scaleMax); err != nil {
+		return err
+	}
+
+	period := normalizePeriod(req.ActivePeriod)
+	if period == "" {
+		period = "PRE"
+	}
+
+	newQuestionnaire := &models.Questionnaire{
+		ID:           uuid.New(),
+		Code:         strings.TrimSpace(req.Code),
+		Title:        strings.TrimSpace(req.Title),
+		Description:  req.Description,
+		Type:         questionnaireType,
+		Status:       0,
+		ActivePeriod: period,
+		ScaleMin:     scaleMin,
+		ScaleMax:     scaleMax,
+		AmountItems:  0,
+	}
+
+	if err := q.questionnaireRepo.Create(ctx, newQuestionnaire); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to save data", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuestionnaire, newQuestionnaire.ID.String(), nil, newQuestionnaire)
+	return nil
+}
+
+// GetAllQuestionnaire implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) GetAllQuestionnaire(ctx context.Context, page, limit int, search, questionnaireType string) ([]*models.Questionnaire, int, error) {
+	if questionnaireType != "" {
+		normalized, ok := normalizeQuestionnaireType(questionnaireType)
+		if !ok {
+			return nil, 0, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "type only 'Pengetahuan' or 'Perilaku'", 400)
+		}
+		questionnaireType = string(normalized)
+	}
+
+	cacheKey := fmt.Sprintf("questionnaires:search:%s:type:%s:page:%d:limit:%d", search, questionnaireType, page, limit)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result struct {
+			Data  []*models.Questionnaire `json:"data"`
+			Total int                     `json:"total"`
+		}
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return result.Data, result.Total, nil
+		}
+	}
+
+	offset := (page - 1) * limit
+	items, total, err := q.questionnaireRepo.FindAll(ctx, limit, offset, search, questionnaireType)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get questionnaires", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.Questionnaire{}
+	}
+
+	buf, _ := json.Marshal(map[string]any{
+		"data":  items,
+		"total": total,
+	})
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return items, total, nil
+}
+
+// GetQuestionnaireById implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) GetQuestionnaireById(ctx context.Context, questionnaireId uuid.UUID) (*models.Questionnaire, error) {
+	cacheKey := fmt.Sprintf("questionnaire:%s", questionnaireId)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var questionnaire models.Questionnaire
+		if json.Unmarshal([]byte(cached), &questionnaire) == nil {
+			return &questionnaire, nil
+		}
+	}
+
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, _ := json.Marshal(questionnaire)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return questionnaire, nil
+}
+
+// UpdateQuestionnaire implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) UpdateQuestionnaire(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.UpdateQuestionnaireRequest) error {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return err
+	}
+	before := *questionnaire
+
+	if strings.TrimSpace(req.Code) != "" {
+		questionnaire.Code = strings.TrimSpace(req.Code)
+	}
+	if strings.TrimSpace(req.Title) != "" {
+		questionnaire.Title = strings.TrimSpace(req.Title)
+	}
+	if strings.TrimSpace(req.Description) != "" {
+		questionnaire.Description = req.Description
+	}
+	if strings.TrimSpace(req.Type) != "" {
+		questionnaireType, ok := normalizeQuestionnaireType(req.Type)
+		if !ok {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "type only 'Pengetahuan' or 'Perilaku'", 400)
+		}
+		questionnaire.Type = questionnaireType
+	}
+	if period := normalizePeriod(req.ActivePeriod); period != "" {
+		questionnaire.ActivePeriod = period
+	}
+
+	if req.ScaleMin != nil || req.ScaleMax != nil {
+		scaleMin, scaleMax := questionnaire.ScaleMin, questionnaire.ScaleMax
+		if req.ScaleMin != nil {
+			scaleMin = *req.ScaleMin
+		}
+		if req.ScaleMax != nil {
+			scaleMax = *req.ScaleMax
+		}
+		if scaleMin != questionnaire.ScaleMin || scaleMax != questionnaire.ScaleMax {
+			if err := validateScale(scaleMin, scaleMax); err != nil {
+				return err
+			}
+			if err := q.ensureNoRespondents(ctx, questionnaireId); err != nil {
+				return err
+			}
+			questionnaire.ScaleMin, questionnaire.ScaleMax = scaleMin, scaleMax
+		}
+	}
+
+	if err := q.questionnaireRepo.Update(ctx, questionnaireId, questionnaire); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update questionnaire", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuestionnaire, questionnaireId.String(), before, questionnaire)
+	return nil
+}
+
+// DeleteQuestionnaire implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) DeleteQuestionnaire(ctx context.Context, questionnaireId uuid.UUID) error {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return err
+	}
+
+	if err := q.questionnaireRepo.Delete(ctx, questionnaireId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete questionnaire", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuestionnaire, questionnaireId.String(), questionnaire, nil)
+	return nil
+}
+
+// UpdateStatusQuestionnaire implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) UpdateStatusQuestionnaire(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.UpdateStatusQuestionnaireRequest) error {
+	if req.Status != 0 && req.Status != 1 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "status only 0 or 1", 400)
+	}
+
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return err
+	}
+	if req.Status == 1 && len(questionnaire.Items) == 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "questionnaire must have at least one item before published", 400)
+	}
+
+	if err := q.questionnaireRepo.UpdateStatus(ctx, questionnaireId, req.Status); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update status questionnaire", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionUpdateStatus, audit.EntityQuestionnaire, questionnaireId.String(), map[string]any{"status": questionnaire.Status}, map[string]any{"status": req.Status})
+	return nil
+}
+
+// CreateItem implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) CreateItem(ctx context.Context, questionnaireId uuid.UUID, req questionnairerequest.CreateQuestionnaireItemRequest) error {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(req.Statement) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "statement is required", 400)
+	}
+	if err := q.ensureNoRespondents(ctx, questionnaireId); err != nil {
+		return err
+	}
+
+	order := req.ItemOrder
+	if order <= 0 {
+		order = len(questionnaire.Items) + 1
+	}
+
+	item := &models.QuestionnaireItem{
+		ID:              uuid.New(),
+		QuestionnaireID: questionnaire.ID,
+		Statement:       strings.TrimSpace(req.Statement),
+		ItemOrder:       order,
+		IsReversed:      req.IsReversed,
+	}
+
+	if err := q.questionnaireRepo.CreateItem(ctx, item); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create item", 500)
+	}
+	if err := q.questionnaireRepo.SyncAmountItems(ctx, questionnaire.ID); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update amount items", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuestionnaireItem, item.ID.String(), nil, itemAuditData(item))
+	return nil
+}
+
+func itemAuditData(item *models.QuestionnaireItem) map[string]any {
+	return map[string]any{
+		"questionnaire_id": item.QuestionnaireID,
+		"statement":        item.Statement,
+		"item_order":       item.ItemOrder,
+		"is_reversed":      item.IsReversed,
+	}
+}
+
+func (q *QuestionnaireServiceImpl) findItem(ctx context.Context, questionnaireId, itemId uuid.UUID) (*models.QuestionnaireItem, error) {
+	item, err := q.questionnaireRepo.FindItemById(ctx, itemId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "item not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get item", 500)
+	}
+	if item.QuestionnaireID != questionnaireId {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "item not found", 404)
+	}
+	return item, nil
+}
+
+// UpdateItem implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) UpdateItem(ctx context.Context, questionnaireId, itemId uuid.UUID, req questionnairerequest.UpdateQuestionnaireItemRequest) error {
+	item, err := q.findItem(ctx, questionnaireId, itemId)
+	if err != nil {
+		return err
+	}
+	before := itemAuditData(item)
+
+	// urutan boleh diubah kapan saja, isi & arah skor dikunci setelah ada responden
+	if strings.TrimSpace(req.Statement) != "" || req.IsReversed != nil {
+		if err := q.ensureNoRespondents(ctx, questionnaireId); err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(req.Statement) != "" {
+		item.Statement = strings.TrimSpace(req.Statement)
+	}
+	if req.ItemOrder != nil {
+		item.ItemOrder = *req.ItemOrder
+	}
+	if req.IsReversed != nil {
+		item.IsReversed = *req.IsReversed
+	}
+
+	if err := q.questionnaireRepo.UpdateItem(ctx, itemId, item); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update item", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuestionnaireItem, itemId.String(), before, itemAuditData(item))
+	return nil
+}
+
+// DeleteItem implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) DeleteItem(ctx context.Context, questionnaireId, itemId uuid.UUID) error {
+	item, err := q.findItem(ctx, questionnaireId, itemId)
+	if err != nil {
+		return err
+	}
+	if err := q.ensureNoRespondents(ctx, questionnaireId); err != nil {
+		return err
+	}
+
+	if err := q.questionnaireRepo.DeleteItem(ctx, itemId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete item", 500)
+	}
+	if err := q.questionnaireRepo.SyncAmountItems(ctx, questionnaireId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update amount items", 500)
+	}
+
+	q.invalidateCacheQuestionnaire(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuestionnaireItem, itemId.String(), itemAuditData(item), nil)
+	return nil
+}
+
+// GetAllAvailable implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) GetAllAvailable(ctx context.Context, studentId uuid.UUID, search string) ([]questionnaireresponse.AvailableQuestionnaireResponse, error) {
+	questionnaires, err := q.questionnaireRepo.FindAllAvailable(ctx, search)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get questionnaires", 500)
+	}
+
+	responded, err := q.questionnaireRepo.FindRespondedPeriods(ctx, studentId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get respondents", 500)
+	}
+	respondedSet := make(map[string]bool, len(responded))
+	for _, r := range responded {
+		respondedSet[r.QuestionnaireID.String()+":"+r.Period] = true
+	}
+
+	data := make([]questionnaireresponse.AvailableQuestionnaireResponse, len(questionnaires))
+	for i, questionnaire := range questionnaires {
+		hasResponded := respondedSet[questionnaire.ID.String()+":"+questionnaire.ActivePeriod]
+		data[i] = questionnaireresponse.ToAvailableQuestionnaireResponse(*questionnaire, hasResponded, false)
+	}
+
+	return data, nil
+}
+
+// GetAvailableById implements IQuestionnaireService.
+func (q *QuestionnaireServiceImpl) GetAvailableById(ctx context.Context, studentId, questionnaireId uuid.UUID) (*questionnaireresponse.AvailableQuestionnaireResponse, error) {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return nil, err
+	}
+	if questionnaire.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "questionnaire not found", 404)
+	}
+
+	hasResponded, err := q.questionnaireRepo.ExistsRespondent(ctx, questionnaire.ID, studentId, questionnaire.ActivePeriod)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check respondent", 500)
+	}
+
+	data := questionnaireresponse.ToAvailableQuestionnaireResponse(*questionnaire, hasResponded, true)
+	return &data, nil
+}
+
+// SubmitQuestionnaire implements IQuestionnaireService.
+// Semua item wajib dijawab dengan nilai di dalam skala, dan siswa hanya bisa mengisi sekali per periode aktif.
+func (q *QuestionnaireServiceImpl) SubmitQuestionnaire(ctx context.Context, studentId, questionnaireId uuid.UUID, req questionnairerequest.SubmitQuestionnaireRequest) error {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return err
+	}
+	if questionnaire.Status != 1 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "questionnaire is not open", 400)
+	}
+
+	period := questionnaire.ActivePeriod
+	exists, err := q.questionnaireRepo.ExistsRespondent(ctx, questionnaire.ID, studentId, period)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check respondent", 500)
+	}
+	if exists {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "Anda sudah mengisi kuesioner ini untuk periode "+period, 409)
+	}
+
+	student, err := q.studentRepo.FindByStudentID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+
+	itemMap := make(map[uuid.UUID]models.QuestionnaireItem, len(questionnaire.Items))
+	for _, item := range questionnaire.Items {
+		itemMap[item.ID] = item
+	}
+
+	if len(req.Answers) != len(itemMap) {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("all %d items must be answered", len(itemMap)), 400)
+	}
+
+	answers := make([]models.RespondentAnswer, 0, len(req.Answers))
+	answered := make(map[uuid.UUID]bool, len(req.Answers))
+	total := 0
+	for _, ans := range req.Answers {
+		item, ok := itemMap[ans.ItemID]
+		if !ok {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "item does not belong to this questionnaire", 400)
+		}
+		if answered[ans.ItemID] {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "each item can only be answered once", 400)
+		}
+		if ans.Value < questionnaire.ScaleMin || ans.Value > questionnaire.ScaleMax {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("value must be between %d and %d", questionnaire.ScaleMin, questionnaire.ScaleMax), 400)
+		}
+		answered[ans.ItemID] = true
+
+		score := likertScore(ans.Value, questionnaire.ScaleMin, questionnaire.ScaleMax, item.IsReversed)
+		total += score
+		answers = append(answers, models.RespondentAnswer{
+			ID:     uuid.New(),
+			ItemID: item.ID,
+			Value:  ans.Value,
+			Score:  score,
+		})
+	}
+
+	respondent := &models.Respondents{
+		ID:              uuid.New(),
+		UserID:          student.ID,
+		QuestionnaireID: questionnaire.ID,
+		Period:          period,
+		ClassID:         student.ClassID,
+		TotalScore:      total,
+		AverageScore:    utils.Round2(float64(total) / float64(len(answers))),
+		Answers:         answers,
+	}
+
+	if err := q.questionnaireRepo.CreateRespondent(ctx, respondent); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "duplicate key") {
+			return errorresponse.NewCustomError(errorresponse.ErrExists, "Anda sudah mengisi kuesioner ini untuk periode "+period, 409)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to submit questionnaire", 500)
+	}
+
+	return nil
+}
+
+// GetResultByClass implements IQuestionnaireService.
+// Rata-rata skor (setelah item negatif dibalik) dan sebaran jawaban mentah per item dihitung per kelas.
+func (q *QuestionnaireServiceImpl) GetResultByClass(ctx context.Context, questionnaireId uuid.UUID, period string, classId *uuid.UUID) (*questionnaireresponse.QuestionnaireResultResponse, error) {
+	questionnaire, err := q.findQuestionnaire(ctx, questionnaireId)
+	if err != nil {
+		return nil, err
+	}
+
+	period = normalizePeriod(period)
+	if period == "" {
+		period = questionnaire.ActivePeriod
+	}
+
+	respondents, err := q.questionnaireRepo.FindRespondentsForResult(ctx, questionnaire.ID, period, classId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get respondents", 500)
+	}
+
+	type itemAgg struct {
+		sum    int
+		count  int
+		values map[int]int
+	}
+	type classAgg struct {
+		respondents int
+		sumAverage  float64
+		sumTotal    int
+		items       map[uuid.UUID]*itemAgg
+	}
+
+	const noClass = "-"
+	groups := make(map[string]*classAgg)
+	classIds := make(map[string]*uuid.UUID)
+	overallSum := 0.0
+
+	for _, r := range respondents {
+		key := noClass
+		if r.ClassID != nil {
+			key = r.ClassID.String()
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &classAgg{items: make(map[uuid.UUID]*itemAgg)}
+			groups[key] = group
+			classIds[key] = r.ClassID
+		}
+
+		group.respondents++
+		group.sumAverage += r.AverageScore
+		group.sumTotal += r.TotalScore
+		overallSum += r.AverageScore
+
+		for _, ans := range r.Answers {
+			agg, ok := group.items[ans.ItemID]
+			if !ok {
+				agg = &itemAgg{values: make(map[int]int)}
+				group.items[ans.ItemID] = agg
+			}
+			agg.sum += ans.Score
+			agg.count++
+			agg.values[ans.Value]++
+		}
+	}
+
+	classes := make([]questionnaireresponse.ClassQuestionnaireResultResponse, 0, len(groups))
+	for key, group := range groups {
+		result := questionnaireresponse.ClassQuestionnaireResultResponse{
+			ClassID:           classIds[key],
+			NameClass:         "Tanpa Kelas",
+			Respondents:       group.respondents,
+			AverageScore:      utils.Round2(group.sumAverage / float64(group.respondents)),
+			AverageTotalScore: utils.Round2(float64(group.sumTotal) / float64(group.respondents)),
+			Items:             make([]questionnaireresponse.ItemResultResponse, 0, len(questionnaire.Items)),
+		}
+
+		if id := classIds[key]; id != nil {
+			if class, err := q.classRepo.FindById(ctx, *id); err == nil {
+				result.NameClass = class.NameClass
+				result.Grade = class.Grade
+			}
+		}
+
+		for _, item := range questionnaire.Items {
+			itemResult := questionnaireresponse.ItemResultResponse{
+				ItemID:       item.ID,
+				Statement:    item.Statement,
+				IsReversed:   item.IsReversed,
+				Distribution: make([]questionnaireresponse.LikertCountResponse, 0, questionnaire.ScaleMax-questionnaire.ScaleMin+1),
+			}
+			agg := group.items[item.ID]
+			if agg != nil && agg.count > 0 {
+				itemResult.Mean = utils.Round2(float64(agg.sum) / float64(agg.count))
+			}
+			for v := questionnaire.ScaleMin; v <= questionnaire.ScaleMax; v++ {
+				count := 0
+				if agg != nil {
+					count = agg.values[v]
+				}
+				itemResult.Distribution = append(itemResult.Distribution, questionnaireresponse.LikertCountResponse{Value: v, Count: count})
+			}
+			result.Items = append(result.Items, itemResult)
+		}
+
+		classes = append(classes, result)
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		if classes[i].Grade != classes[j].Grade {
+			return classes[i].Grade < classes[j].Grade
+		}
+		return classes[i].NameClass < classes[j].NameClass
+	})
+
+	overall := 0.0
+	if len(respondents) > 0 {
+		overall = utils.Round2(overallSum / float64(len(respondents)))
+	}
+
+	return &questionnaireresponse.QuestionnaireResultResponse{
+		QuestionnaireID:  questionnaire.ID,
+		Title:            questionnaire.Title,
+		Type:             string(questionnaire.Type),
+		Period:           period,
+		ScaleMin:         questionnaire.ScaleMin,
+		ScaleMax:         questionnaire.ScaleMax,
+		TotalRespondents: len(respondents),
+		AverageScore:     overall,
+		Classes:          classes,
+	}, nil
+}
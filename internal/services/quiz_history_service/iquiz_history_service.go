@@ -2,14 +2,17 @@ package quizhistoryservice
 
 import (
 	"context"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
 	quizhistoryresponse "giat-cerika-service/internal/dto/response/quiz_history_response"
-	"giat-cerika-service/internal/models"
 
 	"github.com/google/uuid"
 )
 
 type IQuizHistoryService interface {
 	GetHistoryQuizStudent(ctx context.Context, userId uuid.UUID, search string) ([]quizhistoryresponse.QuizHistoryResponse, error)
-	GetAllHistoryQuestionByQuizHistory(ctx context.Context, quizHistoryId uuid.UUID) ([]*models.QuestionHistory, error)
+	// GetAllHistoryQuestionByQuizHistory hanya untuk pemilik riwayat; kunci jawaban mengikuti CorrectAnswerVisibility quiz.
+	GetAllHistoryQuestionByQuizHistory(ctx context.Context, userId uuid.UUID, quizHistoryId uuid.UUID) ([]quizhistoryresponse.QuestionHistory, error)
 	GetHistoryQuizByQuizID(ctx context.Context) ([]quizhistoryresponse.QuizHistoryGroupAdminResponse, error)
+	GetItemAnalysis(ctx context.Context, quizId uuid.UUID) (*quizhistoryresponse.QuizItemAnalysisResponse, error)
+	ExportQuizHistory(ctx context.Context, req quizrequest.ExportQuizHistoryRequest) (*quizhistoryresponse.QuizHistoryExport, error)
 }
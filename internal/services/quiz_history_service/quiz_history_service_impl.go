@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"giat-cerika-service/configs"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
 	quizhistoryresponse "giat-cerika-service/internal/dto/response/quiz_history_response"
 	"giat-cerika-service/internal/models"
 	quizhistoryrepo "giat-cerika-service/internal/repositories/quiz_history_repo"
@@ -13,6 +14,10 @@ import (
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"giat-cerika-service/pkg/utils"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -114,16 +119,7 @@ func (q QuizHistoryServiceImpl) GetHistoryQuizStudent(
 }
 
 // GetAllHistoryQuestionByQuizHistory implements [IQuizHistoryService].
-func (q *QuizHistoryServiceImpl) GetAllHistoryQuestionByQuizHistory(ctx context.Context, quizHistoryId uuid.UUID) ([]*models.QuestionHistory, error) {
-	cacheKey := fmt.Sprintf("questions_history:quiz_history:%s", quizHistoryId)
-
-	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
-		var data []*models.QuestionHistory
-		if json.Unmarshal([]byte(cached), &data) == nil {
-			return data, nil
-		}
-	}
-
+func (q *QuizHistoryServiceImpl) GetAllHistoryQuestionByQuizHistory(ctx context.Context, userId uuid.UUID, quizHistoryId uuid.UUID) ([]quizhistoryresponse.QuestionHistory, error) {
 	quizHistory, err := q.quizHistoryRepo.FindQuizHistoryById(ctx, quizHistoryId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -131,35 +127,79 @@ func (q *QuizHistoryServiceImpl) GetAllHistoryQuestionByQuizHistory(ctx context.
 		}
 		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz history", 500)
 	}
+	if quizHistory.UserID != userId {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz history not found", 404)
+	}
 
-	items, err := q.quizHistoryRepo.FindAllQuestionHistory(ctx, quizHistory.ID)
-	if err != nil {
-		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get question history", 500)
+	// Pengaturan quiz terbaru diutamakan; snapshot di history dipakai bila quiz sudah dihapus.
+	visibility, endDate := quizHistory.CorrectAnswerVisibility, quizHistory.EndDate
+	if quiz, err := q.quizRepo.FindQuizAvailableById(ctx, quizHistory.QuizID); err == nil {
+		visibility, endDate = quiz.CorrectAnswerVisibility, &quiz.EndDate
+	}
+	showAnswerKey := isAnswerKeyVisible(visibility, endDate, time.Now())
+
+	cacheKey := fmt.Sprintf("questions_history:quiz_history:%s", quizHistoryId)
+
+	var items []*models.QuestionHistory
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		_ = json.Unmarshal([]byte(cached), &items)
 	}
 
 	if items == nil {
-		items = []*models.QuestionHistory{}
+		items, err = q.quizHistoryRepo.FindAllQuestionHistory(ctx, quizHistory.ID)
+		if err != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get question history", 500)
+		}
+		if items == nil {
+			items = []*models.QuestionHistory{}
+		}
+
+		buf, _ := json.Marshal(items)
+		_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
 	}
 
-	buf, _ := json.Marshal(map[string]any{
-		"data": items,
-	})
+	res := make([]quizhistoryresponse.QuestionHistory, len(items))
+	for i, item := range items {
+		res[i] = quizhistoryresponse.ToQuestionHistory(*item, showAnswerKey)
+	}
 
-	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+	return res, nil
+}
 
-	return items, nil
+// isAnswerKeyVisible menentukan apakah score_value tiap opsi (kunci jawaban) boleh dikirim ke siswa.
+// Nilai kosong (data lama) diperlakukan sebagai after_submit.
+func isAnswerKeyVisible(visibility models.CorrectAnswerVisibility, endDate *time.Time, now time.Time) bool {
+	switch visibility {
+	case models.CorrectAnswerNever:
+		return false
+	case models.CorrectAnswerAfterClose:
+		if endDate == nil {
+			return false
+		}
+		// end_date bertipe timestamp tanpa zona berisi jam WIB; dibangun ulang seperti di StartQuizSession
+		locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+		end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(),
+			endDate.Hour(), endDate.Minute(), endDate.Second(), 0, locJakarta)
+		return !now.Before(end)
+	default:
+		return true
+	}
 }
 
 // GetHistoryQuizByQuizID implements [IQuizHistoryService].
 func (q *QuizHistoryServiceImpl) GetHistoryQuizByQuizID(ctx context.Context) ([]quizhistoryresponse.QuizHistoryGroupAdminResponse, error) {
+	teacherId := utils.TeacherScopeFromContext(ctx)
 	cacheKey := "quizHistory:all"
+	if teacherId != nil {
+		cacheKey = fmt.Sprintf("quizHistory:all:teacher:%s", *teacherId)
+	}
 	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
 		var data []quizhistoryresponse.QuizHistoryGroupAdminResponse
 		if json.Unmarshal([]byte(cached), &data) == nil {
 			return data, nil
 		}
 	}
-	items, err := q.quizHistoryRepo.FindHistoryByQuizID(ctx)
+	items, err := q.quizHistoryRepo.FindHistoryByQuizID(ctx, teacherId)
 	if err != nil {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get history quiz student", 500)
 	}
@@ -251,3 +291,446 @@ func (q *QuizHistoryServiceImpl) GetHistoryQuizByQuizID(ctx context.Context) ([]
 
 	return result, nil
 }
+
+// Ambang batas analisis butir soal.
+const (
+	itemTooDifficult       = 0.20 // p < 0.20 → terlalu sulit
+	itemTooEasy            = 0.90 // p > 0.90 → terlalu mudah
+	itemPoorDiscrimination = 0.20 // D < 0.20 → daya beda jelek
+	itemWeakDistractor     = 0.05 // pengecoh dipilih < 5% peserta → tidak berfungsi
+)
+
+// GetItemAnalysis implements [IQuizHistoryService].
+// Untuk setiap soal dihitung tingkat kesukaran (p = proporsi benar) dan daya beda
+// D = (benar kelompok atas - benar kelompok bawah) / n, dengan n = 27% peserta
+// diurutkan dari persentase skor. Hanya percobaan pertama tiap siswa yang dipakai;
+// untuk guru, peserta dibatasi ke siswa di kelas yang diampunya.
+// Jawaban benar adalah opsi dengan ScoreValue tertinggi (> 0) pada snapshot soal.
+func (q QuizHistoryServiceImpl) GetItemAnalysis(ctx context.Context, quizId uuid.UUID) (*quizhistoryresponse.QuizItemAnalysisResponse, error) {
+	quiz, err := q.quizRepo.FindById(ctx, quizId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+
+	histories, err := q.quizHistoryRepo.FindCompletedHistoryWithQuestions(ctx, quizId, utils.TeacherScopeFromContext(ctx))
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz history", 500)
+	}
+
+	// percobaan pertama per siswa
+	seenUser := make(map[uuid.UUID]bool)
+	attempts := make([]*models.QuizHistory, 0, len(histories))
+	sessionIds := make([]uuid.UUID, 0, len(histories))
+	for _, h := range histories {
+		if seenUser[h.UserID] {
+			continue
+		}
+		seenUser[h.UserID] = true
+		attempts = append(attempts, h)
+		sessionIds = append(sessionIds, h.QuizSessionID)
+	}
+
+	responses, err := q.quizHistoryRepo.FindResponsesBySessionIDs(ctx, sessionIds)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get responses", 500)
+	}
+	// sessionId -> questionId -> answerId
+	selected := make(map[uuid.UUID]map[uuid.UUID]uuid.UUID)
+	for _, r := range responses {
+		if r.AnswerID == nil {
+			continue
+		}
+		if selected[r.QuizSessionID] == nil {
+			selected[r.QuizSessionID] = make(map[uuid.UUID]uuid.UUID)
+		}
+		selected[r.QuizSessionID][r.QuestionID] = *r.AnswerID
+	}
+
+	sort.SliceStable(attempts, func(i, j int) bool {
+		return attempts[i].Percentage > attempts[j].Percentage
+	})
+
+	total := len(attempts)
+	groupSize := 0
+	if total >= 2 {
+		groupSize = int(math.Round(float64(total) * 0.27))
+		if groupSize < 1 {
+			groupSize = 1
+		}
+	}
+	upperGroup := make(map[uuid.UUID]bool, groupSize)
+	lowerGroup := make(map[uuid.UUID]bool, groupSize)
+	for i := 0; i < groupSize; i++ {
+		upperGroup[attempts[i].ID] = true
+		lowerGroup[attempts[total-1-i].ID] = true
+	}
+
+	type optionAgg struct {
+		text     string
+		isKey    bool
+		selected int
+		upper    int
+		lower    int
+	}
+	type itemAgg struct {
+		text         string
+		respondents  int
+		correct      int
+		omitted      int
+		upperCorrect int
+		lowerCorrect int
+		optionOrder  []uuid.UUID
+		options      map[uuid.UUID]*optionAgg
+	}
+
+	items := make(map[uuid.UUID]*itemAgg)
+	itemOrder := make([]uuid.UUID, 0)
+
+	for _, h := range attempts {
+		chosen := selected[h.QuizSessionID]
+		for _, qh := range h.QuestionHistory {
+			item, ok := items[qh.QuestionID]
+			if !ok {
+				item = &itemAgg{options: make(map[uuid.UUID]*optionAgg)}
+				items[qh.QuestionID] = item
+				itemOrder = append(itemOrder, qh.QuestionID)
+			}
+			// snapshot terbaru yang dipakai sebagai teks soal
+			item.text = qh.QuestionText
+			item.respondents++
+
+			maxScore := 0
+			for _, ah := range qh.AnswerHistory {
+				if ah.ScoreValue > maxScore {
+					maxScore = ah.ScoreValue
+				}
+			}
+
+			// pilihan tunggal dibaca dari responses; tipe lain dari snapshot answer history
+			isChoice := qh.QuestionType.IsChoice()
+			answerId, answered := chosen[qh.QuestionID]
+			if !isChoice {
+				answered = qh.SubmittedText != ""
+				for _, ah := range qh.AnswerHistory {
+					answered = answered || ah.Selected
+				}
+			}
+			if !answered {
+				item.omitted++
+			}
+
+			isCorrect := false
+			for _, ah := range qh.AnswerHistory {
+				opt, ok := item.options[ah.AnswerID]
+				if !ok {
+					opt = &optionAgg{}
+					item.options[ah.AnswerID] = opt
+					item.optionOrder = append(item.optionOrder, ah.AnswerID)
+				}
+				opt.text = ah.AnswerText
+				if isChoice {
+					opt.isKey = maxScore > 0 && ah.ScoreValue == maxScore
+				} else {
+					opt.isKey = ah.ScoreValue > 0
+				}
+
+				isSelected := ah.Selected
+				if isChoice {
+					isSelected = answered && ah.AnswerID == answerId
+				}
+				if !isSelected {
+					continue
+				}
+				opt.selected++
+				if upperGroup[h.ID] {
+					opt.upper++
+				}
+				if lowerGroup[h.ID] {
+					opt.lower++
+				}
+				if isChoice && opt.isKey {
+					isCorrect = true
+				}
+			}
+
+			// tipe non-pilihan dianggap benar bila memperoleh skor penuh
+			if !isChoice {
+				isCorrect = qh.MaxScore > 0 && qh.ScoreEarned >= qh.MaxScore
+			}
+			if isCorrect {
+				item.correct++
+				if upperGroup[h.ID] {
+					item.upperCorrect++
+				}
+				if lowerGroup[h.ID] {
+					item.lowerCorrect++
+				}
+			}
+		}
+	}
+
+	result := &quizhistoryresponse.QuizItemAnalysisResponse{
+		QuizID:       quiz.ID,
+		Title:        quiz.Title,
+		Participants: total,
+		GroupSize:    groupSize,
+		Items:        make([]quizhistoryresponse.ItemAnalysisResponse, 0, len(itemOrder)),
+	}
+
+	for _, questionId := range itemOrder {
+		item := items[questionId]
+		analysis := quizhistoryresponse.ItemAnalysisResponse{
+			QuestionID:   questionId,
+			QuestionText: item.text,
+			Respondents:  item.respondents,
+			Correct:      item.correct,
+			Omitted:      item.omitted,
+			Flags:        []string{},
+			Options:      make([]quizhistoryresponse.OptionAnalysisResponse, 0, len(item.optionOrder)),
+		}
+
+		if item.respondents > 0 {
+			analysis.Difficulty = utils.Round2(float64(item.correct) / float64(item.respondents))
+		}
+		if groupSize > 0 {
+			d := utils.Round2(float64(item.upperCorrect-item.lowerCorrect) / float64(groupSize))
+			analysis.Discrimination = &d
+		}
+
+		if item.respondents > 0 {
+			switch {
+			case analysis.Difficulty < itemTooDifficult:
+				analysis.Flags = append(analysis.Flags, "too_difficult")
+			case analysis.Difficulty > itemTooEasy:
+				analysis.Flags = append(analysis.Flags, "too_easy")
+			}
+		}
+		if analysis.Discrimination != nil {
+			switch {
+			case *analysis.Discrimination < 0:
+				analysis.Flags = append(analysis.Flags, "negative_discrimination")
+			case *analysis.Discrimination < itemPoorDiscrimination:
+				analysis.Flags = append(analysis.Flags, "poor_discrimination")
+			}
+		}
+
+		hasKey := false
+		weakDistractor := false
+		for _, answerId := range item.optionOrder {
+			opt := item.options[answerId]
+			percentage := 0.0
+			if item.respondents > 0 {
+				percentage = utils.Round2(float64(opt.selected) / float64(item.respondents) * 100)
+			}
+			if opt.isKey {
+				hasKey = true
+			} else if item.respondents > 0 && percentage < itemWeakDistractor*100 {
+				weakDistractor = true
+			}
+
+			analysis.Options = append(analysis.Options, quizhistoryresponse.OptionAnalysisResponse{
+				AnswerID:   answerId,
+				AnswerText: opt.text,
+				IsKey:      opt.isKey,
+				Selected:   opt.selected,
+				Percentage: percentage,
+				Upper:      opt.upper,
+				Lower:      opt.lower,
+			})
+		}
+		if !hasKey {
+			analysis.Flags = append(analysis.Flags, "no_answer_key")
+		}
+		if weakDistractor {
+			analysis.Flags = append(analysis.Flags, "non_functioning_distractor")
+		}
+
+		result.Items = append(result.Items, analysis)
+	}
+
+	return result, nil
+}
+
+// exportAnswerText meringkas jawaban tipe non-pilihan untuk satu sel export.
+func exportAnswerText(qh models.QuestionHistory) string {
+	if qh.QuestionType == models.QuestionShortAnswer {
+		return qh.SubmittedText
+	}
+	parts := make([]string, 0, len(qh.AnswerHistory))
+	for _, ah := range qh.AnswerHistory {
+		if !ah.Selected {
+			continue
+		}
+		if qh.QuestionType == models.QuestionMatching {
+			parts = append(parts, ah.AnswerText+" - "+ah.SubmittedMatch)
+		} else {
+			parts = append(parts, ah.AnswerText)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func statusCategoryLabel(category int) string {
+	switch category {
+	case 1:
+		return "Baik"
+	case 2:
+		return "Cukup"
+	case 3:
+		return "Kurang"
+	}
+	return "-"
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	return t.In(locJakarta).Format("2006-01-02 15:04:05")
+}
+
+// ExportQuizHistory implements [IQuizHistoryService].
+// Kolom per soal (jawaban yang dipilih & skor) hanya bisa ditambahkan bila ekspor dibatasi pada satu quiz,
+// urutan kolomnya mengikuti snapshot soal pada riwayat pertama.
+func (q QuizHistoryServiceImpl) ExportQuizHistory(ctx context.Context, req quizrequest.ExportQuizHistoryRequest) (*quizhistoryresponse.QuizHistoryExport, error) {
+	filter := quizhistoryrepo.QuizHistoryExportFilter{
+		QuizID:        req.QuizID,
+		ClassID:       req.ClassID,
+		TeacherID:     utils.TeacherScopeFromContext(ctx),
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+		WithQuestions: req.IncludeAnswers,
+	}
+
+	if filter.WithQuestions && filter.QuizID == nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "quiz_id is required to include per-question answers", 400)
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "end date must be after start date", 400)
+	}
+
+	histories, err := q.quizHistoryRepo.FindHistoryForExport(ctx, filter)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz history", 500)
+	}
+
+	userIds := make([]uuid.UUID, 0, len(histories))
+	sessionIds := make([]uuid.UUID, 0, len(histories))
+	seenUser := make(map[uuid.UUID]bool)
+	for _, h := range histories {
+		if !seenUser[h.UserID] {
+			seenUser[h.UserID] = true
+			userIds = append(userIds, h.UserID)
+		}
+		sessionIds = append(sessionIds, h.QuizSessionID)
+	}
+
+	students, err := q.studentRepo.FindByUserIDs(ctx, userIds)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get students", 500)
+	}
+	studentMap := make(map[uuid.UUID]*models.User, len(students))
+	for _, s := range students {
+		studentMap[s.ID] = s
+	}
+
+	headers := []string{"Nama", "NISN", "Kelas", "Kode Quiz", "Quiz", "Skor", "Skor Maksimal", "Persentase", "Kategori", "Status", "Mulai", "Selesai"}
+
+	// soal dikumpulkan dari snapshot; urutan mengikuti kemunculan pertama
+	var questionOrder []uuid.UUID
+	selected := make(map[uuid.UUID]map[uuid.UUID]uuid.UUID)
+	if filter.WithQuestions {
+		seenQuestion := make(map[uuid.UUID]bool)
+		for _, h := range histories {
+			for _, qh := range h.QuestionHistory {
+				if !seenQuestion[qh.QuestionID] {
+					seenQuestion[qh.QuestionID] = true
+					questionOrder = append(questionOrder, qh.QuestionID)
+				}
+			}
+		}
+		for i := range questionOrder {
+			headers = append(headers, fmt.Sprintf("Soal %d", i+1), fmt.Sprintf("Skor Soal %d", i+1))
+		}
+
+		responses, err := q.quizHistoryRepo.FindResponsesBySessionIDs(ctx, sessionIds)
+		if err != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get responses", 500)
+		}
+		for _, r := range responses {
+			if r.AnswerID == nil {
+				continue
+			}
+			if selected[r.QuizSessionID] == nil {
+				selected[r.QuizSessionID] = make(map[uuid.UUID]uuid.UUID)
+			}
+			selected[r.QuizSessionID][r.QuestionID] = *r.AnswerID
+		}
+	}
+
+	rows := make([][]string, 0, len(histories))
+	for _, h := range histories {
+		name, nisn, className := "", "", ""
+		if s, ok := studentMap[h.UserID]; ok {
+			if s.Name != nil {
+				name = *s.Name
+			}
+			if s.Nisn != nil {
+				nisn = *s.Nisn
+			}
+			if s.ClassID != nil {
+				className = s.Class.NameClass
+			}
+		}
+
+		row := []string{
+			name,
+			nisn,
+			className,
+			h.Code,
+			h.Title,
+			strconv.Itoa(h.Score),
+			strconv.Itoa(h.MaxScore),
+			strconv.FormatFloat(utils.Round2(h.Percentage), 'f', 2, 64),
+			statusCategoryLabel(h.StatusCategory),
+			string(h.Status),
+			formatExportTime(h.StartedAt),
+			formatExportTime(h.CompletedAt),
+		}
+
+		if filter.WithQuestions {
+			questionMap := make(map[uuid.UUID]models.QuestionHistory, len(h.QuestionHistory))
+			for _, qh := range h.QuestionHistory {
+				questionMap[qh.QuestionID] = qh
+			}
+			chosen := selected[h.QuizSessionID]
+
+			for _, questionId := range questionOrder {
+				answerText, score := "", ""
+				if qh, ok := questionMap[questionId]; ok && !qh.QuestionType.IsChoice() {
+					answerText, score = exportAnswerText(qh), strconv.Itoa(qh.ScoreEarned)
+				} else if ok {
+					score = "0"
+					if answerId, answered := chosen[questionId]; answered {
+						for _, ah := range qh.AnswerHistory {
+							if ah.AnswerID == answerId {
+								answerText = ah.AnswerText
+								score = strconv.Itoa(ah.ScoreEarned)
+								break
+							}
+						}
+					}
+				}
+				row = append(row, answerText, score)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return &quizhistoryresponse.QuizHistoryExport{Headers: headers, Rows: rows}, nil
+}
@@ -0,0 +1,42 @@
+package quizhistoryservice
+
+import (
+	"giat-cerika-service/internal/models"
+	"testing"
+	"time"
+)
+
+func TestIsAnswerKeyVisible_AfterCloseUsesJakartaWallClock(t *testing.T) {
+	locJakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	// end_date dibaca dari kolom timestamp tanpa zona: jam WIB berlabel UTC
+	endDate := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		// 03:30 UTC masih sebelum 10:00 bila dibandingkan mentah, padahal quiz sudah tutup
+		{"closed within the utc offset", time.Date(2026, 3, 10, 10, 30, 0, 0, locJakarta), true},
+		{"still open", time.Date(2026, 3, 10, 9, 30, 0, 0, locJakarta), false},
+		{"exactly at close", time.Date(2026, 3, 10, 10, 0, 0, 0, locJakarta), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := isAnswerKeyVisible(models.CorrectAnswerAfterClose, &endDate, tc.now)
+			if got != tc.want {
+				t.Fatalf("isAnswerKeyVisible(after_close, now=%s) = %v, want %v", tc.now, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsAnswerKeyVisible_AfterCloseWithoutEndDate(t *testing.T) {
+	if isAnswerKeyVisible(models.CorrectAnswerAfterClose, nil, time.Now()) {
+		t.Fatal("expected answer key hidden when end date is unknown")
+	}
+}
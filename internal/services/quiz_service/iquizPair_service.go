@@ -0,0 +1,19 @@
+package quizservice
+
+import (
+	"context"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
+	quizresponse "giat-cerika-service/internal/dto/response/quiz_response"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IQuizPairService interface {
+	CreateQuizPair(ctx context.Context, req quizrequest.CreateQuizPairRequest) error
+	GetAllQuizPair(ctx context.Context, page, limit int, search string) ([]*models.QuizPair, int, error)
+	GetQuizPairById(ctx context.Context, pairId uuid.UUID) (*models.QuizPair, error)
+	UpdateQuizPair(ctx context.Context, pairId uuid.UUID, req quizrequest.UpdateQuizPairRequest) error
+	DeleteQuizPair(ctx context.Context, pairId uuid.UUID) error
+	GetLearningGain(ctx context.Context, pairId uuid.UUID, classId *uuid.UUID) (*quizresponse.QuizPairGainResponse, error)
+}
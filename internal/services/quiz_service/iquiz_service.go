@@ -16,6 +16,12 @@ type IQuizService interface {
 	DeleteQuiz(ctx context.Context, quizId uuid.UUID) error
 	UpdateStatusQuiz(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateStatusQuizRequest) error
 	UpdateQuestionOrderMode(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateQuestionOrderModeRequest) error
+	// UpdateCorrectAnswerVisibility mengatur kapan kunci jawaban terlihat siswa di riwayat soal.
+	UpdateCorrectAnswerVisibility(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateCorrectAnswerVisibilityRequest) error
+	// UpdateShuffleAnswers mengaktifkan pengacakan opsi jawaban per sesi.
+	UpdateShuffleAnswers(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateShuffleAnswersRequest) error
+	// UpdateQuestionPool mengatur jumlah soal yang diundi per sesi (0 = semua soal) dan stratifikasinya.
+	UpdateQuestionPool(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateQuestionPoolRequest) error
 
 	GetAllQuizAvailable(ctx context.Context, search string) ([]*models.Quiz, error)
 	GetQuizAvailableById(ctx context.Context, quizId uuid.UUID) (*models.Quiz, error)
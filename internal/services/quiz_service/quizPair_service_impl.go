@@ -0,0 +1,398 @@
+package quizservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
+	quizresponse "giat-cerika-service/internal/dto/response/quiz_response"
+	"giat-cerika-service/internal/models"
+	quizhistoryrepo "giat-cerika-service/internal/repositories/quiz_history_repo"
+	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type QuizPairServiceImpl struct {
+	pairRepo        quizrepo.IQuizPairRepository
+	quizRepo        quizrepo.IQuizRepository
+	quizHistoryRepo quizhistoryrepo.IQuizHistoryRepository
+	studentRepo     studentrepo.IStudentRepository
+	audit           auditservice.IAuditService
+	rdb             *redis.Client
+}
+
+func NewQuizPairServiceImpl(pairRepo quizrepo.IQuizPairRepository, quizRepo quizrepo.IQuizRepository, quizHistoryRepo quizhistoryrepo.IQuizHistoryRepository, studentRepo studentrepo.IStudentRepository, auditService auditservice.IAuditService, rdb *redis.Client) IQuizPairService {
+	return &QuizPairServiceImpl{pairRepo: pairRepo, quizRepo: quizRepo, quizHistoryRepo: quizHistoryRepo, studentRepo: studentRepo, audit: auditService, rdb: rdb}
+}
+
+func (q *QuizPairServiceImpl) invalidateCacheQuizPair(ctx context.Context) {
+	iter := q.rdb.Scan(ctx, 0, "quizPairs:*", 0).Iterator()
+	for iter.Next(ctx) {
+		q.rdb.Del(ctx, iter.Val())
+	}
+}
+
+func (q *QuizPairServiceImpl) validatePairQuizzes(ctx context.Context, pairId *uuid.UUID, preQuizId, postQuizId uuid.UUID) error {
+	if preQuizId == uuid.Nil || postQuizId == uuid.Nil {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "pre quiz id and post quiz id are required", 400)
+	}
+	if preQuizId == postQuizId {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "pre quiz and post quiz must be different", 400)
+	}
+
+	quizzes, err := q.quizRepo.FindByIds(ctx, []uuid.UUID{preQuizId, postQuizId})
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+	if len(quizzes) != 2 {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+	}
+
+	existing, err := q.pairRepo.FindByQuizIds(ctx, preQuizId, postQuizId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check quiz pair", 500)
+	}
+	if existing != nil && (pairId == nil || existing.ID != *pairId) {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "quiz pair already exists", 409)
+	}
+
+	return nil
+}
+
+// CreateQuizPair implements IQuizPairService.
+func (q *QuizPairServiceImpl) CreateQuizPair(ctx context.Context, req quizrequest.CreateQuizPairRequest) error {
+	if strings.TrimSpace(req.Title) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "title is required", 400)
+	}
+	if err := q.validatePairQuizzes(ctx, nil, req.PreQuizID, req.PostQuizID); err != nil {
+		return err
+	}
+
+	newPair := &models.QuizPair{
+		ID:          uuid.New(),
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		PreQuizID:   req.PreQuizID,
+		PostQuizID:  req.PostQuizID,
+	}
+
+	if err := q.pairRepo.Create(ctx, newPair); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to save data", 500)
+	}
+
+	q.invalidateCacheQuizPair(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuizPair, newPair.ID.String(), nil, newPair)
+	return nil
+}
+
+// GetAllQuizPair implements IQuizPairService.
+func (q *QuizPairServiceImpl) GetAllQuizPair(ctx context.Context, page, limit int, search string) ([]*models.QuizPair, int, error) {
+	cacheKey := fmt.Sprintf("quizPairs:search:%s:page:%d:limit:%d", search, page, limit)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result struct {
+			Data  []*models.QuizPair `json:"data"`
+			Total int                `json:"total"`
+		}
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return result.Data, result.Total, nil
+		}
+	}
+
+	offset := (page - 1) * limit
+	items, total, err := q.pairRepo.FindAll(ctx, limit, offset, search)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz pairs", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.QuizPair{}
+	}
+
+	buf, _ := json.Marshal(map[string]any{
+		"data":  items,
+		"total": total,
+	})
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return items, total, nil
+}
+
+// GetQuizPairById implements IQuizPairService.
+func (q *QuizPairServiceImpl) GetQuizPairById(ctx context.Context, pairId uuid.UUID) (*models.QuizPair, error) {
+	pair, err := q.pairRepo.FindById(ctx, pairId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz pair not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz pair", 500)
+	}
+	return pair, nil
+}
+
+// UpdateQuizPair implements IQuizPairService.
+func (q *QuizPairServiceImpl) UpdateQuizPair(ctx context.Context, pairId uuid.UUID, req quizrequest.UpdateQuizPairRequest) error {
+	pair, err := q.GetQuizPairById(ctx, pairId)
+	if err != nil {
+		return err
+	}
+	before := *pair
+
+	preQuizId, postQuizId := pair.PreQuizID, pair.PostQuizID
+	if req.PreQuizID != uuid.Nil {
+		preQuizId = req.PreQuizID
+	}
+	if req.PostQuizID != uuid.Nil {
+		postQuizId = req.PostQuizID
+	}
+	if preQuizId != pair.PreQuizID || postQuizId != pair.PostQuizID {
+		if err := q.validatePairQuizzes(ctx, &pair.ID, preQuizId, postQuizId); err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(req.Title) != "" {
+		pair.Title = strings.TrimSpace(req.Title)
+	}
+	if strings.TrimSpace(req.Description) != "" {
+		pair.Description = req.Description
+	}
+	pair.PreQuizID = preQuizId
+	pair.PostQuizID = postQuizId
+
+	if err := q.pairRepo.Update(ctx, pairId, pair); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update quiz pair", 500)
+	}
+
+	q.invalidateCacheQuizPair(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuizPair, pairId.String(), before, pair)
+	return nil
+}
+
+// DeleteQuizPair implements IQuizPairService.
+func (q *QuizPairServiceImpl) DeleteQuizPair(ctx context.Context, pairId uuid.UUID) error {
+	pair, err := q.GetQuizPairById(ctx, pairId)
+	if err != nil {
+		return err
+	}
+
+	if err := q.pairRepo.Delete(ctx, pairId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete quiz pair", 500)
+	}
+
+	q.invalidateCacheQuizPair(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuizPair, pairId.String(), pair, nil)
+	return nil
+}
+
+func addGainCategory(dist *quizresponse.GainDistributionResponse, category string) {
+	switch category {
+	case utils.GainCategoryHigh:
+		dist.High++
+	case utils.GainCategoryMedium:
+		dist.Medium++
+	case utils.GainCategoryLow:
+		dist.Low++
+	default:
+		dist.Undefined++
+	}
+}
+
+// GetLearningGain implements IQuizPairService.
+// Nilai pre diambil dari percobaan pre-test pertama yang selesai dan nilai post dari percobaan post-test terakhir,
+// lalu dihitung gain ternormalisasi dari persentase skor (maksimal 100) agar quiz dengan MaxScore berbeda tetap sebanding.
+// Hanya siswa yang mengerjakan keduanya yang dihitung; guru hanya melihat siswa di kelas yang diampunya.
+func (q *QuizPairServiceImpl) GetLearningGain(ctx context.Context, pairId uuid.UUID, classId *uuid.UUID) (*quizresponse.QuizPairGainResponse, error) {
+	pair, err := q.GetQuizPairById(ctx, pairId)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := q.quizHistoryRepo.FindCompletedHistoryByQuizIDs(ctx, []uuid.UUID{pair.PreQuizID, pair.PostQuizID}, utils.TeacherScopeFromContext(ctx))
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz history", 500)
+	}
+
+	preByUser := make(map[uuid.UUID]*models.QuizHistory)
+	postByUser := make(map[uuid.UUID]*models.QuizHistory)
+	for _, h := range histories {
+		if h.QuizID == pair.PreQuizID {
+			if _, ok := preByUser[h.UserID]; !ok {
+				preByUser[h.UserID] = h
+			}
+		} else {
+			postByUser[h.UserID] = h
+		}
+	}
+
+	userIds := make([]uuid.UUID, 0, len(preByUser)+len(postByUser))
+	seen := make(map[uuid.UUID]bool)
+	for id := range preByUser {
+		userIds = append(userIds, id)
+		seen[id] = true
+	}
+	for id := range postByUser {
+		if !seen[id] {
+			userIds = append(userIds, id)
+		}
+	}
+
+	students, err := q.studentRepo.FindByUserIDs(ctx, userIds)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get students", 500)
+	}
+
+	type classAgg struct {
+		member   *models.User
+		students int
+		sumPre   float64
+		sumPost  float64
+		sumGain  float64
+		gains    int
+		dist     quizresponse.GainDistributionResponse
+	}
+
+	result := &quizresponse.QuizPairGainResponse{
+		Pair:     quizresponse.ToQuizPairResponse(*pair),
+		Classes:  []quizresponse.ClassGainResponse{},
+		Students: []quizresponse.StudentGainResponse{},
+	}
+
+	groups := make(map[string]*classAgg)
+	var sumPre, sumPost, sumGain float64
+	gains := 0
+
+	for _, student := range students {
+		if classId != nil && (student.ClassID == nil || *student.ClassID != *classId) {
+			continue
+		}
+
+		pre, hasPre := preByUser[student.ID]
+		post, hasPost := postByUser[student.ID]
+		switch {
+		case hasPre && !hasPost:
+			result.OnlyPre++
+			continue
+		case !hasPre && hasPost:
+			result.OnlyPost++
+			continue
+		}
+
+		gainValue, ok := utils.NormalizedGain(pre.Percentage, post.Percentage, 100)
+		category := utils.GainCategoryUndefined
+		var gain *float64
+		if ok {
+			rounded := utils.Round2(gainValue)
+			gain = &rounded
+			category = utils.GainCategory(gainValue)
+		}
+
+		nameClass := ""
+		key := "-"
+		if student.ClassID != nil {
+			key = student.ClassID.String()
+			nameClass = student.Class.NameClass
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &classAgg{member: student}
+			groups[key] = group
+		}
+
+		group.students++
+		group.sumPre += pre.Percentage
+		group.sumPost += post.Percentage
+		addGainCategory(&group.dist, category)
+		addGainCategory(&result.Distribution, category)
+		if ok {
+			group.sumGain += gainValue
+			group.gains++
+			sumGain += gainValue
+			gains++
+		}
+		sumPre += pre.Percentage
+		sumPost += post.Percentage
+		result.TotalPaired++
+
+		name, nisn := "", ""
+		if student.Name != nil {
+			name = *student.Name
+		}
+		if student.Nisn != nil {
+			nisn = *student.Nisn
+		}
+
+		result.Students = append(result.Students, quizresponse.StudentGainResponse{
+			UserID:         student.ID,
+			Name:           name,
+			Nisn:           nisn,
+			NameClass:      nameClass,
+			PrePercentage:  utils.Round2(pre.Percentage),
+			PostPercentage: utils.Round2(post.Percentage),
+			Gain:           gain,
+			Category:       category,
+		})
+	}
+
+	if result.TotalPaired > 0 {
+		result.AveragePre = utils.Round2(sumPre / float64(result.TotalPaired))
+		result.AveragePost = utils.Round2(sumPost / float64(result.TotalPaired))
+	}
+	if gains > 0 {
+		result.AverageGain = utils.Round2(sumGain / float64(gains))
+	}
+
+	for _, group := range groups {
+		avgPre := group.sumPre / float64(group.students)
+		avgPost := group.sumPost / float64(group.students)
+
+		classGain := quizresponse.ClassGainResponse{
+			ClassID:      group.member.ClassID,
+			NameClass:    "Tanpa Kelas",
+			Students:     group.students,
+			AveragePre:   utils.Round2(avgPre),
+			AveragePost:  utils.Round2(avgPost),
+			Distribution: group.dist,
+		}
+		if group.member.ClassID != nil {
+			classGain.NameClass = group.member.Class.NameClass
+			classGain.Grade = group.member.Class.Grade
+		}
+		if group.gains > 0 {
+			classGain.AverageGain = utils.Round2(group.sumGain / float64(group.gains))
+		}
+		// gain kelas versi Hake: dihitung dari rata-rata pre & post kelas
+		if g, ok := utils.NormalizedGain(avgPre, avgPost, 100); ok {
+			rounded := utils.Round2(g)
+			classGain.ClassNormalizedGain = &rounded
+		}
+
+		result.Classes = append(result.Classes, classGain)
+	}
+
+	sort.Slice(result.Classes, func(i, j int) bool {
+		if result.Classes[i].Grade != result.Classes[j].Grade {
+			return result.Classes[i].Grade < result.Classes[j].Grade
+		}
+		return result.Classes[i].NameClass < result.Classes[j].NameClass
+	})
+	sort.Slice(result.Students, func(i, j int) bool {
+		if result.Students[i].NameClass != result.Students[j].NameClass {
+			return result.Students[i].NameClass < result.Students[j].NameClass
+		}
+		return result.Students[i].Name < result.Students[j].Name
+	})
+
+	return result, nil
+}
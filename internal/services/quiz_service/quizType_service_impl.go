@@ -9,6 +9,8 @@ import (
 	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
 	"giat-cerika-service/internal/models"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"strings"
 	"time"
@@ -20,11 +22,12 @@ import (
 
 type QuizTypeServiceImpl struct {
 	qtRepo quizrepo.IQuizTypeRepository
+	audit  auditservice.IAuditService
 	rdb    *redis.Client
 }
 
-func NewQuizTypeServiceImpl(qtRepo quizrepo.IQuizTypeRepository, rdb *redis.Client) IQuizTypeService {
-	return &QuizTypeServiceImpl{qtRepo: qtRepo, rdb: rdb}
+func NewQuizTypeServiceImpl(qtRepo quizrepo.IQuizTypeRepository, auditService auditservice.IAuditService, rdb *redis.Client) IQuizTypeService {
+	return &QuizTypeServiceImpl{qtRepo: qtRepo, audit: auditService, rdb: rdb}
 }
 
 func (q *QuizTypeServiceImpl) invalideCacheQT(ctx context.Context) {
@@ -70,6 +73,7 @@ func (q *QuizTypeServiceImpl) CreateQt(ctx context.Context, req quizrequest.Crea
 	}
 
 	q.invalideCacheQT(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuizType, newQt.ID.String(), nil, newQt)
 
 	return nil
 
@@ -129,6 +133,7 @@ func (q *QuizTypeServiceImpl) UpdateQt(ctx context.Context, quizTypeId uuid.UUID
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz type", 500)
 	}
+	before := *qt
 	if req.Name != "" {
 		qt.Name = req.Name
 	}
@@ -140,13 +145,14 @@ func (q *QuizTypeServiceImpl) UpdateQt(ctx context.Context, quizTypeId uuid.UUID
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update quiz type", 500)
 	}
 	q.invalideCacheQT(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuizType, quizTypeId.String(), before, qt)
 
 	return nil
 }
 
 // DeleteQt implements IQuizTypeService.
 func (q *QuizTypeServiceImpl) DeleteQt(ctx context.Context, quizTypeId uuid.UUID) error {
-	_, err := q.qtRepo.FindById(ctx, quizTypeId)
+	qt, err := q.qtRepo.FindById(ctx, quizTypeId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz type not found", 404)
@@ -158,6 +164,7 @@ func (q *QuizTypeServiceImpl) DeleteQt(ctx context.Context, quizTypeId uuid.UUID
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete quiz type", 500)
 	}
 	q.invalideCacheQT(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuizType, quizTypeId.String(), qt, nil)
 
 	return nil
 }
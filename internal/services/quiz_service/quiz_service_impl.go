@@ -9,6 +9,8 @@ import (
 	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
 	"giat-cerika-service/internal/models"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"strings"
 	"time"
@@ -21,11 +23,12 @@ import (
 type QuizServiceImpl struct {
 	quizRepo quizrepo.IQuizRepository
 	qtRepo   quizrepo.IQuizTypeRepository
+	audit    auditservice.IAuditService
 	rdb      *redis.Client
 }
 
-func NewQuizServiceImpl(quizRepo quizrepo.IQuizRepository, qtRepo quizrepo.IQuizTypeRepository, rdb *redis.Client) IQuizService {
-	return &QuizServiceImpl{quizRepo: quizRepo, qtRepo: qtRepo, rdb: rdb}
+func NewQuizServiceImpl(quizRepo quizrepo.IQuizRepository, qtRepo quizrepo.IQuizTypeRepository, auditService auditservice.IAuditService, rdb *redis.Client) IQuizService {
+	return &QuizServiceImpl{quizRepo: quizRepo, qtRepo: qtRepo, audit: auditService, rdb: rdb}
 }
 
 func (q *QuizServiceImpl) invalidateCacheQuiz(ctx context.Context) {
@@ -83,6 +86,9 @@ func (q *QuizServiceImpl) CreateQuiz(ctx context.Context, req quizrequest.Create
 	if req.EndDate.Before(req.StartDate) {
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "end date must be after start date", 400)
 	}
+	if req.DurationMinutes < 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "duration minutes cannot be negative", 400)
+	}
 
 	startUTC := req.StartDate.UTC()
 	endUTC := req.EndDate.UTC()
@@ -163,6 +169,7 @@ func (q *QuizServiceImpl) CreateQuiz(ctx context.Context, req quizrequest.Create
 		Status:          0,
 		AmountQuestions: 0,
 		AmountAssigned:  0,
+		DurationMinutes: req.DurationMinutes,
 	}
 
 	err = q.quizRepo.Create(ctx, newQuiz)
@@ -170,6 +177,7 @@ func (q *QuizServiceImpl) CreateQuiz(ctx context.Context, req quizrequest.Create
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to save data", 500)
 	}
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionCreate, audit.EntityQuiz, newQuiz.ID.String(), nil, newQuiz)
 
 	return nil
 
@@ -237,6 +245,7 @@ func (q *QuizServiceImpl) UpdateQuiz(ctx context.Context, quizId uuid.UUID, req
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
 	}
+	before := *quiz
 
 	if req.QuizTypeId != uuid.Nil {
 		quiz.QuizTypeID = req.QuizTypeId
@@ -256,18 +265,25 @@ func (q *QuizServiceImpl) UpdateQuiz(ctx context.Context, quizId uuid.UUID, req
 	if !req.EndDate.IsZero() {
 		quiz.EndDate = req.EndDate
 	}
+	if req.DurationMinutes != nil {
+		if *req.DurationMinutes < 0 {
+			return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "duration minutes cannot be negative", 400)
+		}
+		quiz.DurationMinutes = *req.DurationMinutes
+	}
 	err = q.quizRepo.Update(ctx, quizId, quiz)
 	if err != nil {
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update quiz", 500)
 	}
 	q.invalidateCacheQuiz(ctx)
 	q.invalidateCacheQuestion(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuiz, quizId.String(), before, quiz)
 	return nil
 }
 
 // DeleteQuiz implements IQuizService.
 func (q *QuizServiceImpl) DeleteQuiz(ctx context.Context, quizId uuid.UUID) error {
-	_, err := q.quizRepo.FindById(ctx, quizId)
+	quiz, err := q.quizRepo.FindById(ctx, quizId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
@@ -279,6 +295,7 @@ func (q *QuizServiceImpl) DeleteQuiz(ctx context.Context, quizId uuid.UUID) erro
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete quiz", 500)
 	}
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionDelete, audit.EntityQuiz, quizId.String(), quiz, nil)
 	return nil
 }
 
@@ -299,6 +316,7 @@ func (q *QuizServiceImpl) UpdateStatusQuiz(ctx context.Context, quizId uuid.UUID
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update quiz status", 500)
 	}
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionUpdateStatus, audit.EntityQuiz, quizId.String(), map[string]any{"status": quiz.Status}, map[string]any{"status": req.Status})
 	return nil
 }
 
@@ -316,6 +334,82 @@ func (q *QuizServiceImpl) UpdateQuestionOrderMode(ctx context.Context, quizId uu
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update question order mode", 500)
 	}
 	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuiz, quizId.String(), map[string]any{"question_order_mode": quiz.QuestionOrderMode}, map[string]any{"question_order_mode": req.QuestionOrderMode})
+	return nil
+}
+
+// UpdateCorrectAnswerVisibility implements [IQuizService].
+func (q *QuizServiceImpl) UpdateCorrectAnswerVisibility(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateCorrectAnswerVisibilityRequest) error {
+	visibility := models.CorrectAnswerVisibility(strings.ToLower(strings.TrimSpace(req.CorrectAnswerVisibility)))
+	switch visibility {
+	case models.CorrectAnswerAfterSubmit, models.CorrectAnswerAfterClose, models.CorrectAnswerNever:
+	default:
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "correct_answer_visibility must be after_submit, after_close or never", 400)
+	}
+
+	quiz, err := q.quizRepo.FindById(ctx, quizId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+	if err := q.quizRepo.UpdateCorrectAnswerVisibility(ctx, quiz.ID, string(visibility)); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update correct answer visibility", 500)
+	}
+	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuiz, quizId.String(), map[string]any{"correct_answer_visibility": quiz.CorrectAnswerVisibility}, map[string]any{"correct_answer_visibility": visibility})
+	return nil
+}
+
+// UpdateShuffleAnswers implements [IQuizService].
+func (q *QuizServiceImpl) UpdateShuffleAnswers(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateShuffleAnswersRequest) error {
+	quiz, err := q.quizRepo.FindById(ctx, quizId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+	if err := q.quizRepo.UpdateShuffleAnswers(ctx, quiz.ID, req.ShuffleAnswers); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update shuffle answers", 500)
+	}
+	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuiz, quizId.String(), map[string]any{"shuffle_answers": quiz.ShuffleAnswers}, map[string]any{"shuffle_answers": req.ShuffleAnswers})
+	return nil
+}
+
+// UpdateQuestionPool implements [IQuizService].
+// Sesi yang sudah diundi tetap memakai subset lamanya; pengaturan baru berlaku untuk sesi berikutnya.
+func (q *QuizServiceImpl) UpdateQuestionPool(ctx context.Context, quizId uuid.UUID, req quizrequest.UpdateQuestionPoolRequest) error {
+	if req.DrawCount < 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "draw_count cannot be negative", 400)
+	}
+	stratifyBy := models.QuestionPoolStratify(strings.ToLower(strings.TrimSpace(req.DrawStratifyBy)))
+	switch stratifyBy {
+	case models.QuestionPoolStratifyNone, models.QuestionPoolStratifyTag, models.QuestionPoolStratifyDifficulty:
+	default:
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "draw_stratify_by must be empty, tag or difficulty", 400)
+	}
+
+	quiz, err := q.quizRepo.FindById(ctx, quizId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+	if req.DrawCount > len(quiz.Questions) {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "draw_count cannot exceed the number of questions", 400)
+	}
+	if err := q.quizRepo.UpdateQuestionPool(ctx, quiz.ID, req.DrawCount, string(stratifyBy)); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update question pool", 500)
+	}
+	q.invalidateCacheQuiz(ctx)
+	q.audit.Record(ctx, audit.ActionUpdate, audit.EntityQuiz, quizId.String(),
+		map[string]any{"draw_count": quiz.DrawCount, "draw_stratify_by": quiz.DrawStratifyBy},
+		map[string]any{"draw_count": req.DrawCount, "draw_stratify_by": stratifyBy},
+	)
 	return nil
 }
 
@@ -0,0 +1,34 @@
+package quizsessionservice
+
+import (
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// orderAnswersForSession mengembalikan opsi dalam urutan yang dilihat siswa pada sesi ini.
+// Bila shuffle aktif, permutasi di-seed dari id sesi + id soal sehingga daftar soal,
+// resume dan snapshot riwayat selalu menghasilkan urutan yang sama.
+func orderAnswersForSession(answers []models.Answer, sessionId uuid.UUID, questionId uuid.UUID, shuffle bool) []models.Answer {
+	if !shuffle || len(answers) < 2 {
+		return answers
+	}
+
+	base := make([]models.Answer, len(answers))
+	copy(base, answers)
+	sort.SliceStable(base, func(i, j int) bool {
+		if !base[i].CreatedAt.Equal(base[j].CreatedAt) {
+			return base[i].CreatedAt.Before(base[j].CreatedAt)
+		}
+		return base[i].ID.String() < base[j].ID.String()
+	})
+
+	rng := utils.SeededRand(sessionId, "answers:"+questionId.String())
+	ordered := make([]models.Answer, len(base))
+	for i, idx := range rng.Perm(len(base)) {
+		ordered[i] = base[idx]
+	}
+	return ordered
+}
@@ -0,0 +1,28 @@
+package quizsessionservice
+
+import "time"
+
+// submitGracePeriod memberi toleransi latensi jaringan untuk submit yang dikirim tepat saat waktu habis.
+const submitGracePeriod = 30 * time.Second
+
+// sessionDeadline menghitung batas waktu satu percobaan: min(startedAt + durasi, end).
+// Tanpa durasi, deadline = end (perilaku lama); nil berarti tanpa batas waktu.
+func sessionDeadline(durationMinutes int, startedAt time.Time, end time.Time, windowUnlimited bool) *time.Time {
+	var deadline *time.Time
+	if !windowUnlimited {
+		windowEnd := end
+		deadline = &windowEnd
+	}
+	if durationMinutes > 0 {
+		attemptEnd := startedAt.Add(time.Duration(durationMinutes) * time.Minute)
+		if deadline == nil || attemptEnd.Before(*deadline) {
+			deadline = &attemptEnd
+		}
+	}
+	return deadline
+}
+
+// deadlinePassed bernilai true bila submit datang setelah deadline tersimpan ditambah masa toleransi.
+func deadlinePassed(deadline *time.Time, now time.Time) bool {
+	return deadline != nil && now.After(deadline.Add(submitGracePeriod))
+}
@@ -13,7 +13,7 @@ type IQuizSessionService interface {
 	AssignCodeQuiz(ctx context.Context, userId uuid.UUID, quizId uuid.UUID, code string) (*models.QuizSession, error)
 	StartQuizSession(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID) (*quizsessionresponse.QuizSessionStartResponse, error)
 	GetQuizSessionDuration(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID) (*quizsessionresponse.QuizSessionDurationResponse, error)
-	SubmtiQuizSession(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID, req quizrequest.SubmitQuizRequest) error
+	SubmtiQuizSession(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID, req quizrequest.SubmitQuizRequest) (*quizsessionresponse.QuizSessionSubmitResponse, error)
 	GetOrderedQuizQuestions(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID) (*quizsessionresponse.OrderedQuizQuestionsResponse, error)
 	GetQuizSessionStudentByQuiz(ctx context.Context) ([]quizsessionresponse.ListQuestionSessionResponse, error)
 }
@@ -0,0 +1,141 @@
+package quizsessionservice
+
+import (
+	"context"
+	"errors"
+	"giat-cerika-service/internal/models"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// drawQuestionIDs mengundi quiz.DrawCount soal dari bank soal untuk satu sesi.
+// Undian di-seed dari id sesi sehingga hasilnya bisa direproduksi. Bila DrawStratifyBy
+// diisi, jatah tiap strata proporsional terhadap ukurannya (metode sisa terbesar).
+// Urutan hasil mengikuti urutan bank soal (created_at).
+func drawQuestionIDs(quiz *models.Quiz, sessionId uuid.UUID) []string {
+	questions := make([]models.Question, len(quiz.Questions))
+	copy(questions, quiz.Questions)
+	sort.SliceStable(questions, func(i, j int) bool {
+		if !questions[i].CreatedAt.Equal(questions[j].CreatedAt) {
+			return questions[i].CreatedAt.Before(questions[j].CreatedAt)
+		}
+		return questions[i].ID.String() < questions[j].ID.String()
+	})
+
+	total := len(questions)
+	drawCount := quiz.DrawCount
+	if drawCount > total {
+		drawCount = total
+	}
+
+	// kelompokkan per strata; tanpa stratifikasi semua soal masuk satu strata
+	strata := make(map[string][]models.Question)
+	var keys []string
+	for _, question := range questions {
+		key := ""
+		switch quiz.DrawStratifyBy {
+		case models.QuestionPoolStratifyTag:
+			key = question.Tag
+		case models.QuestionPoolStratifyDifficulty:
+			key = string(question.Difficulty)
+		}
+		if _, ok := strata[key]; !ok {
+			keys = append(keys, key)
+		}
+		strata[key] = append(strata[key], question)
+	}
+	sort.Strings(keys)
+
+	type allocation struct {
+		key       string
+		take      int
+		remainder float64
+	}
+	allocations := make([]allocation, len(keys))
+	allocated := 0
+	for i, key := range keys {
+		exact := float64(drawCount) * float64(len(strata[key])) / float64(total)
+		allocations[i] = allocation{key: key, take: int(exact), remainder: exact - float64(int(exact))}
+		allocated += allocations[i].take
+	}
+	sort.SliceStable(allocations, func(i, j int) bool {
+		return allocations[i].remainder > allocations[j].remainder
+	})
+	for i := 0; allocated < drawCount; i = (i + 1) % len(allocations) {
+		if allocations[i].take < len(strata[allocations[i].key]) {
+			allocations[i].take++
+			allocated++
+		}
+	}
+	sort.SliceStable(allocations, func(i, j int) bool {
+		return allocations[i].key < allocations[j].key
+	})
+
+	rng := utils.SeededRand(sessionId, "question_pool")
+	drawn := make(map[uuid.UUID]bool, drawCount)
+	for _, alloc := range allocations {
+		pool := strata[alloc.key]
+		for _, idx := range rng.Perm(len(pool))[:alloc.take] {
+			drawn[pool[idx].ID] = true
+		}
+	}
+
+	ids := make([]string, 0, drawCount)
+	for _, question := range questions {
+		if drawn[question.ID] {
+			ids = append(ids, question.ID.String())
+		}
+	}
+	return ids
+}
+
+// filterDrawnQuestions menyaring soal ke subset undian sesi dengan mempertahankan urutan asal.
+// drawnIds kosong berarti seluruh soal dipakai.
+func filterDrawnQuestions(questions []models.Question, drawnIds []string) []models.Question {
+	if len(drawnIds) == 0 {
+		return questions
+	}
+	drawn := make(map[string]bool, len(drawnIds))
+	for _, id := range drawnIds {
+		drawn[id] = true
+	}
+	filtered := make([]models.Question, 0, len(drawnIds))
+	for _, question := range questions {
+		if drawn[question.ID.String()] {
+			filtered = append(filtered, question)
+		}
+	}
+	return filtered
+}
+
+// ensureDrawnQuestions mengembalikan subset soal sesi; undian dilakukan sekali lalu
+// disimpan di quiz session agar resume, submit dan riwayat memakai soal yang sama.
+func (q *QuizSessionServiceImpl) ensureDrawnQuestions(ctx context.Context, quiz *models.Quiz, quizSession *models.QuizSession) ([]string, error) {
+	if len(quizSession.DrawnQuestionIDs) > 0 || quiz.DrawCount <= 0 || len(quiz.Questions) == 0 {
+		return quizSession.DrawnQuestionIDs, nil
+	}
+
+	drawnIds := drawQuestionIDs(quiz, quizSession.ID)
+	saved, err := q.quizSessionRepo.SaveDrawnQuestions(ctx, quizSession.ID, drawnIds)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to draw quiz questions", 500)
+	}
+	if !saved {
+		// sudah diundi oleh request lain; pakai yang tersimpan
+		existing, err := q.quizSessionRepo.FindById(ctx, quizSession.UserID, quizSession.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz session not found", 404)
+			}
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz session", 500)
+		}
+		drawnIds = existing.DrawnQuestionIDs
+	}
+
+	quizSession.DrawnQuestionIDs = drawnIds
+	return drawnIds, nil
+}
@@ -0,0 +1,155 @@
+package quizsessionservice
+
+import (
+	"encoding/json"
+	quizrequest "giat-cerika-service/internal/dto/request/quiz_request"
+	"giat-cerika-service/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// questionScore adalah hasil penilaian satu soal beserta detail yang di-snapshot ke history.
+type questionScore struct {
+	answerID       *uuid.UUID
+	answerDetail   json.RawMessage
+	submittedText  string
+	selected       map[uuid.UUID]bool
+	submittedMatch map[uuid.UUID]string
+	answerEarned   map[uuid.UUID]int
+	scoreEarned    int
+	maxScore       int
+}
+
+// normalizeAnswerText menyamakan huruf besar/kecil dan spasi untuk short answer & matching.
+func normalizeAnswerText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func questionMaxScore(question models.Question) int {
+	scoreValues := make([]int, len(question.Answers))
+	for i, answer := range question.Answers {
+		scoreValues[i] = answer.ScoreValue
+	}
+	return question.QuestionType.MaxScore(scoreValues)
+}
+
+// multiSelectPenalty adalah pengurangan untuk memilih opsi salah yang score_value-nya 0.
+// Besarnya maxScore dibagi jumlah opsi salah tersebut (dibulatkan ke atas), sehingga
+// mencentang semua opsi tidak pernah mendapat nilai penuh. Opsi bernilai negatif memakai nilainya sendiri.
+func multiSelectPenalty(question models.Question, maxScore int) int {
+	zeroWrong := 0
+	for _, answer := range question.Answers {
+		if answer.ScoreValue == 0 {
+			zeroWrong++
+		}
+	}
+	if zeroWrong == 0 {
+		return 0
+	}
+	return (maxScore + zeroWrong - 1) / zeroWrong
+}
+
+// scoreQuestion menilai jawaban siswa untuk satu soal sesuai tipenya.
+// Hanya opsi milik soal itu yang dihitung, sehingga answer_id dari soal lain bernilai 0.
+func scoreQuestion(question models.Question, sub *quizrequest.SubmitAnswerRequest) questionScore {
+	result := questionScore{
+		selected:       make(map[uuid.UUID]bool),
+		submittedMatch: make(map[uuid.UUID]string),
+		answerEarned:   make(map[uuid.UUID]int),
+		maxScore:       questionMaxScore(question),
+	}
+	if sub == nil {
+		return result
+	}
+
+	answerMap := make(map[uuid.UUID]models.Answer, len(question.Answers))
+	for _, answer := range question.Answers {
+		answerMap[answer.ID] = answer
+	}
+
+	switch question.QuestionType {
+	case models.QuestionMultiSelect:
+		penalty := multiSelectPenalty(question, result.maxScore)
+		answerIds := make([]uuid.UUID, 0, len(sub.AnswerIDs))
+		for _, answerId := range sub.AnswerIDs {
+			answer, ok := answerMap[answerId]
+			if !ok || result.selected[answerId] {
+				continue
+			}
+			earned := answer.ScoreValue
+			if earned == 0 {
+				earned = -penalty
+			}
+			result.selected[answerId] = true
+			result.answerEarned[answerId] = earned
+			result.scoreEarned += earned
+			answerIds = append(answerIds, answerId)
+		}
+		if result.scoreEarned < 0 {
+			result.scoreEarned = 0
+		}
+		if result.scoreEarned > result.maxScore {
+			result.scoreEarned = result.maxScore
+		}
+		if len(answerIds) > 0 {
+			result.answerDetail, _ = json.Marshal(map[string]any{"answer_ids": answerIds})
+		}
+
+	case models.QuestionShortAnswer:
+		text := strings.TrimSpace(sub.TextAnswer)
+		if text == "" {
+			return result
+		}
+		result.submittedText = text
+		normalized := normalizeAnswerText(text)
+		var matched *models.Answer
+		for i, answer := range question.Answers {
+			if normalizeAnswerText(answer.AnswerText) != normalized {
+				continue
+			}
+			if matched == nil || answer.ScoreValue > matched.ScoreValue {
+				matched = &question.Answers[i]
+			}
+		}
+		if matched != nil {
+			result.selected[matched.ID] = true
+			result.answerEarned[matched.ID] = matched.ScoreValue
+			result.scoreEarned = matched.ScoreValue
+		}
+		result.answerDetail, _ = json.Marshal(map[string]any{"text": text})
+
+	case models.QuestionMatching:
+		pairs := make([]quizrequest.SubmitMatchPairRequest, 0, len(sub.Pairs))
+		for _, pair := range sub.Pairs {
+			answer, ok := answerMap[pair.AnswerID]
+			if !ok || result.selected[pair.AnswerID] || strings.TrimSpace(pair.MatchText) == "" {
+				continue
+			}
+			result.selected[pair.AnswerID] = true
+			result.submittedMatch[pair.AnswerID] = pair.MatchText
+			if normalizeAnswerText(pair.MatchText) == normalizeAnswerText(answer.MatchText) && answer.ScoreValue > 0 {
+				result.answerEarned[pair.AnswerID] = answer.ScoreValue
+				result.scoreEarned += answer.ScoreValue
+			}
+			pairs = append(pairs, pair)
+		}
+		if len(pairs) > 0 {
+			result.answerDetail, _ = json.Marshal(map[string]any{"pairs": pairs})
+		}
+
+	default:
+		// single choice & true/false
+		answer, ok := answerMap[sub.AnswerID]
+		if !ok {
+			return result
+		}
+		answerId := answer.ID
+		result.answerID = &answerId
+		result.selected[answerId] = true
+		result.answerEarned[answerId] = answer.ScoreValue
+		result.scoreEarned = answer.ScoreValue
+	}
+
+	return result
+}
@@ -12,6 +12,7 @@ import (
 	quizsessionrepo "giat-cerika-service/internal/repositories/quiz_session_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
 	"strings"
 	"time"
 
@@ -256,64 +257,63 @@ func (q *QuizSessionServiceImpl) StartQuizSession(
 	}
 
 	// =========================
-	// DURASI & END TIME (FIXED)
+	// DURASI & DEADLINE
 	// =========================
+	// Deadline disimpan di quiz session; Redis hanya cache sehingga flush Redis
+	// tidak mereset timer.
 	var durationSeconds int64
 	var isUnlimited bool
 	var endTime *time.Time
 
 	redisKey := fmt.Sprintf("quiz_session:%s:duration", quizSession.ID.String())
+	windowUnlimited := start.IsZero() || end.IsZero() || start.Equal(end)
+
+	deadline := quizSession.Deadline
+	if deadline == nil {
+		// sesi yang sudah berjalan sebelum deadline disimpan dihitung dari started_at aslinya
+		startedAt := now
+		if quizSession.StartedAt != nil {
+			startedAt = *quizSession.StartedAt
+		}
+		deadline = sessionDeadline(quiz.DurationMinutes, startedAt, end, windowUnlimited)
+	}
 
-	if start.IsZero() || end.IsZero() || start.Equal(end) {
+	if deadline == nil {
 		// Unlimited quiz
 		isUnlimited = true
 		durationSeconds = 0
 	} else {
-		existingTTL := q.rdb.TTL(ctx, redisKey).Val()
-
-		if existingTTL > 0 {
-			// Resume quiz
-			durationSeconds = int64(existingTTL.Seconds())
-
-			calculatedEndTime := now.Add(existingTTL)
-			endTime = &calculatedEndTime
-
-			isUnlimited = false
-		} else {
-			// 🔥 FIRST START → HITUNG SISA WAKTU
-			remaining := end.Sub(now)
-
-			// ❌ Sudah lewat end time
-			if remaining <= 0 {
-				return nil, errorresponse.NewCustomError(
-					errorresponse.ErrBadRequest,
-					"quiz time has ended",
-					400,
-				)
-			}
+		remaining := deadline.Sub(now)
 
-			durationSeconds = int64(remaining.Seconds())
+		// ❌ Sudah lewat deadline
+		if remaining <= 0 {
+			return nil, errorresponse.NewCustomError(
+				errorresponse.ErrBadRequest,
+				"quiz time has ended",
+				400,
+			)
+		}
 
-			calculatedEndTime := now.Add(remaining)
-			endTime = &calculatedEndTime
+		durationSeconds = int64(remaining.Seconds())
+		calculatedEndTime := deadline.In(locJakarta)
+		endTime = &calculatedEndTime
+		isUnlimited = false
 
-			isUnlimited = false
+		_ = q.rdb.Set(ctx, redisKey, durationSeconds, remaining).Err()
+	}
 
-			// Simpan sisa waktu ke Redis
-			_ = q.rdb.Set(
-				ctx,
-				redisKey,
-				durationSeconds,
-				time.Duration(durationSeconds)*time.Second,
-			).Err()
-		}
+	// =========================
+	// UNDI BANK SOAL (sekali per sesi)
+	// =========================
+	if _, err := q.ensureDrawnQuestions(ctx, quiz, quizSession); err != nil {
+		return nil, err
 	}
 
 	// =========================
-	// UPDATE STARTED AT
+	// UPDATE STARTED AT & DEADLINE
 	// =========================
 	if quizSession.Status == models.SessionStatusStarted {
-		err = q.quizSessionRepo.CreateStartedAt(ctx, quizSession.ID)
+		err = q.quizSessionRepo.CreateStartedAt(ctx, quizSession.ID, now, deadline)
 		if err != nil {
 			return nil, errorresponse.NewCustomError(
 				errorresponse.ErrInternal,
@@ -321,6 +321,14 @@ func (q *QuizSessionServiceImpl) StartQuizSession(
 				500,
 			)
 		}
+	} else if quizSession.Deadline == nil && deadline != nil {
+		if err := q.quizSessionRepo.SaveDeadline(ctx, quizSession.ID, *deadline); err != nil {
+			return nil, errorresponse.NewCustomError(
+				errorresponse.ErrInternal,
+				"failed to save quiz deadline",
+				500,
+			)
+		}
 	}
 
 	// =========================
@@ -367,6 +375,23 @@ func (q *QuizSessionServiceImpl) GetQuizSessionDuration(ctx context.Context, use
 		}, nil
 	}
 
+	// Deadline tersimpan menjadi sumber utama sisa waktu (tahan terhadap flush Redis)
+	if quizSession.Deadline != nil {
+		remaining := time.Until(*quizSession.Deadline)
+		if remaining <= 0 {
+			return &quizsessionresponse.QuizSessionDurationResponse{
+				RemainingSeconds: 0,
+				IsExpired:        true,
+				IsUnlimited:      false,
+			}, nil
+		}
+		return &quizsessionresponse.QuizSessionDurationResponse{
+			RemainingSeconds: int64(remaining.Seconds()),
+			IsExpired:        false,
+			IsUnlimited:      false,
+		}, nil
+	}
+
 	// Get quiz detail untuk cek apakah unlimited
 	quiz, err := q.quizRepo.FindById(ctx, quizSession.QuizID)
 	if err != nil {
@@ -376,8 +401,8 @@ func (q *QuizSessionServiceImpl) GetQuizSessionDuration(ctx context.Context, use
 	// Cek apakah quiz memang unlimited dari awal
 	isQuizUnlimited := quiz.StartDate.IsZero() || quiz.EndDate.IsZero() || quiz.StartDate.Equal(quiz.EndDate)
 
-	// Jika quiz memang unlimited
-	if isQuizUnlimited {
+	// Jika quiz memang unlimited (tanpa batas per percobaan)
+	if isQuizUnlimited && quiz.DurationMinutes == 0 {
 		return &quizsessionresponse.QuizSessionDurationResponse{
 			RemainingSeconds: 0,
 			IsExpired:        false,
@@ -407,6 +432,12 @@ func (q *QuizSessionServiceImpl) GetQuizSessionDuration(ctx context.Context, use
 
 	// Jika belum pernah di-start, hitung durasi dari quiz
 	duration := quiz.EndDate.Sub(quiz.StartDate)
+	if quiz.DurationMinutes > 0 {
+		attempt := time.Duration(quiz.DurationMinutes) * time.Minute
+		if isQuizUnlimited || attempt < duration {
+			duration = attempt
+		}
+	}
 	return &quizsessionresponse.QuizSessionDurationResponse{
 		RemainingSeconds: int64(duration.Seconds()),
 		IsExpired:        false,
@@ -418,94 +449,84 @@ func (q *QuizSessionServiceImpl) GetQuizSessionDuration(ctx context.Context, use
 // Seluruh operasi submit (simpan jawaban + complete session + simpan history)
 // dikerjakan dalam SATU transaksi database.
 // Jika terjadi error / timeout di tengah jalan, seluruh data di-ROLLBACK.
-func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID, req quizrequest.SubmitQuizRequest) error {
+func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId uuid.UUID, quizSessionId uuid.UUID, req quizrequest.SubmitQuizRequest) (*quizsessionresponse.QuizSessionSubmitResponse, error) {
 	student, err := q.studentRepo.FindByStudentID(ctx, userId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
 		}
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
 	}
 	quizSession, err := q.quizSessionRepo.FindById(ctx, student.ID, quizSessionId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz session not found", 404)
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz session not found", 404)
 		}
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz session", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz session", 500)
 	}
 	if quizSession.Status == models.SessionStatusCompleted {
 		// 💡 IDEMPOTENCY FIX: Jika quiz session sudah completed (misal karena submit pertama
 		// sukses di database tetapi respon HTTP ke aplikasi mobile terputus koneksi),
-		// kembalikan nil (HTTP 200 OK) agar retry submit dari aplikasi mobile tetap dianggap SUKSES
-		// tanpa menampilkan popup error / gagal.
-		return nil
+		// kembalikan hasil yang sama (HTTP 200 OK) agar retry submit dari aplikasi mobile tetap dianggap SUKSES
+		// tanpa menampilkan popup error / gagal. Status terlambat diturunkan ulang dari completed_at.
+		late := quizSession.CompletedAt != nil && deadlinePassed(quizSession.Deadline, *quizSession.CompletedAt)
+		return &quizsessionresponse.QuizSessionSubmitResponse{QuizSessionID: quizSession.ID, Late: late, Scored: !late}, nil
 	}
 
 	quiz, err := q.quizRepo.FindById(ctx, quizSession.QuizID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "quiz not found", 404)
 		}
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get quiz", 500)
+	}
+
+	// Submit setelah deadline: percobaan tetap ditutup (agar tidak menggantung sebagai
+	// in_progress) tetapi jawaban yang terlambat tidak dinilai. completed_at dipakai juga
+	// untuk menurunkan status terlambat saat retry.
+	completedAt := time.Now()
+	late := deadlinePassed(quizSession.Deadline, completedAt)
+
+	// skor & max score hanya dihitung dari subset undian bank soal (bila ada)
+	drawnIds, err := q.ensureDrawnQuestions(ctx, quiz, quizSession)
+	if err != nil {
+		return nil, err
 	}
+	questions := filterDrawnQuestions(quiz.Questions, drawnIds)
 
-	totalQuestions := len(quiz.Questions)
+	totalQuestions := len(questions)
 	if totalQuestions == 0 {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "quiz has no question", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "quiz has no question", 500)
 	}
 
-	// ── Hitung score ──
+	// ── Hitung score (per tipe soal) ──
 	var responseToSave []*models.Response
 	var totalScore int
 	var maxScore int
 
-	submittedAnswers := make(map[uuid.UUID]uuid.UUID, len(req.Answers))
-	for _, sub := range req.Answers {
-		submittedAnswers[sub.QuestionID] = sub.AnswerID
-	}
-
-	answerScoreMap := make(map[uuid.UUID]int)
-	for _, question := range quiz.Questions {
-		qID := question.ID
-		currentQuestionMaxScore := 0
-
-		for _, answer := range question.Answers {
-			if answer.ScoreValue > currentQuestionMaxScore {
-				currentQuestionMaxScore = answer.ScoreValue
-			}
-			answerScoreMap[answer.ID] = answer.ScoreValue
+	submittedAnswers := make(map[uuid.UUID]*quizrequest.SubmitAnswerRequest, len(req.Answers))
+	if !late {
+		for i := range req.Answers {
+			submittedAnswers[req.Answers[i].QuestionID] = &req.Answers[i]
 		}
+	}
 
-		maxScore += currentQuestionMaxScore
-
-		answerID, submitted := submittedAnswers[qID]
-		scoreEarned := 0
-
-		isAnswerSubmitted := submitted && answerID != uuid.Nil
+	questionScores := make(map[uuid.UUID]questionScore, totalQuestions)
+	for _, question := range questions {
+		result := scoreQuestion(question, submittedAnswers[question.ID])
+		questionScores[question.ID] = result
 
-		if isAnswerSubmitted {
-			scoreEarned = answerScoreMap[answerID]
-			totalScore += scoreEarned
+		maxScore += result.maxScore
+		totalScore += result.scoreEarned
 
-			response := &models.Response{
-				ID:            uuid.New(),
-				QuizSessionID: quizSessionId,
-				QuestionID:    qID,
-				AnswerID:      &answerID, // AnswerID disimpan (bukan nil)
-				ScoreEarned:   scoreEarned,
-			}
-
-			responseToSave = append(responseToSave, response)
-		} else {
-			response := &models.Response{
-				ID:            uuid.New(),
-				QuizSessionID: quizSessionId,
-				QuestionID:    qID,
-				AnswerID:      nil, // AnswerID tetap nil
-				ScoreEarned:   0,
-			}
-			responseToSave = append(responseToSave, response)
-		}
+		responseToSave = append(responseToSave, &models.Response{
+			ID:            uuid.New(),
+			QuizSessionID: quizSessionId,
+			QuestionID:    question.ID,
+			AnswerID:      result.answerID, // nil bila tidak dijawab / bukan pilihan tunggal
+			AnswerDetail:  result.answerDetail,
+			ScoreEarned:   result.scoreEarned,
+		})
 	}
 
 	// ── Hapus timer Redis ──
@@ -527,8 +548,6 @@ func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId u
 		quizHistoryStatus = 1
 	}
 
-	completedAt := time.Now()
-
 	startedAtTime := time.Time{}
 	if quizSession.StartedAt != nil {
 		startedAtTime = *quizSession.StartedAt
@@ -544,7 +563,7 @@ func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId u
 		Description:     quiz.Description,
 		StartDate:       &quiz.StartDate,
 		EndDate:         &quiz.EndDate,
-		AmountQuestions: quiz.AmountQuestions,
+		AmountQuestions: totalQuestions,
 		AmountAssigned:  quiz.AmountAssigned,
 		UserID:          quizSession.UserID,
 		Score:           totalScore,
@@ -554,26 +573,16 @@ func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId u
 		CompletedAt:     &completedAt,
 		Status:          models.SessionStatusCompleted,
 		StatusCategory:  quizHistoryStatus,
+
+		CorrectAnswerVisibility: quiz.CorrectAnswerVisibility,
 	}
 
 	var questionHistories []models.QuestionHistory
 	var answerHistories []models.AnswerHistory
 
-	responseAnswerIDMap := make(map[uuid.UUID]uuid.UUID)
-	responseScoreMap := make(map[uuid.UUID]int)
-
-	for _, r := range responseToSave {
-		if r.AnswerID != nil {
-			responseAnswerIDMap[r.QuestionID] = *r.AnswerID
-			responseScoreMap[r.QuestionID] = r.ScoreEarned
-		} else {
-			responseAnswerIDMap[r.QuestionID] = uuid.Nil
-			responseScoreMap[r.QuestionID] = 0
-		}
-	}
-
-	for _, question := range quiz.Questions {
+	for _, question := range questions {
 		qHistID := uuid.New()
+		result := questionScores[question.ID]
 
 		qHistory := models.QuestionHistory{
 			ID:            qHistID,
@@ -581,26 +590,27 @@ func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId u
 			QuestionID:    question.ID,
 			QuestionText:  question.QuestionText,
 			QuestionImage: question.QuestionImage,
+			QuestionType:  question.QuestionType,
+			SubmittedText: result.submittedText,
+			ScoreEarned:   result.scoreEarned,
+			MaxScore:      result.maxScore,
 		}
 
 		questionHistories = append(questionHistories, qHistory)
 
-		submittedAnswerID := responseAnswerIDMap[question.ID]
-
-		for _, answer := range question.Answers {
-			scoreEarned := 0
-
-			if answer.ID == submittedAnswerID && submittedAnswerID != uuid.Nil {
-				scoreEarned = responseScoreMap[question.ID]
-			}
-
+		// snapshot mengikuti urutan opsi yang dilihat siswa pada sesi ini
+		for order, answer := range orderAnswersForSession(question.Answers, quizSession.ID, question.ID, quiz.ShuffleAnswers) {
 			aHistory := models.AnswerHistory{
 				ID:                uuid.New(),
 				QuestionHistoryID: qHistID,
 				AnswerID:          answer.ID,
 				AnswerText:        answer.AnswerText,
 				ScoreValue:        answer.ScoreValue,
-				ScoreEarned:       scoreEarned,
+				ScoreEarned:       result.answerEarned[answer.ID],
+				Selected:          result.selected[answer.ID],
+				MatchText:         answer.MatchText,
+				SubmittedMatch:    result.submittedMatch[answer.ID],
+				DisplayOrder:      order,
 			}
 
 			answerHistories = append(answerHistories, aHistory)
@@ -622,11 +632,11 @@ func (q *QuizSessionServiceImpl) SubmtiQuizSession(ctx context.Context, userId u
 		questionHistories,
 		answerHistories,
 	); err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to submit quiz", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to submit quiz", 500)
 	}
 
 	q.invalidateCacheQuiz(ctx)
-	return nil
+	return &quizsessionresponse.QuizSessionSubmitResponse{QuizSessionID: quizSession.ID, Late: late, Scored: !late}, nil
 }
 
 // GetOrderedQuizQuestions implements [IQuizSessionService].
@@ -652,9 +662,10 @@ func (q *QuizSessionServiceImpl) GetOrderedQuizQuestions(ctx context.Context, us
 	}
 
 	cacheKey := fmt.Sprintf(
-		"quiz_session:%s:ordered_questions:%s:user_id:%s",
+		"quiz_session:%s:ordered_questions:%s:shuffle_answers:%t:user_id:%s",
 		quizSessionId.String(),
 		tempQuiz.QuestionOrderMode,
+		tempQuiz.ShuffleAnswers,
 		quizSession.UserID,
 	)
 
@@ -665,27 +676,21 @@ func (q *QuizSessionServiceImpl) GetOrderedQuizQuestions(ctx context.Context, us
 		}
 	}
 
+	drawnIds, err := q.ensureDrawnQuestions(ctx, tempQuiz, quizSession)
+	if err != nil {
+		return nil, err
+	}
+
 	quiz, err := q.quizSessionRepo.FindQuizWithOrderedQuestions(ctx, quizSession.QuizID, string(tempQuiz.QuestionOrderMode))
 	if err != nil {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get ordered quiz questions", 500)
 	}
+	questions := filterDrawnQuestions(quiz.Questions, drawnIds)
 
-	var questionResponse []quizsessionresponse.QuestionDetailResponse
-	for _, question := range quiz.Questions {
-		var answerResponse []quizsessionresponse.AnswerResponse
-		for _, answer := range question.Answers {
-			answerResponse = append(answerResponse, quizsessionresponse.AnswerResponse{
-				ID:         answer.ID,
-				AnswerText: answer.AnswerText,
-				ScoreValue: answer.ScoreValue,
-			})
-		}
-		questionResponse = append(questionResponse, quizsessionresponse.QuestionDetailResponse{
-			ID:            question.ID,
-			QuestionText:  question.QuestionText,
-			QuestionImage: &question.QuestionImage,
-			Answers:       answerResponse,
-		})
+	questionResponse := make([]quizsessionresponse.StudentQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		question.Answers = orderAnswersForSession(question.Answers, quizSession.ID, question.ID, tempQuiz.ShuffleAnswers)
+		questionResponse = append(questionResponse, quizsessionresponse.ToStudentQuestionResponse(question))
 	}
 
 	response := &quizsessionresponse.OrderedQuizQuestionsResponse{
@@ -702,7 +707,7 @@ func (q *QuizSessionServiceImpl) GetOrderedQuizQuestions(ctx context.Context, us
 
 // GetQuizSessionStudentByQuiz implements [IQuizSessionService].
 func (q *QuizSessionServiceImpl) GetQuizSessionStudentByQuiz(ctx context.Context) ([]quizsessionresponse.ListQuestionSessionResponse, error) {
-	sessions, err := q.quizSessionRepo.FindQuizSessionByQuiz(ctx)
+	sessions, err := q.quizSessionRepo.FindQuizSessionByQuiz(ctx, utils.TeacherScopeFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -9,7 +9,10 @@ import (
 	rolerequest "giat-cerika-service/internal/dto/request/role_request"
 	"giat-cerika-service/internal/models"
 	rolerepo "giat-cerika-service/internal/repositories/role_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/constant/permission"
 	"strings"
 	"time"
 
@@ -20,11 +23,12 @@ import (
 
 type RoleServiceImpl struct {
 	roleRepo rolerepo.IRoleRepository
+	audit    auditservice.IAuditService
 	rdb      *redis.Client
 }
 
-func NewRoleServiceImpl(roleRepo rolerepo.IRoleRepository, rdb *redis.Client) IRoleService {
-	return &RoleServiceImpl{roleRepo: roleRepo, rdb: rdb}
+func NewRoleServiceImpl(roleRepo rolerepo.IRoleRepository, auditService auditservice.IAuditService, rdb *redis.Client) IRoleService {
+	return &RoleServiceImpl{roleRepo: roleRepo, audit: auditService, rdb: rdb}
 }
 
 func (r *RoleServiceImpl) invalidateCacheRole(ctx context.Context) {
@@ -37,6 +41,12 @@ func (r *RoleServiceImpl) invalidateCacheRole(ctx context.Context) {
 	for iterID.Next(ctx) {
 		r.rdb.Del(ctx, iterID.Val())
 	}
+
+	// cache permission disimpan per nama role, jadi ikut dibersihkan saat role diubah/dihapus
+	iterPerm := r.rdb.Scan(ctx, 0, permission.CacheKeyPattern, 0).Iterator()
+	for iterPerm.Next(ctx) {
+		r.rdb.Del(ctx, iterPerm.Val())
+	}
 }
 
 // CreateRole implements IRoleService.
@@ -65,6 +75,7 @@ func (r *RoleServiceImpl) CreateRole(ctx context.Context, req rolerequest.Create
 	}
 
 	r.invalidateCacheRole(ctx)
+	r.audit.Record(ctx, audit.ActionCreate, audit.EntityRole, newRole.ID.String(), nil, newRole)
 
 	return nil
 }
@@ -133,6 +144,7 @@ func (r *RoleServiceImpl) UpdateRole(ctx context.Context, roleId uuid.UUID, req
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "role not found", 500)
 	}
+	before := *role
 
 	existsRole, err := r.roleRepo.FindByName(ctx, req.Name)
 	if err == nil && existsRole.ID != roleId {
@@ -148,13 +160,14 @@ func (r *RoleServiceImpl) UpdateRole(ctx context.Context, roleId uuid.UUID, req
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "Failed to update role", 500)
 	}
 	r.invalidateCacheRole(ctx)
+	r.audit.Record(ctx, audit.ActionUpdate, audit.EntityRole, roleId.String(), before, role)
 
 	return nil
 }
 
 // DeleteRole implements IRoleService.
 func (r *RoleServiceImpl) DeleteRole(ctx context.Context, roleId uuid.UUID) error {
-	_, err := r.roleRepo.FindById(ctx, roleId)
+	role, err := r.roleRepo.FindById(ctx, roleId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "role not found", 404)
@@ -168,6 +181,7 @@ func (r *RoleServiceImpl) DeleteRole(ctx context.Context, roleId uuid.UUID) erro
 	}
 
 	r.invalidateCacheRole(ctx)
+	r.audit.Record(ctx, audit.ActionDelete, audit.EntityRole, roleId.String(), role, nil)
 
 	return nil
 }
@@ -3,24 +3,38 @@ package studentservice
 import (
 	"context"
 	studentrequest "giat-cerika-service/internal/dto/request/student_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	studentresponse "giat-cerika-service/internal/dto/response/student_response"
+	toothbrushresponse "giat-cerika-service/internal/dto/response/toothbrush_response"
 	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
 	"mime/multipart"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type IStudentService interface {
 	Register(ctx context.Context, req studentrequest.RegisterStudentRequest) error
-	Login(ctx context.Context, req studentrequest.LoginStudentRequet) (string, error)
-	GetProfile(ctx context.Context, studentId uuid.UUID, token string) (*models.User, error)
-	Logout(ctx context.Context, studentID uuid.UUID, token string) error
-	CheckTokenBlacklisted(ctx context.Context, token string) (bool, error)
-	CheckNisnAndDateOfBirth(ctx context.Context, req studentrequest.CheckNisnAndDateOfBirth) (*models.User, error)
-	UpdateNewPasswordStudent(ctx context.Context, studentID uuid.UUID, req studentrequest.UpdatePassword) error
+	Login(ctx context.Context, req studentrequest.LoginStudentRequet) (*sessionresponse.TokenResponse, error)
+	GetProfile(ctx context.Context, studentId uuid.UUID) (*models.User, error)
+	Logout(ctx context.Context, studentID uuid.UUID, claims *utils.JWTClaims) error
+	// CheckNisnAndDateOfBirth dan VerifyResetCode menghasilkan reset token sekali pakai untuk UpdateNewPasswordStudent.
+	CheckNisnAndDateOfBirth(ctx context.Context, req studentrequest.CheckNisnAndDateOfBirth) (*studentresponse.PasswordResetTokenResponse, error)
+	VerifyResetCode(ctx context.Context, req studentrequest.VerifyResetCodeRequest) (*studentresponse.PasswordResetTokenResponse, error)
+	UpdateNewPasswordStudent(ctx context.Context, req studentrequest.UpdatePassword) error
+	// ResetPasswordByAdmin membuat kode reset sementara yang dicetak admin untuk diberikan ke siswa.
+	ResetPasswordByAdmin(ctx context.Context, studentId uuid.UUID) (*studentresponse.StudentResetCodeResponse, error)
 	UpdateProfileStudent(ctx context.Context, studentId uuid.UUID, req studentrequest.UpdateProfileRequest) error
 	UpdatePhotoStudent(ctx context.Context, studentId uuid.UUID, photo *multipart.FileHeader) error
 
 	CreateTootBrushStudent(ctx context.Context, studentId uuid.UUID, req studentrequest.CreateTootBrushRequest) error
 	GetHitoryToothBrush(ctx context.Context, studentId uuid.UUID, typeTime string, page int, limit int) ([]*models.ToootBrushLog, int, error)
+	GetToothBrushProgress(ctx context.Context, studentId uuid.UUID, startDate, endDate, month time.Time) (*toothbrushresponse.ToothBrushProgressResponse, error)
+	GetToothBrushForReview(ctx context.Context, reviewStatus string, classId *uuid.UUID, onlyWithPhoto bool, page int, limit int) ([]*models.ToootBrushLog, int, error)
+	ReviewToothBrush(ctx context.Context, logId uuid.UUID, adminId uuid.UUID, req studentrequest.ReviewToothBrushRequest) error
 	GetAllStudents(ctx context.Context, search string) ([]*models.User, int, error)
+	// UpdateStudentStatus mengaktifkan (1) / menonaktifkan (0) akun siswa; menonaktifkan mencabut semua tokennya.
+	UpdateStudentStatus(ctx context.Context, studentId uuid.UUID, req studentrequest.UpdateStudentStatusRequest) error
+	ImportStudents(ctx context.Context, req studentrequest.ImportStudentRequest) (*studentresponse.StudentImportResponse, error)
 }
@@ -2,15 +2,24 @@ package studentservice
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"giat-cerika-service/configs"
 	datasources "giat-cerika-service/internal/dataSources"
 	studentrequest "giat-cerika-service/internal/dto/request/student_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	studentresponse "giat-cerika-service/internal/dto/response/student_response"
+	toothbrushresponse "giat-cerika-service/internal/dto/response/toothbrush_response"
 	"giat-cerika-service/internal/models"
 	classrepo "giat-cerika-service/internal/repositories/class_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	rabbitmq "giat-cerika-service/pkg/constant/rabbitMq"
 	"giat-cerika-service/pkg/utils"
@@ -18,7 +27,10 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,14 +39,18 @@ import (
 )
 
 type StudentServiceImpl struct {
-	studenRepo studentrepo.IStudentRepository
-	classRepo  classrepo.IClassRepository
-	rdb        *redis.Client
-	cld        datasources.CloudinaryService
+	studenRepo  studentrepo.IStudentRepository
+	classRepo   classrepo.IClassRepository
+	sessionRepo toothbrushsessionrepo.IToothBrushSessionRepository
+	userSession usersessionservice.IUserSessionService
+	loginGuard  loginguardservice.ILoginGuardService
+	audit       auditservice.IAuditService
+	rdb         *redis.Client
+	cld         datasources.CloudinaryService
 }
 
-func NewStudentServiceImpl(studentRepo studentrepo.IStudentRepository, classRepo classrepo.IClassRepository, rdb *redis.Client, cld datasources.CloudinaryService) IStudentService {
-	return &StudentServiceImpl{studenRepo: studentRepo, classRepo: classRepo, rdb: rdb, cld: cld}
+func NewStudentServiceImpl(studentRepo studentrepo.IStudentRepository, classRepo classrepo.IClassRepository, sessionRepo toothbrushsessionrepo.IToothBrushSessionRepository, userSession usersessionservice.IUserSessionService, loginGuard loginguardservice.ILoginGuardService, auditService auditservice.IAuditService, rdb *redis.Client, cld datasources.CloudinaryService) IStudentService {
+	return &StudentServiceImpl{studenRepo: studentRepo, classRepo: classRepo, sessionRepo: sessionRepo, userSession: userSession, loginGuard: loginGuard, audit: auditService, rdb: rdb, cld: cld}
 }
 
 func fileStudentToBytes(fh *multipart.FileHeader) ([]byte, error) {
@@ -97,14 +113,7 @@ func (s *StudentServiceImpl) Register(ctx context.Context, req studentrequest.Re
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Date Of Birth is required", 400)
 	}
 	if req.Age == nil && !req.DateOfBirth.IsZero() {
-		now := time.Now()
-		age := now.Year() - req.DateOfBirth.Year()
-		if now.YearDay() < req.DateOfBirth.YearDay() {
-			age--
-		}
-		if age < 0 {
-			age = 0
-		}
+		age := calculateAge(req.DateOfBirth, time.Now())
 		req.Age = &age
 	}
 	if req.Age == nil {
@@ -173,43 +182,46 @@ func (s *StudentServiceImpl) Register(ctx context.Context, req studentrequest.Re
 }
 
 // Login implements IStudentService.
-func (s *StudentServiceImpl) Login(ctx context.Context, req studentrequest.LoginStudentRequet) (string, error) {
+func (s *StudentServiceImpl) Login(ctx context.Context, req studentrequest.LoginStudentRequet) (*sessionresponse.TokenResponse, error) {
+	if err := s.loginGuard.Check(ctx, req.Username, req.IPAddress); err != nil {
+		return nil, err
+	}
+
 	student, err := s.studenRepo.FindByUsername(ctx, req.Username)
 	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid credential", 400)
+		s.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, nil)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "invalid credential", 400)
 	}
 
 	isPassword := utils.CheckPasswordHash(req.Password, student.Password)
 	if !isPassword {
-		return "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "password incorrect", 400)
-	}
-
-	token, err := utils.GenerateToken(student.ID.String(), student.Role.Name)
-	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate token", 500)
+		s.loginGuard.RecordFailure(ctx, req.Username, req.IPAddress, &student.ID)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "password incorrect", 400)
 	}
-
-	expiry, err := utils.GetExpiryFromToken(token)
-	if err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get expiry token", 500)
+	s.loginGuard.RecordSuccess(ctx, req.Username)
+	if student.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "akun dinonaktifkan, hubungi admin sekolah", 403)
 	}
 
-	redisKey := fmt.Sprintf("student_token:%s", student.ID)
-	if err := configs.SetRedis(ctx, redisKey, token, time.Until(expiry)); err != nil {
-		return "", errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store token in cache", 400)
+	// Password sementara (hasil impor) tidak membuka sesi; siswa wajib menggantinya
+	// lewat PUT /update-new-password memakai reset token ini.
+	if student.MustChangePassword {
+		reset, err := s.issueResetToken(ctx, student)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionresponse.TokenResponse{
+			MustChangePassword: true,
+			ResetToken:         reset.ResetToken,
+			ExpiresIn:          reset.ExpiresIn,
+		}, nil
 	}
 
-	return token, nil
+	return s.userSession.CreateSession(ctx, student, req.UserAgent, req.IPAddress)
 }
 
 // GetProfile implements IStudentService.
-func (s *StudentServiceImpl) GetProfile(ctx context.Context, studentId uuid.UUID, token string) (*models.User, error) {
-	cacheKey := fmt.Sprintf("student_token:%s", studentId)
-	storedToken, err := configs.GetRedis(ctx, cacheKey)
-	if err != nil || storedToken != token {
-		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "unauthorized access", 401)
-	}
-
+func (s *StudentServiceImpl) GetProfile(ctx context.Context, studentId uuid.UUID) (*models.User, error) {
 	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
 	if err != nil {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
@@ -218,66 +230,239 @@ func (s *StudentServiceImpl) GetProfile(ctx context.Context, studentId uuid.UUID
 	return student, nil
 }
 
-// CheckTokenBlacklisted implements IStudentService.
-func (s *StudentServiceImpl) CheckTokenBlacklisted(ctx context.Context, token string) (bool, error) {
-	blackListeed := fmt.Sprintf("blacklistToken_student:%s", token)
-	val, err := configs.GetRedis(ctx, blackListeed)
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return false, nil
-		}
-		return false, errorresponse.NewCustomError(errorresponse.ErrNotFound, "blacklisted token not found", 404)
+// Logoutctx implements IStudentService.
+func (s *StudentServiceImpl) Logout(ctx context.Context, studentID uuid.UUID, claims *utils.JWTClaims) error {
+	if err := s.userSession.RevokeToken(ctx, claims); err != nil {
+		return err
 	}
-	return val == "blacklister", nil
+
+	// Logout hanya mengakhiri sesi perangkat ini; sesi di perangkat lain tetap aktif.
+	if sessionId, err := uuid.Parse(claims.SessionID); err == nil {
+		return s.userSession.RevokeSession(ctx, studentID, sessionId)
+	}
+	return nil
 }
 
-// Logoutctx implements IStudentService.
-func (s *StudentServiceImpl) Logout(ctx context.Context, studentID uuid.UUID, token string) error {
-	expiry, err := utils.GetExpiryFromToken(token)
+const (
+	// resetTokenTTL adalah umur reset token sekali pakai hasil verifikasi.
+	resetTokenTTL = 10 * time.Minute
+	// resetCodeTTL cukup panjang agar kode cetak bisa dibagikan di kelas keesokan harinya.
+	resetCodeTTL       = 72 * time.Hour
+	resetCodeLength    = 8
+	resetMaxAttempts   = 5
+	resetMaxIPAttempts = 20
+	resetAttemptWindow = 15 * time.Minute
+)
+
+func resetTokenKey(tokenHash string) string {
+	return fmt.Sprintf("reset_password_token:%s", tokenHash)
+}
+
+func resetTokenStudentKey(studentId uuid.UUID) string {
+	return fmt.Sprintf("reset_password_student:%s", studentId)
+}
+
+func resetCodeKey(studentId uuid.UUID) string {
+	return fmt.Sprintf("reset_password_code:%s", studentId)
+}
+
+func resetAttemptKey(scope, value string) string {
+	return fmt.Sprintf("reset_password_attempt:%s:%s", scope, value)
+}
+
+// checkResetAttempts menolak permintaan bila percobaan gagal pada key ini sudah mencapai limit.
+func (s *StudentServiceImpl) checkResetAttempts(ctx context.Context, key string, limit int) error {
+	count, err := s.rdb.Get(ctx, key).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get cache", 500)
+	}
+	if count >= limit {
+		return errorresponse.NewCustomError(errorresponse.ErrTooManyRequests, "too many attempts, please try again later", 429)
+	}
+	return nil
+}
+
+func (s *StudentServiceImpl) recordResetFailure(ctx context.Context, key string, window time.Duration) int {
+	count, err := s.rdb.Incr(ctx, key).Result()
 	if err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get expiry token", 500)
+		return 0
 	}
-	blacklistedKey := fmt.Sprintf("blacklistToken_student:%s", token)
-	err = configs.SetRedis(ctx, blacklistedKey, "blacklister", time.Until(expiry))
+	if count == 1 {
+		s.rdb.Expire(ctx, key, window)
+	}
+	return int(count)
+}
+
+// issueResetToken membuat reset token baru dan membatalkan token sebelumnya milik siswa yang sama.
+func (s *StudentServiceImpl) issueResetToken(ctx context.Context, student *models.User) (*studentresponse.PasswordResetTokenResponse, error) {
+	token, err := utils.GenerateOpaqueToken(32)
 	if err != nil {
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store blacklisted token in cache", 500)
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate reset token", 500)
 	}
-	return nil
+	tokenHash := utils.HashToken(token)
+
+	if oldHash, err := s.rdb.Get(ctx, resetTokenStudentKey(student.ID)).Result(); err == nil {
+		s.rdb.Del(ctx, resetTokenKey(oldHash))
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, resetTokenKey(tokenHash), student.ID.String(), resetTokenTTL)
+	pipe.Set(ctx, resetTokenStudentKey(student.ID), tokenHash, resetTokenTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store reset token", 500)
+	}
+
+	return &studentresponse.PasswordResetTokenResponse{
+		ResetToken: token,
+		ExpiresIn:  int(resetTokenTTL.Seconds()),
+		Name:       student.Name,
+	}, nil
 }
 
 // CheckNisnAndDateOfBirth implements IStudentService.
-func (s *StudentServiceImpl) CheckNisnAndDateOfBirth(ctx context.Context, req studentrequest.CheckNisnAndDateOfBirth) (*models.User, error) {
-	if strings.TrimSpace(req.Nisn) == "" {
+func (s *StudentServiceImpl) CheckNisnAndDateOfBirth(ctx context.Context, req studentrequest.CheckNisnAndDateOfBirth) (*studentresponse.PasswordResetTokenResponse, error) {
+	nisn := strings.TrimSpace(req.Nisn)
+	if nisn == "" {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Nisn is required", 400)
 	}
 	if req.DateOfBirth.IsZero() {
 		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Date Of Birth is required", 400)
 	}
-	student, err := s.studenRepo.CheckNisnAndDateOfBirth(ctx, req.Nisn, req.DateOfBirth)
+
+	nisnKey := resetAttemptKey("nisn", nisn)
+	ipKey := resetAttemptKey("ip", req.IPAddress)
+	if err := s.checkResetAttempts(ctx, nisnKey, resetMaxAttempts); err != nil {
+		return nil, err
+	}
+	if err := s.checkResetAttempts(ctx, ipKey, resetMaxIPAttempts); err != nil {
+		return nil, err
+	}
+
+	student, err := s.studenRepo.CheckNisnAndDateOfBirth(ctx, nisn, req.DateOfBirth)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.recordResetFailure(ctx, nisnKey, resetAttemptWindow)
+			s.recordResetFailure(ctx, ipKey, resetAttemptWindow)
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+	if student.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "account is disabled", 403)
+	}
+
+	s.rdb.Del(ctx, nisnKey)
+
+	return s.issueResetToken(ctx, student)
+}
+
+// VerifyResetCode implements IStudentService.
+func (s *StudentServiceImpl) VerifyResetCode(ctx context.Context, req studentrequest.VerifyResetCodeRequest) (*studentresponse.PasswordResetTokenResponse, error) {
+	username := strings.TrimSpace(req.Username)
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if username == "" {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "username is required", 400)
+	}
+	if code == "" {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "code is required", 400)
+	}
+
+	ipKey := resetAttemptKey("ip", req.IPAddress)
+	if err := s.checkResetAttempts(ctx, ipKey, resetMaxIPAttempts); err != nil {
+		return nil, err
+	}
+
+	invalidErr := errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "invalid username or reset code", 401)
+
+	student, err := s.studenRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.recordResetFailure(ctx, ipKey, resetAttemptWindow)
+			return nil, invalidErr
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+
+	studentKey := resetAttemptKey("code", student.ID.String())
+	if err := s.checkResetAttempts(ctx, studentKey, resetMaxAttempts); err != nil {
+		return nil, err
+	}
+
+	storedHash, err := s.rdb.Get(ctx, resetCodeKey(student.ID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get cache", 500)
+	}
+	if storedHash == "" || subtle.ConstantTimeCompare([]byte(storedHash), []byte(utils.HashToken(code))) != 1 {
+		s.recordResetFailure(ctx, ipKey, resetAttemptWindow)
+		// kode yang terlalu sering salah dibatalkan; siswa harus meminta kode baru ke admin
+		if s.recordResetFailure(ctx, studentKey, resetCodeTTL) >= resetMaxAttempts {
+			s.rdb.Del(ctx, resetCodeKey(student.ID))
+		}
+		return nil, invalidErr
+	}
+	if student.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "account is disabled", 403)
+	}
+
+	s.rdb.Del(ctx, resetCodeKey(student.ID), studentKey)
+
+	return s.issueResetToken(ctx, student)
+}
+
+// ResetPasswordByAdmin implements IStudentService.
+func (s *StudentServiceImpl) ResetPasswordByAdmin(ctx context.Context, studentId uuid.UUID) (*studentresponse.StudentResetCodeResponse, error) {
+	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
 		}
 		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
 	}
-	if student == nil {
-		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student is empty", 404)
+	if student.Role.Name != "student" {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
 	}
 
-	redisKey := fmt.Sprintf("id_forgot_password:%s", student.ID)
-	data, _ := json.Marshal(map[string]any{
-		"student_id":    student.ID,
-		"name":          student.Name,
-		"nisn":          req.Nisn,
-		"date_of_birth": req.DateOfBirth,
+	code, err := utils.GenerateRandomPassword(resetCodeLength)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate reset code", 500)
+	}
+	code = strings.ToUpper(code)
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, resetCodeKey(student.ID), utils.HashToken(code), resetCodeTTL)
+	pipe.Del(ctx, resetAttemptKey("code", student.ID.String()))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to store reset code", 500)
+	}
+
+	// kode reset tidak pernah ikut dicatat di audit log
+	s.audit.Record(ctx, audit.ActionResetPassword, audit.EntityStudent, student.ID.String(), nil, map[string]any{
+		"username":   student.Username,
+		"expires_at": time.Now().Add(resetCodeTTL),
 	})
-	_ = configs.SetRedis(ctx, redisKey, data, time.Minute*5)
 
-	return student, nil
+	nisn := ""
+	if student.Nisn != nil {
+		nisn = *student.Nisn
+	}
+
+	return &studentresponse.StudentResetCodeResponse{
+		StudentID: student.ID,
+		Name:      student.Name,
+		Nisn:      nisn,
+		Username:  student.Username,
+		Class:     student.Class.NameClass,
+		Code:      code,
+		ExpiresAt: utils.FormatDate(time.Now().Add(resetCodeTTL)),
+	}, nil
 }
 
 // UpdateNewPasswordStudent implements IStudentService.
-func (s *StudentServiceImpl) UpdateNewPasswordStudent(ctx context.Context, studentID uuid.UUID, req studentrequest.UpdatePassword) error {
+func (s *StudentServiceImpl) UpdateNewPasswordStudent(ctx context.Context, req studentrequest.UpdatePassword) error {
+	if strings.TrimSpace(req.ResetToken) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "reset token is required", 400)
+	}
+
 	if strings.TrimSpace(req.NewPassword) == "" {
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "new password is required", 400)
 	}
@@ -286,25 +471,24 @@ func (s *StudentServiceImpl) UpdateNewPasswordStudent(ctx context.Context, stude
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "confirm password is required", 400)
 	}
 
-	if req.StudentID == uuid.Nil {
-		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "student id is required", 400)
-	}
-
 	if strings.TrimSpace(req.NewPassword) != strings.TrimSpace(req.ConfirmPassword) {
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "new password and confirm password doesn't match", 400)
 	}
 
-	redisKey := fmt.Sprintf("id_forgot_password:%s", studentID)
-	cacheData, err := configs.GetRedis(ctx, redisKey)
+	// GETDEL membuat token benar-benar sekali pakai walau ada request bersamaan.
+	storedId, err := s.rdb.GetDel(ctx, resetTokenKey(utils.HashToken(strings.TrimSpace(req.ResetToken)))).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "reset password session expired", 401)
+			return errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "reset token is invalid or expired", 401)
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get cache", 500)
 	}
 
-	var stored map[string]any
-	_ = json.Unmarshal([]byte(cacheData), &stored)
+	studentID, err := uuid.Parse(storedId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "reset token is invalid or expired", 401)
+	}
+	s.rdb.Del(ctx, resetTokenStudentKey(studentID))
 
 	hashed, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
@@ -315,7 +499,10 @@ func (s *StudentServiceImpl) UpdateNewPasswordStudent(ctx context.Context, stude
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update password", 500)
 	}
 
-	_ = configs.DeleteRedis(ctx, redisKey)
+	// Password baru mengakhiri semua login lama di perangkat mana pun.
+	if err := s.userSession.RevokeUserTokens(ctx, studentID, usersessionservice.RevokeReasonPassword); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -413,40 +600,67 @@ func (s *StudentServiceImpl) CreateTootBrushStudent(ctx context.Context, student
 		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "type time is required", 400)
 	}
 
-	if req.TimeType != strings.ToUpper("MORNING") && req.TimeType != strings.ToUpper("NIGHT") {
-		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "type time only 'MORNING' or 'NIGHT'", 400)
+	// jenis sesi sepenuhnya ditentukan tabel tooth_brush_sessions (kode + status aktif)
+	timeType := strings.ToUpper(strings.TrimSpace(req.TimeType))
+	session, err := s.sessionRepo.FindByCode(ctx, timeType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "tooth brush session not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get tooth brush session", 500)
 	}
 
-	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
-	nowJakarta := time.Now().In(locJakarta)
-
-	hour := nowJakarta.Hour()
-	minute := nowJakarta.Minute()
-	timeType := strings.ToUpper(req.TimeType)
+	if !session.IsActive {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("sesi %s sedang tidak aktif", session.Name), 400)
+	}
 
-	if timeType == "MORNING" {
-		if hour < 5 || (hour == 7 && minute > 0) || hour > 7 {
-			return errorresponse.NewCustomError(
-				errorresponse.ErrBadRequest,
-				"absen pagi hanya bisa antara jam 05:00 sampai 10:00",
-				400,
-			)
+	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
 		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
 	}
 
-	if timeType == "NIGHT" {
-		if hour < 17 || (hour == 22 && minute > 0) || hour > 22 {
-			return errorresponse.NewCustomError(
-				errorresponse.ErrBadRequest,
-				"absen malam hanya bisa antara jam 17:00 sampai 22:00",
-				400,
-			)
+	// jendela bawaan sesi bisa ditimpa per kelas, grace kosong berarti ikut sesi
+	startClock, endClock, grace := session.StartTime, session.EndTime, session.GraceMinutes
+	if student.ClassID != nil {
+		override, err := s.sessionRepo.FindOverride(ctx, session.ID, *student.ClassID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class override", 500)
 		}
+		if err == nil {
+			startClock, endClock = override.StartTime, override.EndTime
+			if override.GraceMinutes != nil {
+				grace = *override.GraceMinutes
+			}
+		}
+	}
+
+	startMinutes, err := utils.ClockToMinutes(startClock)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "invalid session start time", 500)
+	}
+	endMinutes, err := utils.ClockToMinutes(endClock)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "invalid session end time", 500)
+	}
+
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	nowJakarta := time.Now().In(locJakarta)
+
+	allowed, isLate := utils.CheckInWindow(nowJakarta.Hour()*60+nowJakarta.Minute(), startMinutes, endMinutes, grace)
+	if !allowed {
+		return errorresponse.NewCustomError(
+			errorresponse.ErrBadRequest,
+			fmt.Sprintf("absen %s hanya bisa antara jam %s sampai %s", strings.ToLower(session.Name), startClock, endClock),
+			400,
+		)
 	}
 
 	logDate := nowJakarta.Format("2006-01-02")
 
-	exists, err := s.studenRepo.CheckTootBrushExists(ctx, studentId, strings.ToUpper(req.TimeType), nowJakarta)
+	exists, err := s.studenRepo.CheckTootBrushExists(ctx, studentId, timeType, nowJakarta)
 	if err != nil {
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check log", 500)
 	}
@@ -455,22 +669,13 @@ func (s *StudentServiceImpl) CreateTootBrushStudent(ctx context.Context, student
 		return errorresponse.NewCustomError(errorresponse.ErrExists, "Anda sudah absen untuk sesi ini hari ini", 409)
 	}
 
-	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
-		}
-		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
-	}
-
-	fmt.Println(logDate)
-
 	newLog := &models.ToootBrushLog{
 		ID:        uuid.New(),
 		UserID:    student.ID,
 		TimeType:  timeType,
 		LogDate:   logDate,
 		LogTime:   nowJakarta,
+		IsLate:    isLate,
 		CreatedAt: nowJakarta,
 	}
 
@@ -478,7 +683,84 @@ func (s *StudentServiceImpl) CreateTootBrushStudent(ctx context.Context, student
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create log", 500)
 	}
 
+	if req.Photo != nil {
+		if bin, err := fileStudentToBytes(req.Photo); err == nil && len(bin) > 0 {
+			task := payload.ImageUploadPayload{
+				ID:        newLog.ID,
+				Type:      "single",
+				FileBytes: bin,
+				Folder:    "giat_cerika/toothbrush_evidence",
+				Filename:  fmt.Sprintf("toothbrush_%s_%s", student.ID.String(), newLog.ID.String()),
+			}
+			_ = rabbitmq.PublishToQueue("", rabbitmq.SendImageToothBrushQueueName, task)
+		}
+	}
+
+	s.invalidateCacheToothBrush(ctx)
+	return nil
+}
+
+// GetToothBrushForReview implements IStudentService.
+func (s *StudentServiceImpl) GetToothBrushForReview(ctx context.Context, reviewStatus string, classId *uuid.UUID, onlyWithPhoto bool, page int, limit int) ([]*models.ToootBrushLog, int, error) {
+	reviewStatus = strings.ToUpper(strings.TrimSpace(reviewStatus))
+	if reviewStatus != "" && reviewStatus != "PENDING" && reviewStatus != "APPROVED" && reviewStatus != "FLAGGED" {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "review status only 'PENDING', 'APPROVED' or 'FLAGGED'", 400)
+	}
+
+	offset := (page - 1) * limit
+	items, total, err := s.studenRepo.GetAllTootBrushForReview(ctx, reviewStatus, classId, onlyWithPhoto, limit, offset)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get toothbrush logs", 500)
+	}
+
+	if len(items) == 0 {
+		items = []*models.ToootBrushLog{}
+	}
+
+	return items, total, nil
+}
+
+// ReviewToothBrush implements IStudentService.
+func (s *StudentServiceImpl) ReviewToothBrush(ctx context.Context, logId uuid.UUID, adminId uuid.UUID, req studentrequest.ReviewToothBrushRequest) error {
+	status := strings.ToUpper(strings.TrimSpace(req.Status))
+	if status != "APPROVED" && status != "FLAGGED" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "status only 'APPROVED' or 'FLAGGED'", 400)
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if status == "FLAGGED" && note == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "note is required when flagging a log", 400)
+	}
+
+	toothBrushLog, err := s.studenRepo.FindTootBrushByID(ctx, logId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "toothbrush log not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get toothbrush log", 500)
+	}
+
+	var reviewNote *string
+	if note != "" {
+		reviewNote = &note
+	}
+
+	updates := map[string]any{
+		"review_status": status,
+		"review_note":   reviewNote,
+		"reviewed_by":   adminId,
+		"reviewed_at":   time.Now(),
+	}
+
+	if err := s.studenRepo.UpdateReviewTootBrush(ctx, logId, updates); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to review toothbrush log", 500)
+	}
+
 	s.invalidateCacheToothBrush(ctx)
+	s.audit.Record(ctx, audit.ActionReview, audit.EntityToothBrushLog, logId.String(),
+		map[string]any{"review_status": toothBrushLog.ReviewStatus, "review_note": toothBrushLog.ReviewNote},
+		map[string]any{"review_status": status, "review_note": reviewNote},
+	)
 	return nil
 }
 
@@ -516,8 +798,223 @@ func (s *StudentServiceImpl) GetHitoryToothBrush(ctx context.Context, studentId
 	return items, total, nil
 }
 
+// GetToothBrushProgress implements IStudentService.
+// Streak dihitung dari seluruh riwayat (hari lengkap = sesi pagi & malam tercatat),
+// sedangkan compliance dihitung pada rentang startDate–endDate (maksimal sampai hari ini).
+func (s *StudentServiceImpl) GetToothBrushProgress(ctx context.Context, studentId uuid.UUID, startDate, endDate, month time.Time) (*toothbrushresponse.ToothBrushProgressResponse, error) {
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	nowJakarta := time.Now().In(locJakarta)
+	today := time.Date(nowJakarta.Year(), nowJakarta.Month(), nowJakarta.Day(), 0, 0, 0, 0, time.UTC)
+
+	if endDate.IsZero() {
+		endDate = today
+	}
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -29)
+	}
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	if startDate.After(endDate) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "start date must be before end date", 400)
+	}
+	if endDate.Sub(startDate) > 366*24*time.Hour {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "date range maximum 366 days", 400)
+	}
+	if month.IsZero() {
+		month = endDate
+	}
+	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// guru hanya boleh melihat progres siswa di kelas yang diampunya (dicek sebelum cache)
+	if teacherId := utils.TeacherScopeFromContext(ctx); teacherId != nil {
+		student, err := s.studenRepo.FindByStudentID(ctx, studentId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+			}
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+		}
+		inScope := false
+		if student.ClassID != nil {
+			if inScope, err = s.classRepo.IsClassTeacher(ctx, *student.ClassID, *teacherId); err != nil {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check class teacher", 500)
+			}
+		}
+		if !inScope {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrForbidden, "student is outside your teaching scope", 403)
+		}
+	}
+
+	cacheKey := fmt.Sprintf("toothbrush:progress:%s:start:%s:end:%s:month:%s:today:%s",
+		studentId, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), monthStart.Format("2006-01"), today.Format("2006-01-02"))
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result toothbrushresponse.ToothBrushProgressResponse
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return &result, nil
+		}
+	}
+
+	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+
+	logs, err := s.studenRepo.FindTootBrushLogsByRange(ctx, student.ID, nil, nil)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get history toothbrush", 500)
+	}
+
+	// hari lengkap & target harian mengikuti sesi yang sedang aktif, bukan kode yang ditanam di kode program
+	activeSessions, err := s.sessionRepo.FindActive(ctx)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get tooth brush sessions", 500)
+	}
+	codes := make([]string, len(activeSessions))
+	for i, session := range activeSessions {
+		codes[i] = session.Code
+	}
+
+	// tanggal (YYYY-MM-DD) → time_type → tercatat
+	sessions := make(map[string]map[string]bool)
+	for _, l := range logs {
+		d, err := utils.ParseLogDate(l.LogDate)
+		if err != nil {
+			continue
+		}
+		key := d.Format("2006-01-02")
+		if sessions[key] == nil {
+			sessions[key] = make(map[string]bool)
+		}
+		sessions[key][l.TimeType] = true
+	}
+
+	completeDays := make(map[string]bool, len(sessions))
+	for day, types := range sessions {
+		if isCompleteToothBrushDay(types, codes) {
+			completeDays[day] = true
+		}
+	}
+	currentStreak, longestStreak := utils.CalculateStreaks(completeDays, today)
+
+	effectiveEnd := endDate
+	if effectiveEnd.After(today) {
+		effectiveEnd = today
+	}
+
+	totalDays := 0
+	logged := make(map[string]int)
+	for d := startDate; !d.After(effectiveEnd); d = d.AddDate(0, 0, 1) {
+		totalDays++
+		for code := range sessions[d.Format("2006-01-02")] {
+			logged[code]++
+		}
+	}
+
+	compliance := func(code string) toothbrushresponse.ToothBrushComplianceResponse {
+		return toothbrushresponse.ToothBrushComplianceResponse{
+			Logged:   logged[code],
+			Expected: totalDays,
+			Rate:     utils.ComplianceRate(logged[code], totalDays),
+		}
+	}
+
+	sessionRes := make([]toothbrushresponse.ToothBrushSessionComplianceResponse, len(activeSessions))
+	totalLogged := 0
+	for i, session := range activeSessions {
+		c := compliance(session.Code)
+		sessionRes[i] = toothbrushresponse.ToothBrushSessionComplianceResponse{
+			Code:     session.Code,
+			Name:     session.Name,
+			Logged:   c.Logged,
+			Expected: c.Expected,
+			Rate:     c.Rate,
+		}
+		totalLogged += c.Logged
+	}
+	totalExpected := totalDays * len(activeSessions)
+
+	result := &toothbrushresponse.ToothBrushProgressResponse{
+		StartDate:     utils.FormatOnlyDate(startDate),
+		EndDate:       utils.FormatOnlyDate(endDate),
+		TotalDays:     totalDays,
+		CurrentStreak: currentStreak,
+		LongestStreak: longestStreak,
+		Sessions:      sessionRes,
+		Morning:       compliance(models.ToothBrushSessionMorning),
+		Night:         compliance(models.ToothBrushSessionNight),
+		Overall: toothbrushresponse.ToothBrushComplianceResponse{
+			Logged:   totalLogged,
+			Expected: totalExpected,
+			Rate:     utils.ComplianceRate(totalLogged, totalExpected),
+		},
+		Calendar: buildToothBrushCalendar(monthStart, sessions, codes),
+	}
+
+	buf, _ := json.Marshal(result)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return result, nil
+}
+
+// isCompleteToothBrushDay bernilai true bila semua sesi aktif (codes) tercatat pada hari tersebut.
+func isCompleteToothBrushDay(types map[string]bool, codes []string) bool {
+	if len(codes) == 0 {
+		return false
+	}
+	for _, code := range codes {
+		if !types[code] {
+			return false
+		}
+	}
+	return true
+}
+
+// buildToothBrushCalendar menyusun matriks kalender satu bulan (baris = minggu, kolom = Senin..Minggu).
+// codes adalah kode sesi aktif yang ditampilkan per hari.
+func buildToothBrushCalendar(monthStart time.Time, sessions map[string]map[string]bool, codes []string) toothbrushresponse.ToothBrushCalendarResponse {
+	weeks := [][]*toothbrushresponse.ToothBrushCalendarDayResponse{}
+	week := make([]*toothbrushresponse.ToothBrushCalendarDayResponse, 7)
+
+	// Weekday Go dimulai dari Minggu (0), geser agar Senin = kolom 0
+	col := (int(monthStart.Weekday()) + 6) % 7
+	for d := monthStart; d.Month() == monthStart.Month(); d = d.AddDate(0, 0, 1) {
+		types := sessions[d.Format("2006-01-02")]
+		daySessions := make(map[string]bool, len(codes))
+		for _, code := range codes {
+			daySessions[code] = types[code]
+		}
+		week[col] = &toothbrushresponse.ToothBrushCalendarDayResponse{
+			Date:     utils.FormatOnlyDate(d),
+			Day:      d.Day(),
+			Sessions: daySessions,
+			Morning:  types[models.ToothBrushSessionMorning],
+			Night:    types[models.ToothBrushSessionNight],
+			Complete: isCompleteToothBrushDay(types, codes),
+		}
+
+		col++
+		if col == 7 {
+			weeks = append(weeks, week)
+			week = make([]*toothbrushresponse.ToothBrushCalendarDayResponse, 7)
+			col = 0
+		}
+	}
+	if col > 0 {
+		weeks = append(weeks, week)
+	}
+
+	return toothbrushresponse.ToothBrushCalendarResponse{
+		Month: monthStart.Format("01-2006"),
+		Weeks: weeks,
+	}
+}
+
 func (s *StudentServiceImpl) GetAllStudents(ctx context.Context, search string) ([]*models.User, int, error) {
-	students, total, err := s.studenRepo.GetAllStudents(ctx, search)
+	students, total, err := s.studenRepo.GetAllStudents(ctx, search, utils.TeacherScopeFromContext(ctx))
 	if err != nil {
 		return nil, 0, errorresponse.NewCustomError(
 			errorresponse.ErrInternal,
@@ -529,3 +1026,319 @@ func (s *StudentServiceImpl) GetAllStudents(ctx context.Context, search string)
 	}
 	return students, total, nil
 }
+
+// UpdateStudentStatus implements IStudentService.
+func (s *StudentServiceImpl) UpdateStudentStatus(ctx context.Context, studentId uuid.UUID, req studentrequest.UpdateStudentStatusRequest) error {
+	if req.Status == nil || (*req.Status != 0 && *req.Status != 1) {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "status must be 0 (nonaktif) or 1 (aktif)", 400)
+	}
+
+	student, err := s.studenRepo.FindByStudentID(ctx, studentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "student not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get student", 500)
+	}
+	if student.Status == *req.Status {
+		return nil
+	}
+
+	if err := s.studenRepo.UpdateStatus(ctx, studentId, *req.Status); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update student status", 500)
+	}
+	s.audit.Record(ctx, audit.ActionUpdateStatus, audit.EntityStudent, studentId.String(), map[string]any{"status": student.Status}, map[string]any{"status": *req.Status})
+
+	if *req.Status == 0 {
+		return s.userSession.RevokeUserTokens(ctx, studentId, usersessionservice.RevokeReasonDisabled)
+	}
+	return nil
+}
+
+const (
+	importMaxFileSize    = 5 << 20
+	importMaxRows        = 1000
+	importPasswordLength = 8
+	importBatchSize      = 100
+)
+
+// importColumnAliases memetakan nama kolom yang diterima pada file roster ke field impor.
+var importColumnAliases = map[string]string{
+	"nama":          "name",
+	"name":          "name",
+	"nama siswa":    "name",
+	"nisn":          "nisn",
+	"tanggal lahir": "date_of_birth",
+	"date of birth": "date_of_birth",
+	"dob":           "date_of_birth",
+	"kelas":         "class",
+	"class":         "class",
+	"class name":    "class",
+	"nama kelas":    "class",
+	"name class":    "class",
+}
+
+// ImportStudents implements IStudentService.
+// Seluruh baris divalidasi terlebih dahulu; akun hanya dibuat (dalam satu transaksi) jika
+// bukan dry-run dan tidak ada baris yang gagal validasi.
+func (s *StudentServiceImpl) ImportStudents(ctx context.Context, req studentrequest.ImportStudentRequest) (*studentresponse.StudentImportResponse, error) {
+	if req.File == nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "file is required", 400)
+	}
+	if req.File.Size > importMaxFileSize {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "ukuran file maksimal 5MB", 400)
+	}
+
+	rows, err := readImportFile(req.File)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "gagal membaca file: "+err.Error(), 400)
+	}
+	if len(rows) < 2 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "file tidak berisi data siswa", 400)
+	}
+
+	columns := map[string]int{}
+	for i, header := range rows[0] {
+		key := strings.ToLower(strings.TrimSpace(strings.ReplaceAll(header, "_", " ")))
+		if field, ok := importColumnAliases[key]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = i
+			}
+		}
+	}
+	for _, field := range []string{"name", "nisn", "date_of_birth", "class"} {
+		if _, ok := columns[field]; !ok {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("kolom %s tidak ditemukan pada header", field), 400)
+		}
+	}
+
+	classes, err := s.classRepo.GetAllPublic(ctx)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get classes", 500)
+	}
+	classByName := make(map[string]*models.Class, len(classes))
+	for _, class := range classes {
+		classByName[strings.ToLower(strings.TrimSpace(class.NameClass))] = class
+	}
+
+	role, err := s.studenRepo.FindRoleStudent(ctx)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get role", 500)
+	}
+
+	results := []studentresponse.StudentImportRowResult{}
+	users := []*models.User{}
+	seenNisn := map[string]int{}
+	nisns := []string{}
+	now := time.Now()
+
+	for i, row := range rows[1:] {
+		cell := func(field string) string {
+			idx := columns[field]
+			if idx < len(row) {
+				return strings.TrimSpace(row[idx])
+			}
+			return ""
+		}
+
+		name, nisn, dobRaw, className := cell("name"), cell("nisn"), cell("date_of_birth"), cell("class")
+		if name == "" && nisn == "" && dobRaw == "" && className == "" {
+			continue
+		}
+
+		result := studentresponse.StudentImportRowResult{
+			Row:         i + 2,
+			Name:        name,
+			Nisn:        nisn,
+			DateOfBirth: dobRaw,
+			Class:       className,
+			Errors:      []string{},
+		}
+
+		if name == "" {
+			result.Errors = append(result.Errors, "nama wajib diisi")
+		}
+
+		switch {
+		case nisn == "":
+			result.Errors = append(result.Errors, "NISN wajib diisi")
+		case strings.Trim(nisn, "0123456789") != "":
+			result.Errors = append(result.Errors, "NISN hanya boleh berisi angka")
+		default:
+			if prevRow, ok := seenNisn[nisn]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("NISN duplikat dengan baris %d", prevRow))
+			} else {
+				seenNisn[nisn] = result.Row
+				nisns = append(nisns, nisn)
+			}
+		}
+
+		var dateOfBirth time.Time
+		if dobRaw == "" {
+			result.Errors = append(result.Errors, "tanggal lahir wajib diisi")
+		} else if dateOfBirth, err = utils.ParseImportDate(dobRaw); err != nil {
+			result.Errors = append(result.Errors, "format tanggal lahir tidak valid")
+		} else if dateOfBirth.After(now) {
+			result.Errors = append(result.Errors, "tanggal lahir tidak boleh di masa depan")
+		} else {
+			result.DateOfBirth = utils.FormatOnlyDate(dateOfBirth)
+		}
+
+		class, ok := classByName[strings.ToLower(className)]
+		if className == "" {
+			result.Errors = append(result.Errors, "kelas wajib diisi")
+		} else if !ok {
+			result.Errors = append(result.Errors, fmt.Sprintf("kelas %q tidak ditemukan", className))
+		}
+
+		results = append(results, result)
+		if len(results) > importMaxRows {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, fmt.Sprintf("maksimal %d siswa per file", importMaxRows), 400)
+		}
+
+		var user *models.User
+		if len(result.Errors) == 0 {
+			studentName, studentNisn, dob := name, nisn, dateOfBirth
+			classID := class.ID
+			user = &models.User{
+				ID:          uuid.New(),
+				Name:        &studentName,
+				Username:    studentNisn,
+				Nisn:        &studentNisn,
+				DateOfBirth: &dob,
+				Age:         calculateAge(dob, now),
+				RoleID:      role.ID,
+				ClassID:     &classID,
+				Status:      1,
+
+				MustChangePassword: true,
+			}
+		}
+		users = append(users, user)
+	}
+
+	if len(results) == 0 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrBadRequest, "file tidak berisi data siswa", 400)
+	}
+
+	// Username akun impor memakai NISN, sehingga keduanya dicek terhadap data yang sudah ada.
+	existingNisns, err := s.studenRepo.FindExistingNisns(ctx, nisns)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check existing nisn", 500)
+	}
+	existingUsernames, err := s.studenRepo.FindExistingUsernames(ctx, nisns)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check existing username", 500)
+	}
+	takenNisn := make(map[string]bool, len(existingNisns))
+	for _, n := range existingNisns {
+		takenNisn[n] = true
+	}
+	takenUsername := make(map[string]bool, len(existingUsernames))
+	for _, u := range existingUsernames {
+		takenUsername[u] = true
+	}
+
+	resp := &studentresponse.StudentImportResponse{DryRun: req.DryRun, TotalRows: len(results)}
+	for i := range results {
+		if takenNisn[results[i].Nisn] {
+			results[i].Errors = append(results[i].Errors, "NISN sudah terdaftar")
+		} else if takenUsername[results[i].Nisn] {
+			results[i].Errors = append(results[i].Errors, "username (NISN) sudah dipakai akun lain")
+		}
+
+		results[i].Valid = len(results[i].Errors) == 0
+		if results[i].Valid {
+			resp.ValidRows++
+		} else {
+			resp.InvalidRows++
+		}
+	}
+	resp.Rows = results
+
+	if req.DryRun || resp.InvalidRows > 0 {
+		return resp, nil
+	}
+
+	passwords := make([]string, len(users))
+	for i := range users {
+		password, err := utils.GenerateRandomPassword(importPasswordLength)
+		if err != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate password", 500)
+		}
+		passwords[i] = password
+	}
+
+	// Password impor bersifat sementara (wajib diganti saat login pertama), sehingga memakai
+	// cost bcrypt yang lebih ringan agar 1000 baris tetap selesai jauh di bawah batas timeout.
+	hashErrs := make([]error, len(users))
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			users[i].Password, hashErrs[i] = utils.HashTemporaryPassword(passwords[i])
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range hashErrs {
+		if err != nil {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to hashing password", 500)
+		}
+	}
+
+	if err := configs.RunTransaction(ctx, func(tx *gorm.DB) error {
+		return tx.CreateInBatches(users, importBatchSize).Error
+	}); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create students", 500)
+	}
+
+	for i := range resp.Rows {
+		resp.Rows[i].Username = users[i].Username
+		resp.Rows[i].InitialPassword = passwords[i]
+	}
+	resp.Created = len(users)
+
+	usernames := make([]string, len(users))
+	for i := range users {
+		usernames[i] = users[i].Username
+	}
+	s.audit.Record(ctx, audit.ActionImport, audit.EntityStudent, "", nil, map[string]any{
+		"created":   resp.Created,
+		"usernames": usernames,
+	})
+
+	return resp, nil
+}
+
+func readImportFile(file *multipart.FileHeader) ([][]string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".csv":
+		return utils.ReadCSV(src)
+	case ".xlsx":
+		return utils.ReadXLSX(src)
+	default:
+		return nil, errors.New("format file harus .csv atau .xlsx")
+	}
+}
+
+// calculateAge menghitung umur dalam tahun penuh; dipakai registrasi maupun impor.
+func calculateAge(dateOfBirth, now time.Time) int {
+	age := now.Year() - dateOfBirth.Year()
+	if now.YearDay() < dateOfBirth.YearDay() {
+		age--
+	}
+	if age < 0 {
+		age = 0
+	}
+	return age
+}
@@ -0,0 +1,43 @@
+package studentservice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildToothBrushCalendar_UsesActiveSessionCodes(t *testing.T) {
+	monthStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	sessions := map[string]map[string]bool{
+		"2026-03-02": {"MORNING": true, "NOON": true},
+		"2026-03-03": {"MORNING": true, "NIGHT": true},
+	}
+	codes := []string{"MORNING", "NOON"}
+
+	calendar := buildToothBrushCalendar(monthStart, sessions, codes)
+
+	days := make(map[int]bool)
+	for _, week := range calendar.Weeks {
+		for _, day := range week {
+			if day == nil {
+				continue
+			}
+			if len(day.Sessions) != len(codes) {
+				t.Fatalf("day %d: expected %d session entries, got %d", day.Day, len(codes), len(day.Sessions))
+			}
+			days[day.Day] = day.Complete
+		}
+	}
+
+	if !days[2] {
+		t.Fatal("expected 2 March complete: every active session is logged")
+	}
+	if days[3] {
+		t.Fatal("expected 3 March incomplete: NOON is active but not logged")
+	}
+}
+
+func TestIsCompleteToothBrushDay_NoActiveSessions(t *testing.T) {
+	if isCompleteToothBrushDay(map[string]bool{"MORNING": true}, nil) {
+		t.Fatal("expected incomplete day when no session is active")
+	}
+}
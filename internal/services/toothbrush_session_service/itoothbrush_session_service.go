@@ -0,0 +1,20 @@
+package toothbrushsessionservice
+
+import (
+	"context"
+	toothbrushsessionrequest "giat-cerika-service/internal/dto/request/toothbrush_session_request"
+	"giat-cerika-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type IToothBrushSessionService interface {
+	CreateSession(ctx context.Context, req toothbrushsessionrequest.CreateToothBrushSessionRequest) error
+	GetAllSession(ctx context.Context, page, limit int, search string) ([]*models.ToothBrushSession, int, error)
+	GetByIdSession(ctx context.Context, sessionId uuid.UUID) (*models.ToothBrushSession, error)
+	UpdateSession(ctx context.Context, sessionId uuid.UUID, req toothbrushsessionrequest.UpdateToothBrushSessionRequest) error
+	DeleteSession(ctx context.Context, sessionId uuid.UUID) error
+
+	UpsertClassOverride(ctx context.Context, sessionId uuid.UUID, req toothbrushsessionrequest.UpsertClassOverrideRequest) error
+	DeleteClassOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) error
+}
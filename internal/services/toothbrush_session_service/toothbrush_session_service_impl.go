@@ -0,0 +1,341 @@
+package toothbrushsessionservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	toothbrushsessionrequest "giat-cerika-service/internal/dto/request/toothbrush_session_request"
+	"giat-cerika-service/internal/models"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type ToothBrushSessionServiceImpl struct {
+	sessionRepo toothbrushsessionrepo.IToothBrushSessionRepository
+	classRepo   classrepo.IClassRepository
+	audit       auditservice.IAuditService
+	rdb         *redis.Client
+}
+
+func NewToothBrushSessionServiceImpl(sessionRepo toothbrushsessionrepo.IToothBrushSessionRepository, classRepo classrepo.IClassRepository, auditService auditservice.IAuditService, rdb *redis.Client) IToothBrushSessionService {
+	return &ToothBrushSessionServiceImpl{sessionRepo: sessionRepo, classRepo: classRepo, audit: auditService, rdb: rdb}
+}
+
+func (t *ToothBrushSessionServiceImpl) invalidateCacheSession(ctx context.Context) {
+	iter := t.rdb.Scan(ctx, 0, "toothbrush_sessions:*", 0).Iterator()
+	for iter.Next(ctx) {
+		t.rdb.Del(ctx, iter.Val())
+	}
+
+	iterID := t.rdb.Scan(ctx, 0, "toothbrush_session:*", 0).Iterator()
+	for iterID.Next(ctx) {
+		t.rdb.Del(ctx, iterID.Val())
+	}
+
+	// progres & kepatuhan dihitung dari daftar sesi aktif
+	iterProgress := t.rdb.Scan(ctx, 0, "toothbrush:*", 0).Iterator()
+	for iterProgress.Next(ctx) {
+		t.rdb.Del(ctx, iterProgress.Val())
+	}
+}
+
+// overrideAuditData dicatat dengan entity id sesi; kelas yang terdampak ada di class_id.
+func overrideAuditData(override *models.ToothBrushSessionOverride) map[string]any {
+	return map[string]any{
+		"class_id":      override.ClassID,
+		"start_time":    override.StartTime,
+		"end_time":      override.EndTime,
+		"grace_minutes": override.GraceMinutes,
+	}
+}
+
+// validateWindow menormalkan jam mulai/selesai ke format HH:MM dan memastikan jendela waktunya valid.
+func validateWindow(startTime, endTime string, graceMinutes int) (string, string, error) {
+	start, err := utils.ClockToMinutes(strings.TrimSpace(startTime))
+	if err != nil {
+		return "", "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "start time must be in HH:MM format", 400)
+	}
+	end, err := utils.ClockToMinutes(strings.TrimSpace(endTime))
+	if err != nil {
+		return "", "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "end time must be in HH:MM format", 400)
+	}
+	if end <= start {
+		return "", "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "end time must be after start time", 400)
+	}
+	if graceMinutes < 0 || end+graceMinutes >= 24*60 {
+		return "", "", errorresponse.NewCustomError(errorresponse.ErrBadRequest, "grace minutes must not pass midnight", 400)
+	}
+
+	return fmt.Sprintf("%02d:%02d", start/60, start%60), fmt.Sprintf("%02d:%02d", end/60, end%60), nil
+}
+
+// CreateSession implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) CreateSession(ctx context.Context, req toothbrushsessionrequest.CreateToothBrushSessionRequest) error {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Code is required", 400)
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "Name is required", 400)
+	}
+
+	existSession, err := t.sessionRepo.FindByCode(ctx, code)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+	if existSession != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "session code already exists", 409)
+	}
+
+	grace := 0
+	if req.GraceMinutes != nil {
+		grace = *req.GraceMinutes
+	}
+	start, end, err := validateWindow(req.StartTime, req.EndTime, grace)
+	if err != nil {
+		return err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	newSession := &models.ToothBrushSession{
+		ID:           uuid.New(),
+		Code:         code,
+		Name:         req.Name,
+		StartTime:    start,
+		EndTime:      end,
+		GraceMinutes: grace,
+		IsActive:     isActive,
+	}
+
+	if err := t.sessionRepo.Create(ctx, newSession); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create session", 500)
+	}
+
+	t.invalidateCacheSession(ctx)
+	t.audit.Record(ctx, audit.ActionCreate, audit.EntityToothBrushSession, newSession.ID.String(), nil, newSession)
+
+	return nil
+}
+
+// GetAllSession implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) GetAllSession(ctx context.Context, page int, limit int, search string) ([]*models.ToothBrushSession, int, error) {
+	cacheKey := fmt.Sprintf("toothbrush_sessions:search:%s:page:%d:limit:%d", search, page, limit)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var result struct {
+			Data  []*models.ToothBrushSession `json:"data"`
+			Total int                         `json:"total"`
+		}
+		if json.Unmarshal([]byte(cached), &result) == nil {
+			return result.Data, result.Total, nil
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	items, total, err := t.sessionRepo.FindAll(ctx, limit, offset, search)
+	if err != nil {
+		return nil, 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get sessions", 500)
+	}
+	if len(items) == 0 {
+		items = []*models.ToothBrushSession{}
+	}
+
+	buf, _ := json.Marshal(map[string]any{
+		"data":  items,
+		"total": total,
+	})
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return items, total, nil
+}
+
+// GetByIdSession implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) GetByIdSession(ctx context.Context, sessionId uuid.UUID) (*models.ToothBrushSession, error) {
+	cacheKey := fmt.Sprintf("toothbrush_session:%s", sessionId)
+	if cached, err := configs.GetRedis(ctx, cacheKey); err == nil && len(cached) > 0 {
+		var session models.ToothBrushSession
+		if json.Unmarshal([]byte(cached), &session) == nil {
+			return &session, nil
+		}
+	}
+
+	session, err := t.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+
+	buf, _ := json.Marshal(session)
+	_ = configs.SetRedis(ctx, cacheKey, buf, time.Minute*30)
+
+	return session, nil
+}
+
+// UpdateSession implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) UpdateSession(ctx context.Context, sessionId uuid.UUID, req toothbrushsessionrequest.UpdateToothBrushSessionRequest) error {
+	session, err := t.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+	before := *session
+
+	// kode tercatat di log & alarm siswa, sehingga tidak boleh diganti setelah dibuat
+	if code := strings.ToUpper(strings.TrimSpace(req.Code)); code != "" && code != session.Code {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "session code cannot be changed after creation", 400)
+	}
+	if req.Name != "" {
+		session.Name = req.Name
+	}
+	if req.StartTime != "" {
+		session.StartTime = req.StartTime
+	}
+	if req.EndTime != "" {
+		session.EndTime = req.EndTime
+	}
+	if req.GraceMinutes != nil {
+		session.GraceMinutes = *req.GraceMinutes
+	}
+	if req.IsActive != nil {
+		session.IsActive = *req.IsActive
+	}
+
+	start, end, err := validateWindow(session.StartTime, session.EndTime, session.GraceMinutes)
+	if err != nil {
+		return err
+	}
+	session.StartTime = start
+	session.EndTime = end
+
+	if err := t.sessionRepo.Update(ctx, sessionId, session); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to update session", 500)
+	}
+
+	t.invalidateCacheSession(ctx)
+	t.audit.Record(ctx, audit.ActionUpdate, audit.EntityToothBrushSession, sessionId.String(), before, session)
+
+	return nil
+}
+
+// DeleteSession implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) DeleteSession(ctx context.Context, sessionId uuid.UUID) error {
+	session, err := t.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+
+	// sesi yang sudah punya log hanya bisa dinonaktifkan agar progres & streak siswa tetap utuh
+	logCount, err := t.sessionRepo.CountLogsByCode(ctx, session.Code)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to check session logs", 500)
+	}
+	if logCount > 0 {
+		return errorresponse.NewCustomError(errorresponse.ErrExists, "session already has tooth brush logs, deactivate it instead", 409)
+	}
+
+	if err := t.sessionRepo.Delete(ctx, sessionId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete session", 500)
+	}
+
+	t.invalidateCacheSession(ctx)
+	t.audit.Record(ctx, audit.ActionDelete, audit.EntityToothBrushSession, sessionId.String(), session, nil)
+
+	return nil
+}
+
+// UpsertClassOverride implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) UpsertClassOverride(ctx context.Context, sessionId uuid.UUID, req toothbrushsessionrequest.UpsertClassOverrideRequest) error {
+	session, err := t.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+
+	if req.ClassID == uuid.Nil {
+		return errorresponse.NewCustomError(errorresponse.ErrBadRequest, "class is required", 400)
+	}
+	class, err := t.classRepo.FindById(ctx, req.ClassID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "class not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class", 500)
+	}
+
+	grace := session.GraceMinutes
+	if req.GraceMinutes != nil {
+		grace = *req.GraceMinutes
+	}
+	start, end, err := validateWindow(req.StartTime, req.EndTime, grace)
+	if err != nil {
+		return err
+	}
+
+	override := &models.ToothBrushSessionOverride{
+		ID:           uuid.New(),
+		SessionID:    session.ID,
+		ClassID:      class.ID,
+		StartTime:    start,
+		EndTime:      end,
+		GraceMinutes: req.GraceMinutes,
+	}
+
+	var before any
+	if existing, err := t.sessionRepo.FindOverride(ctx, session.ID, class.ID); err == nil {
+		before = overrideAuditData(existing)
+	}
+
+	if err := t.sessionRepo.UpsertOverride(ctx, override); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to save class override", 500)
+	}
+
+	t.invalidateCacheSession(ctx)
+	t.audit.Record(ctx, audit.ActionUpdate, audit.EntityToothBrushOverride, sessionId.String(), before, overrideAuditData(override))
+
+	return nil
+}
+
+// DeleteClassOverride implements IToothBrushSessionService.
+func (t *ToothBrushSessionServiceImpl) DeleteClassOverride(ctx context.Context, sessionId uuid.UUID, classId uuid.UUID) error {
+	override, err := t.sessionRepo.FindOverride(ctx, sessionId, classId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "class override not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get class override", 500)
+	}
+
+	if err := t.sessionRepo.DeleteOverride(ctx, sessionId, classId); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to delete class override", 500)
+	}
+
+	t.invalidateCacheSession(ctx)
+	t.audit.Record(ctx, audit.ActionDelete, audit.EntityToothBrushOverride, sessionId.String(), overrideAuditData(override), nil)
+
+	return nil
+}
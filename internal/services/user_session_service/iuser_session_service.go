@@ -0,0 +1,26 @@
+package usersessionservice
+
+import (
+	"context"
+	sessionrequest "giat-cerika-service/internal/dto/request/session_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	"giat-cerika-service/internal/models"
+	"giat-cerika-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type IUserSessionService interface {
+	// CreateSession membuat sesi perangkat baru setelah login berhasil dan menerbitkan pasangan token.
+	CreateSession(ctx context.Context, user *models.User, userAgent, ipAddress string) (*sessionresponse.TokenResponse, error)
+	Refresh(ctx context.Context, req sessionrequest.RefreshTokenRequest) (*sessionresponse.TokenResponse, error)
+	GetMySessions(ctx context.Context, userId uuid.UUID) ([]*models.UserSession, error)
+	RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	// RevokeAllSessions mencabut semua sesi aktif user; exceptSessionId (opsional) tetap dipertahankan.
+	RevokeAllSessions(ctx context.Context, userId uuid.UUID, exceptSessionId *uuid.UUID) (int, error)
+	// RevokeToken mencabut satu access token berdasarkan jti sampai token tersebut kedaluwarsa.
+	RevokeToken(ctx context.Context, claims *utils.JWTClaims) error
+	// RevokeUserTokens menaikkan epoch token user sehingga seluruh token yang sudah terbit ditolak,
+	// sekaligus mencabut semua sesi perangkatnya.
+	RevokeUserTokens(ctx context.Context, userId uuid.UUID, reason string) error
+}
@@ -0,0 +1,253 @@
+package usersessionservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"giat-cerika-service/configs"
+	sessionrequest "giat-cerika-service/internal/dto/request/session_request"
+	sessionresponse "giat-cerika-service/internal/dto/response/session_response"
+	"giat-cerika-service/internal/models"
+	usersessionrepo "giat-cerika-service/internal/repositories/user_session_repo"
+	errorresponse "giat-cerika-service/pkg/constant/error_response"
+	"giat-cerika-service/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	refreshTokenBytes = 32
+
+	RevokeReasonUser       = "revoked_by_user"
+	RevokeReasonTokenReuse = "refresh_token_reuse"
+	RevokeReasonDisabled   = "account_disabled"
+	RevokeReasonPassword   = "password_changed"
+)
+
+type UserSessionServiceImpl struct {
+	sessionRepo usersessionrepo.IUserSessionRepository
+	rdb         *redis.Client
+}
+
+func NewUserSessionServiceImpl(sessionRepo usersessionrepo.IUserSessionRepository, rdb *redis.Client) IUserSessionService {
+	return &UserSessionServiceImpl{sessionRepo: sessionRepo, rdb: rdb}
+}
+
+// refresh token berbentuk "<session_id>.<secret>" agar sesi bisa dicari tanpa menyimpan token mentah.
+func splitRefreshToken(token string) (uuid.UUID, string, bool) {
+	idPart, secret, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || secret == "" {
+		return uuid.Nil, "", false
+	}
+	sessionId, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	return sessionId, secret, true
+}
+
+func (u *UserSessionServiceImpl) issueTokens(user *models.User, sessionId uuid.UUID, secret string) (*sessionresponse.TokenResponse, error) {
+	accessToken, err := utils.GenerateToken(user.ID.String(), user.Role.Name, sessionId.String(), user.TokenEpoch)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate token", 500)
+	}
+
+	return &sessionresponse.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: fmt.Sprintf("%s.%s", sessionId, secret),
+		TokenType:    "Bearer",
+		ExpiresIn:    int(utils.AccessTokenTTL.Seconds()),
+		SessionID:    sessionId,
+	}, nil
+}
+
+// markRevoked menandai sesi di Redis agar access token yang masih berlaku langsung ditolak middleware.
+func (u *UserSessionServiceImpl) markRevoked(ctx context.Context, sessionIds []uuid.UUID) {
+	for _, id := range sessionIds {
+		_ = configs.SetRedis(ctx, utils.SessionRevokedKey(id.String()), "revoked", utils.AccessTokenTTL)
+	}
+}
+
+// CreateSession implements IUserSessionService.
+func (u *UserSessionServiceImpl) CreateSession(ctx context.Context, user *models.User, userAgent, ipAddress string) (*sessionresponse.TokenResponse, error) {
+	secret, err := utils.GenerateOpaqueToken(refreshTokenBytes)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate refresh token", 500)
+	}
+
+	now := time.Now()
+	session := &models.UserSession{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RefreshTokenHash: utils.HashToken(secret),
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(utils.RefreshTokenTTL),
+	}
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to create session", 500)
+	}
+
+	return u.issueTokens(user, session.ID, secret)
+}
+
+// Refresh implements IUserSessionService.
+func (u *UserSessionServiceImpl) Refresh(ctx context.Context, req sessionrequest.RefreshTokenRequest) (*sessionresponse.TokenResponse, error) {
+	sessionId, secret, ok := splitRefreshToken(req.RefreshToken)
+	if !ok {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "invalid refresh token", 401)
+	}
+
+	session, err := u.sessionRepo.FindByID(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "invalid refresh token", 401)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+
+	if session.RevokedAt != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "session has been revoked", 401)
+	}
+	if session.User.Status != 1 {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "account is disabled", 401)
+	}
+
+	now := time.Now()
+	if now.After(session.ExpiresAt) || now.After(session.LastSeenAt.Add(utils.RefreshIdleTTL)) {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "session expired, please login again", 401)
+	}
+
+	presentedHash := utils.HashToken(secret)
+	if presentedHash != session.RefreshTokenHash {
+		// Refresh token lama dipakai lagi: kemungkinan token dicuri, seluruh sesi perangkat ini dicabut.
+		if session.PreviousRefreshHash != "" && presentedHash == session.PreviousRefreshHash {
+			if err := u.sessionRepo.Revoke(ctx, []uuid.UUID{session.ID}, RevokeReasonTokenReuse); err != nil {
+				return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke session", 500)
+			}
+			u.markRevoked(ctx, []uuid.UUID{session.ID})
+			return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "refresh token reuse detected, session revoked", 401)
+		}
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "invalid refresh token", 401)
+	}
+
+	newSecret, err := utils.GenerateOpaqueToken(refreshTokenBytes)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to generate refresh token", 500)
+	}
+
+	rotated, err := u.sessionRepo.RotateRefreshToken(ctx, session.ID, presentedHash, utils.HashToken(newSecret), req.UserAgent, req.IPAddress, now)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to rotate refresh token", 500)
+	}
+	if !rotated {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrUnauthorized, "refresh token already used", 401)
+	}
+
+	return u.issueTokens(&session.User, session.ID, newSecret)
+}
+
+// GetMySessions implements IUserSessionService.
+func (u *UserSessionServiceImpl) GetMySessions(ctx context.Context, userId uuid.UUID) ([]*models.UserSession, error) {
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, userId)
+	if err != nil {
+		return nil, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get sessions", 500)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession implements IUserSessionService.
+func (u *UserSessionServiceImpl) RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+		}
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get session", 500)
+	}
+	if session.UserID != userId {
+		return errorresponse.NewCustomError(errorresponse.ErrNotFound, "session not found", 404)
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := u.sessionRepo.Revoke(ctx, []uuid.UUID{session.ID}, RevokeReasonUser); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke session", 500)
+	}
+	u.markRevoked(ctx, []uuid.UUID{session.ID})
+
+	return nil
+}
+
+// RevokeAllSessions implements IUserSessionService.
+func (u *UserSessionServiceImpl) RevokeAllSessions(ctx context.Context, userId uuid.UUID, exceptSessionId *uuid.UUID) (int, error) {
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, userId)
+	if err != nil {
+		return 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get sessions", 500)
+	}
+
+	ids := make([]uuid.UUID, 0, len(sessions))
+	for _, s := range sessions {
+		if exceptSessionId != nil && s.ID == *exceptSessionId {
+			continue
+		}
+		ids = append(ids, s.ID)
+	}
+
+	if err := u.sessionRepo.Revoke(ctx, ids, RevokeReasonUser); err != nil {
+		return 0, errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke sessions", 500)
+	}
+	u.markRevoked(ctx, ids)
+
+	return len(ids), nil
+}
+
+// RevokeToken implements IUserSessionService.
+func (u *UserSessionServiceImpl) RevokeToken(ctx context.Context, claims *utils.JWTClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := configs.SetRedis(ctx, utils.RevokedTokenKey(claims.ID), "revoked", ttl); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke token", 500)
+	}
+
+	return nil
+}
+
+// RevokeUserTokens implements IUserSessionService.
+func (u *UserSessionServiceImpl) RevokeUserTokens(ctx context.Context, userId uuid.UUID, reason string) error {
+	epoch, err := u.sessionRepo.IncrementTokenEpoch(ctx, userId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke user tokens", 500)
+	}
+	if err := configs.SetRedis(ctx, utils.TokenEpochKey(userId.String()), epoch, utils.TokenEpochCacheTTL); err != nil {
+		// Cache lama bisa membuat token lama lolos sampai TTL habis, jadi hapus agar dibaca ulang dari DB.
+		_ = configs.DeleteRedis(ctx, utils.TokenEpochKey(userId.String()))
+	}
+
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, userId)
+	if err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get sessions", 500)
+	}
+	ids := make([]uuid.UUID, len(sessions))
+	for i, s := range sessions {
+		ids[i] = s.ID
+	}
+	if err := u.sessionRepo.Revoke(ctx, ids, reason); err != nil {
+		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to revoke sessions", 500)
+	}
+
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	videorequest "giat-cerika-service/internal/dto/request/video_request"
 	"giat-cerika-service/internal/models"
 	videorepo "giat-cerika-service/internal/repositories/video_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/audit"
 	errorresponse "giat-cerika-service/pkg/constant/error_response"
 	"strings"
 	"time"
@@ -20,11 +22,12 @@ import (
 
 type VideoServiceImpl struct {
 	videoRepo videorepo.IVideoRepository
+	audit     auditservice.IAuditService
 	rdb       *redis.Client
 }
 
-func NewVideoServiceImpl(videoRepo videorepo.IVideoRepository, rdb *redis.Client) IVideoService {
-	return &VideoServiceImpl{videoRepo: videoRepo, rdb: rdb}
+func NewVideoServiceImpl(videoRepo videorepo.IVideoRepository, auditService auditservice.IAuditService, rdb *redis.Client) IVideoService {
+	return &VideoServiceImpl{videoRepo: videoRepo, audit: auditService, rdb: rdb}
 }
 
 func (c *VideoServiceImpl) invalidateCacheVideo(ctx context.Context) {
@@ -79,6 +82,7 @@ func (c *VideoServiceImpl) CreateVideo(
 	}
 
 	c.invalidateCacheVideo(ctx)
+	c.audit.Record(ctx, audit.ActionCreate, audit.EntityVideo, newVideo.ID.String(), nil, newVideo)
 	return nil
 }
 
@@ -146,6 +150,7 @@ func (c *VideoServiceImpl) UpdateVideo(ctx context.Context, videoId uuid.UUID, r
 		}
 		return errorresponse.NewCustomError(errorresponse.ErrInternal, "failed to get video", 500)
 	}
+	before := *video
 
 	existsVideo, err := c.videoRepo.FindByTitle(ctx, req.Title)
 	if err == nil && existsVideo.ID != videoId {
@@ -165,13 +170,14 @@ func (c *VideoServiceImpl) UpdateVideo(ctx context.Context, videoId uuid.UUID, r
 	}
 
 	c.invalidateCacheVideo(ctx)
+	c.audit.Record(ctx, audit.ActionUpdate, audit.EntityVideo, videoId.String(), before, video)
 
 	return nil
 }
 
 // DeleteVideo implements IVideoService.
 func (c *VideoServiceImpl) DeleteVideo(ctx context.Context, videoId uuid.UUID) error {
-	_, err := c.videoRepo.FindById(ctx, videoId)
+	video, err := c.videoRepo.FindById(ctx, videoId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errorresponse.NewCustomError(errorresponse.ErrNotFound, "video not found", 404)
@@ -185,6 +191,7 @@ func (c *VideoServiceImpl) DeleteVideo(ctx context.Context, videoId uuid.UUID) e
 	}
 
 	c.invalidateCacheVideo(ctx)
+	c.audit.Record(ctx, audit.ActionDelete, audit.EntityVideo, videoId.String(), video, nil)
 
 	return nil
 }
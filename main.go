@@ -4,6 +4,7 @@ import (
 	"giat-cerika-service/configs"
 	datasources "giat-cerika-service/internal/dataSources"
 	"giat-cerika-service/pkg/workers/producer"
+	"giat-cerika-service/pkg/workers/scheduler"
 	"giat-cerika-service/routes"
 	"log"
 	"net/http"
@@ -24,6 +25,19 @@ func main() {
 
 	e := echo.New()
 
+	// IP klien dipakai rate limiter, batas percobaan login/reset/link dan audit log, sehingga
+	// X-Forwarded-For hanya dipercaya dari proxy di TRUSTED_PROXIES; tanpa itu pakai IP koneksi.
+	if trusted := configs.GetTrustedProxies(); len(trusted) > 0 {
+		// selain loopback, hanya rentang yang didaftarkan yang dipercaya (bukan seluruh jaringan privat)
+		options := []echo.TrustOption{echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+		for _, ipNet := range trusted {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(options...)
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+
 	// ================================================================
 	// MIDDLEWARE STACK — urutan penting!
 	// ================================================================
@@ -104,6 +118,7 @@ func main() {
 	defer configs.CloseConnections()
 
 	go producer.StartWorker()
+	go scheduler.StartAlarmScheduler()
 
 	routes.Routes(e, db, rdb, &cloudinarySvc)
 
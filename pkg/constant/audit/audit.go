@@ -0,0 +1,41 @@
+package audit
+
+const (
+	ActionCreate        = "create"
+	ActionUpdate        = "update"
+	ActionDelete        = "delete"
+	ActionUpdateStatus  = "update_status"
+	ActionImport        = "import"
+	ActionSend          = "send"
+	ActionReview        = "review"
+	ActionResetPassword = "reset_password"
+	ActionRevoke        = "revoke"
+	ActionUnlock        = "unlock"
+	ActionLink          = "link"
+	ActionUnlink        = "unlink"
+)
+
+const (
+	EntityQuiz               = "quiz"
+	EntityQuizType           = "quiz_type"
+	EntityQuizPair           = "quiz_pair"
+	EntityQuestion           = "question"
+	EntityMaterial           = "material"
+	EntityVideo              = "video"
+	EntityClass              = "class"
+	EntityRole               = "role"
+	EntityPermission         = "permission"
+	EntityRolePermission     = "role_permission"
+	EntityPrediction         = "prediction"
+	EntityQuestionnaire      = "questionnaire"
+	EntityQuestionnaireItem  = "questionnaire_item"
+	EntityToothBrushSession  = "toothbrush_session"
+	EntityToothBrushOverride = "toothbrush_session_override"
+	EntityToothBrushLog      = "toothbrush_log"
+	EntityStudent            = "student"
+	EntityAdminInvite        = "admin_invite"
+	EntityAPIKey             = "api_key"
+	EntityLoginLockout       = "login_lockout"
+	EntityGuardianLink       = "guardian_link"
+	EntityGuardianLinkCode   = "guardian_link_code"
+)
@@ -0,0 +1,102 @@
+package permission
+
+const (
+	RoleManage    = "role:manage"
+	AdminInvite   = "admin:invite"
+	AccountUnlock = "account:unlock"
+	AuditRead     = "audit:read"
+	APIKeyManage  = "api_key:manage"
+	DashboardView = "dashboard:access"
+
+	ClassRead  = "class:read"
+	ClassWrite = "class:write"
+
+	StudentRead      = "student:read"
+	StudentWrite     = "student:write"
+	StudentProfile   = "student:profile"
+	ToothBrushReview = "toothbrush:review"
+	ToothBrushLog    = "toothbrush:log"
+
+	ToothBrushSessionRead  = "toothbrush_session:read"
+	ToothBrushSessionWrite = "toothbrush_session:write"
+
+	MaterialRead  = "material:read"
+	MaterialWrite = "material:write"
+	VideoRead     = "video:read"
+	VideoWrite    = "video:write"
+
+	QuizRead       = "quiz:read"
+	QuizWrite      = "quiz:write"
+	QuizTake       = "quiz:take"
+	QuizResultRead = "quiz_result:read"
+
+	PredictionRead    = "prediction:read"
+	PredictionWrite   = "prediction:write"
+	PredictionReadOwn = "prediction:read_own"
+
+	QuestionnaireRead       = "questionnaire:read"
+	QuestionnaireWrite      = "questionnaire:write"
+	QuestionnaireRespond    = "questionnaire:respond"
+	QuestionnaireResultRead = "questionnaire_result:read"
+
+	GuardianAccess = "guardian:access"
+)
+
+// Definition adalah permission bawaan beserta role yang otomatis mendapatkannya saat pertama kali dibuat.
+type Definition struct {
+	Code         string
+	Description  string
+	DefaultRoles []string
+}
+
+// Catalog di-seed saat migrasi. Permission yang sudah ada tidak diubah sehingga pengaturan admin tetap terjaga.
+var Catalog = []Definition{
+	{RoleManage, "Kelola role dan permission", []string{"admin"}},
+	{AdminInvite, "Kelola undangan akun admin/staf", []string{"admin"}},
+	{AccountUnlock, "Lihat & buka kunci login akibat percobaan gagal", []string{"admin"}},
+	{AuditRead, "Lihat audit log perubahan data", []string{"admin"}},
+	{APIKeyManage, "Kelola API key integrasi sistem sekolah", []string{"admin"}},
+	{DashboardView, "Akses profil & dashboard staf", []string{"admin", "teacher"}},
+	{ClassRead, "Lihat data kelas", []string{"admin", "teacher"}},
+	{ClassWrite, "Kelola data kelas", []string{"admin"}},
+	{StudentRead, "Lihat data siswa", []string{"admin", "teacher"}},
+	{StudentWrite, "Kelola akun siswa (impor, status)", []string{"admin"}},
+	{StudentProfile, "Akses profil & akun siswa sendiri", []string{"student"}},
+	{ToothBrushReview, "Review bukti foto sikat gigi", []string{"admin"}},
+	{ToothBrushLog, "Catat sikat gigi & alarm pribadi", []string{"student"}},
+	{ToothBrushSessionRead, "Lihat jadwal sesi sikat gigi", []string{"admin"}},
+	{ToothBrushSessionWrite, "Kelola jadwal sesi sikat gigi", []string{"admin"}},
+	{MaterialRead, "Lihat materi (panel admin)", []string{"admin"}},
+	{MaterialWrite, "Kelola materi", []string{"admin"}},
+	{VideoRead, "Lihat video (panel admin)", []string{"admin"}},
+	{VideoWrite, "Kelola video", []string{"admin"}},
+	{QuizRead, "Lihat quiz, tipe quiz, soal & pasangan quiz", []string{"admin", "teacher"}},
+	{QuizWrite, "Kelola quiz, tipe quiz, soal & pasangan quiz", []string{"admin"}},
+	{QuizTake, "Mengerjakan quiz & melihat riwayat sendiri", []string{"student"}},
+	{QuizResultRead, "Lihat hasil, analisis & ekspor quiz", []string{"admin", "teacher"}},
+	{PredictionRead, "Lihat hasil prediksi", []string{"admin", "teacher"}},
+	{PredictionWrite, "Kelola & kirim hasil prediksi", []string{"admin"}},
+	{PredictionReadOwn, "Lihat hasil prediksi sendiri", []string{"student"}},
+	{QuestionnaireRead, "Lihat kuesioner", []string{"admin"}},
+	{QuestionnaireWrite, "Kelola kuesioner", []string{"admin"}},
+	{QuestionnaireRespond, "Mengisi kuesioner", []string{"student"}},
+	{QuestionnaireResultRead, "Lihat hasil kuesioner", []string{"admin"}},
+	{GuardianAccess, "Akses orang tua/wali ke data anak yang terhubung", []string{"guardian"}},
+}
+
+// APIKeyScopes adalah permission yang boleh diberikan ke API key integrasi: menarik data siswa,
+// kelas dan hasil quiz, serta mengirim perubahan kelas. Permission lain hanya untuk akun manusia.
+var APIKeyScopes = []string{
+	ClassRead,
+	ClassWrite,
+	StudentRead,
+	QuizResultRead,
+}
+
+// CacheKeyPattern dipakai untuk menghapus seluruh cache permission role setelah ada perubahan.
+const CacheKeyPattern = "role_permissions:*"
+
+// CacheKey adalah key Redis daftar kode permission milik sebuah role (berdasarkan nama role di JWT).
+func CacheKey(roleName string) string {
+	return "role_permissions:" + roleName
+}
@@ -5,4 +5,6 @@ const (
 	SendImageProfileAdminQueueName   = "image_profile_admin.queue"
 	SendImageMateriQueueName         = "image_materi.queue"
 	SendImageQuestionQueueName       = "image_question.queue"
+	SendAlarmReminderQueueName       = "alarm_reminder.queue"
+	SendImageToothBrushQueueName     = "image_toothbrush.queue"
 )
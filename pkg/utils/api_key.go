@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// APIKeyHeader adalah header yang dibaca middleware untuk autentikasi integrasi sistem sekolah.
+	APIKeyHeader = "X-API-Key"
+	// APIKeyTokenPrefix memudahkan mengenali key milik layanan ini bila bocor di log atau repo.
+	APIKeyTokenPrefix = "gck_"
+	// APIKeyCacheTTL membatasi berapa lama hasil lookup key disimpan di Redis; revoke menghapus cache-nya langsung.
+	APIKeyCacheTTL = 5 * time.Minute
+	// APIKeyLastUsedInterval membatasi penulisan last_used_at agar tidak terjadi update di setiap request.
+	APIKeyLastUsedInterval = time.Minute
+
+	apiKeyContextKey = "api_key"
+)
+
+// APIKeyPrincipal adalah identitas API key yang lolos autentikasi, disimpan di echo context.
+type APIKeyPrincipal struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// APIKeyCacheKey adalah key Redis cache principal berdasarkan hash API key.
+func APIKeyCacheKey(keyHash string) string {
+	return fmt.Sprintf("api_key:%s", keyHash)
+}
+
+// APIKeyLastUsedKey adalah penanda Redis bahwa last_used_at key ini baru saja diperbarui.
+func APIKeyLastUsedKey(keyId uuid.UUID) string {
+	return fmt.Sprintf("api_key_used:%s", keyId)
+}
+
+func SetAPIKeyToContext(c echo.Context, principal *APIKeyPrincipal) {
+	c.Set(apiKeyContextKey, principal)
+}
+
+func GetAPIKeyFromContext(c echo.Context) (*APIKeyPrincipal, bool) {
+	principal, ok := c.Get(apiKeyContextKey).(*APIKeyPrincipal)
+	return principal, ok && principal != nil
+}
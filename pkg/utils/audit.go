@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+type auditActorKey struct{}
+
+// AuditActor adalah identitas pelaku request yang disisipkan JWTMiddleware ke context.
+type AuditActor struct {
+	UserID    string
+	Role      string
+	IPAddress string
+}
+
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func AuditActorFromContext(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor, ok
+}
+
+// auditIgnoredFields tidak pernah disimpan (rahasia) atau tidak bermakna sebagai perubahan.
+var auditIgnoredFields = map[string]bool{
+	"password":           true,
+	"token_hash":         true,
+	"refresh_token_hash": true,
+	"created_at":         true,
+	"updated_at":         true,
+}
+
+func auditFields(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return map[string]any{"value": json.RawMessage(raw)}
+	}
+	for key := range fields {
+		if auditIgnoredFields[key] {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
+
+// AuditDiff mengubah data sebelum/sesudah menjadi JSON. Bila keduanya ada, hanya field yang
+// berbeda yang disimpan sehingga log mudah dibaca; nil berarti entitas baru dibuat / dihapus.
+func AuditDiff(before, after any) (json.RawMessage, json.RawMessage) {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	if beforeFields != nil && afterFields != nil {
+		for key, oldValue := range beforeFields {
+			if newValue, ok := afterFields[key]; ok && reflect.DeepEqual(oldValue, newValue) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	return marshalAuditFields(beforeFields), marshalAuditFields(afterFields)
+}
+
+func marshalAuditFields(fields map[string]any) json.RawMessage {
+	if fields == nil {
+		return nil
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
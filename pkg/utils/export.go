@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteCSV menulis header dan baris data sebagai CSV langsung ke writer.
+func WriteCSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteXLSX menulis header dan baris data ke satu sheet XLSX memakai stream writer excelize.
+func WriteXLSX(w io.Writer, sheet string, headers []string, rows [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return err
+	}
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	writeRow := func(index int, values []string) error {
+		cells := make([]any, len(values))
+		for i, v := range values {
+			cells[i] = v
+		}
+		cell, err := excelize.CoordinatesToCellName(1, index)
+		if err != nil {
+			return err
+		}
+		return sw.SetRow(cell, cells)
+	}
+
+	if err := writeRow(1, headers); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		if err := writeRow(i+2, row); err != nil {
+			return err
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
@@ -0,0 +1,29 @@
+package utils
+
+const (
+	GainCategoryHigh      = "high"
+	GainCategoryMedium    = "medium"
+	GainCategoryLow       = "low"
+	GainCategoryUndefined = "undefined"
+)
+
+// NormalizedGain menghitung gain ternormalisasi Hake: (post - pre) / (max - pre).
+// ok bernilai false bila pre sudah maksimal sehingga gain tidak terdefinisi.
+func NormalizedGain(pre, post, max float64) (gain float64, ok bool) {
+	if max-pre <= 0 {
+		return 0, false
+	}
+	return (post - pre) / (max - pre), true
+}
+
+// GainCategory mengelompokkan gain: tinggi (g >= 0.7), sedang (0.3 <= g < 0.7), rendah (g < 0.3).
+func GainCategory(gain float64) string {
+	switch {
+	case gain >= 0.7:
+		return GainCategoryHigh
+	case gain >= 0.3:
+		return GainCategoryMedium
+	default:
+		return GainCategoryLow
+	}
+}
@@ -1,13 +1,45 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+    "crypto/rand"
+    "math/big"
+
+    "golang.org/x/crypto/bcrypt"
+)
 
 func HashPassword(password string) (string, error) {
     bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
     return string(bytes), err
 }
 
+// temporaryPasswordCost lebih ringan dari HashPassword; hanya untuk password sekali pakai
+// (mis. hasil impor massal) yang wajib diganti siswa saat login pertama.
+const temporaryPasswordCost = bcrypt.DefaultCost
+
+// HashTemporaryPassword meng-hash password sementara dengan cost yang lebih rendah.
+func HashTemporaryPassword(password string) (string, error) {
+    bytes, err := bcrypt.GenerateFromPassword([]byte(password), temporaryPasswordCost)
+    return string(bytes), err
+}
+
 func CheckPasswordHash(password, hash string) bool {
     err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
     return err == nil
-}
\ No newline at end of file
+}
+
+// passwordAlphabet tanpa karakter yang mudah tertukar (0/O, 1/l/I) agar mudah dicetak & diketik siswa.
+const passwordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateRandomPassword membuat password acak sepanjang length memakai crypto/rand.
+func GenerateRandomPassword(length int) (string, error) {
+    buf := make([]byte, length)
+    max := big.NewInt(int64(len(passwordAlphabet)))
+    for i := range buf {
+        n, err := rand.Int(rand.Reader, max)
+        if err != nil {
+            return "", err
+        }
+        buf[i] = passwordAlphabet[n.Int64()]
+    }
+    return string(buf), nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadCSV membaca seluruh baris CSV; jumlah kolom per baris boleh berbeda.
+func ReadCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+// ReadXLSX membaca seluruh baris pada sheet pertama file XLSX.
+func ReadXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("file xlsx tidak memiliki sheet")
+	}
+	return f.GetRows(sheets[0])
+}
+
+// ParseImportDate mem-parsing tanggal dari file impor, termasuk nomor seri tanggal Excel.
+func ParseImportDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	formats := []string{
+		"2006-01-02",
+		"02-01-2006",
+		"02/01/2006",
+		"2006/01/02",
+		"2/1/2006",
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+	}
+	for _, f := range formats {
+		if t, err := time.Parse(f, value); err == nil {
+			return t, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		return excelize.ExcelDateToTime(serial, false)
+	}
+	return time.Time{}, errors.New("format tanggal tidak valid")
+}
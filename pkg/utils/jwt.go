@@ -1,24 +1,45 @@
 package utils
 
 import (
+	"fmt"
 	"giat-cerika-service/configs"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// AccessTokenTTL sengaja pendek; klien memperpanjang sesi lewat refresh token.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL adalah umur maksimal satu sesi perangkat sejak login.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	// RefreshIdleTTL mengakhiri sesi yang tidak di-refresh selama periode ini (mis. tablet bersama).
+	RefreshIdleTTL = 7 * 24 * time.Hour
+	// TokenEpochCacheTTL adalah lama cache epoch token per user di Redis sebelum dibaca ulang dari DB.
+	TokenEpochCacheTTL = 30 * time.Minute
 )
 
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	// Epoch adalah users.token_epoch saat token diterbitkan; token dengan epoch lebih kecil dianggap dicabut.
+	Epoch int `json:"epoch"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID string, role string) (string, error) {
+func GenerateToken(userID string, role string, sessionID string, epoch int) (string, error) {
+	now := time.Now()
 	claims := JWTClaims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		Epoch:     epoch,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -36,3 +57,19 @@ func GetExpiryFromToken(tokenStr string) (time.Time, error) {
 
 	return time.Time{}, err
 }
+
+// SessionRevokedKey adalah key Redis penanda sesi yang dicabut, dicek JWTMiddleware
+// selama access token sesi tersebut masih mungkin berlaku.
+func SessionRevokedKey(sessionID string) string {
+	return fmt.Sprintf("session_revoked:%s", sessionID)
+}
+
+// RevokedTokenKey adalah key Redis penanda access token (berdasarkan jti) yang dicabut.
+func RevokedTokenKey(jti string) string {
+	return fmt.Sprintf("revoked_jti:%s", jti)
+}
+
+// TokenEpochKey adalah key Redis cache users.token_epoch.
+func TokenEpochKey(userID string) string {
+	return fmt.Sprintf("token_epoch:%s", userID)
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "math"
+
+// Round2 membulatkan nilai ke dua angka di belakang koma.
+func Round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
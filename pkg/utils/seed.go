@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"encoding/binary"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// SeededRand membuat generator acak deterministik dari sebuah UUID (mis. id sesi quiz),
+// sehingga hasil acak bisa direproduksi. salt membedakan kegunaan dengan seed yang sama.
+func SeededRand(id uuid.UUID, salt string) *rand.Rand {
+	seed := int64(binary.BigEndian.Uint64(id[:8]) ^ binary.BigEndian.Uint64(id[8:]))
+	for _, c := range salt {
+		seed = seed*31 + int64(c)
+	}
+	return rand.New(rand.NewSource(seed))
+}
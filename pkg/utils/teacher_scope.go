@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RoleTeacher adalah role wali kelas; data siswa yang terlihat dibatasi ke kelas yang diampunya.
+const RoleTeacher = "teacher"
+
+// TeacherScopeFromContext mengembalikan ID guru bila request berasal dari akun teacher.
+// Nil berarti tidak ada pembatasan kelas (mis. admin).
+func TeacherScopeFromContext(ctx context.Context) *uuid.UUID {
+	actor, ok := AuditActorFromContext(ctx)
+	if !ok || actor.Role != RoleTeacher {
+		return nil
+	}
+
+	teacherId, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		// ID tidak valid tetap dibatasi agar tidak bocor ke seluruh data sekolah.
+		teacherId = uuid.Nil
+	}
+	return &teacherId
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ParseLogDate mengubah nilai kolom log_date (RFC3339 dari driver atau YYYY-MM-DD) menjadi time.Time.
+func ParseLogDate(dateStr string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, dateStr); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", dateStr)
+}
+
+// ComplianceRate menghitung persentase (2 desimal) sesi yang tercatat dibanding sesi yang seharusnya.
+func ComplianceRate(logged, expected int) float64 {
+	if expected <= 0 {
+		return 0
+	}
+	return math.Round(float64(logged)/float64(expected)*10000) / 100
+}
+
+// CalculateStreaks menghitung streak berjalan dan streak terpanjang dari tanggal-tanggal (YYYY-MM-DD)
+// yang sesinya lengkap. Hari ini yang belum lengkap tidak memutus streak berjalan.
+func CalculateStreaks(completeDays map[string]bool, today time.Time) (current int, longest int) {
+	dates := make([]time.Time, 0, len(completeDays))
+	for d, ok := range completeDays {
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse("2006-01-02", d); err == nil {
+			dates = append(dates, t)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	run := 0
+	for i, d := range dates {
+		if i > 0 && d.Sub(dates[i-1]) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !completeDays[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for completeDays[day.Format("2006-01-02")] {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return current, longest
+}
+
+// ClockToMinutes mengubah jam "HH:MM" menjadi jumlah menit sejak 00:00.
+func ClockToMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// CheckInWindow mengecek apakah menit (sejak 00:00) berada dalam jendela start–end (inklusif).
+// Absen setelah end namun masih dalam graceMinutes tetap diterima dengan status terlambat.
+func CheckInWindow(minutes, start, end, graceMinutes int) (allowed bool, late bool) {
+	if minutes < start {
+		return false, false
+	}
+	if minutes <= end {
+		return true, false
+	}
+	if minutes <= end+graceMinutes {
+		return true, true
+	}
+	return false, false
+}
@@ -0,0 +1,45 @@
+package handlerconsumer
+
+import (
+	"context"
+	"giat-cerika-service/configs"
+	"giat-cerika-service/internal/models"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	"giat-cerika-service/pkg/workers/payload"
+)
+
+type ToothBrushPhotoHandler struct {
+	repo studentrepo.IStudentRepository
+}
+
+func NewToothBrushPhotoHandler() *ToothBrushPhotoHandler {
+	return &ToothBrushPhotoHandler{
+		repo: studentrepo.NewStudentRepositoryImpl(configs.DB),
+	}
+}
+
+// HandleSingle menempelkan URL foto bukti ke log sikat gigi (payload ID = ID log).
+func (h *ToothBrushPhotoHandler) HandleSingle(ctx context.Context, imageURL string, payloads any) error {
+	p, ok := payloads.(*payload.ImageUploadPayload)
+	if !ok {
+		return nil
+	}
+
+	if err := h.repo.UpdatePhotoTootBrush(ctx, p.ID, imageURL); err != nil {
+		return err
+	}
+
+	// riwayat sikat gigi di-cache, hapus agar URL foto langsung terlihat
+	if configs.RDB != nil {
+		iter := configs.RDB.Scan(ctx, 0, "toothbrush:*", 0).Iterator()
+		for iter.Next(ctx) {
+			configs.RDB.Del(ctx, iter.Val())
+		}
+	}
+
+	return nil
+}
+
+func (h *ToothBrushPhotoHandler) HandleMany(ctx context.Context, image *models.Image, payloads any) error {
+	return nil
+}
@@ -0,0 +1,11 @@
+package payload
+
+import "github.com/google/uuid"
+
+type AlarmReminderPayload struct {
+	AlarmID     uuid.UUID `json:"alarm_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	TimeType    string    `json:"time_type"`    // kode sesi sikat gigi (mis. MORNING, NIGHT)
+	Clock       string    `json:"clock"`        // jam alarm, format HH:MM (Asia/Jakarta)
+	ScheduledAt string    `json:"scheduled_at"` // RFC3339, waktu reminder dipublish
+}
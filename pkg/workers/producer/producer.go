@@ -12,6 +12,7 @@ func StartWorker() {
 	studentImageHandler := handlerconsumer.NewStudentImageHandler()
 	adminPhotoHandler := handlerconsumer.NewAdminPhotoHandler()
 	questionHandler := handlerconsumer.NewQuestionHandler()
+	toothBrushPhotoHandler := handlerconsumer.NewToothBrushPhotoHandler()
 	go consumer.StartImageConsumer(rabbitmq.SendImageProfileStudentQueueName, studentImageHandler, func() any { return &payload.ImageUploadPayload{} })
 	go consumer.StartImageConsumer(rabbitmq.SendImageProfileAdminQueueName, adminPhotoHandler, func() any { return &payload.ImageUploadPayload{} })
 	go consumer.StartImageConsumer(
@@ -24,5 +25,10 @@ func StartWorker() {
 		questionHandler,
 		func() any { return &payload.ImageUploadPayload{} },
 	)
+	go consumer.StartImageConsumer(
+		rabbitmq.SendImageToothBrushQueueName,
+		toothBrushPhotoHandler,
+		func() any { return &payload.ImageUploadPayload{} },
+	)
 	select {}
 }
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"giat-cerika-service/configs"
+	alarmrepo "giat-cerika-service/internal/repositories/alarm_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	rabbitmq "giat-cerika-service/pkg/constant/rabbitMq"
+	"giat-cerika-service/pkg/workers/payload"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// alarmLastMinuteKey menyimpan menit terakhir yang sudah diproses (unix detik).
+	alarmLastMinuteKey = "alarm_scheduler:last_minute"
+	// alarmMaxCatchUp membatasi susulan setelah downtime panjang agar reminder basi tidak terkirim.
+	alarmMaxCatchUp = 30 * time.Minute
+)
+
+type AlarmScheduler struct {
+	alarmRepo   alarmrepo.IAlarmRepository
+	studentRepo studentrepo.IStudentRepository
+	sessionRepo toothbrushsessionrepo.IToothBrushSessionRepository
+	rdb         *redis.Client
+}
+
+func NewAlarmScheduler() *AlarmScheduler {
+	return &AlarmScheduler{
+		alarmRepo:   alarmrepo.NewAlarmRepositoryImpl(configs.DB),
+		studentRepo: studentrepo.NewStudentRepositoryImpl(configs.DB),
+		sessionRepo: toothbrushsessionrepo.NewToothBrushSessionRepositoryImpl(configs.DB),
+		rdb:         configs.RDB,
+	}
+}
+
+// StartAlarmScheduler mengecek alarm setiap menit dan mempublish reminder yang jatuh tempo
+// ke queue alarm_reminder. Dijalankan sebagai goroutine dari main.
+func StartAlarmScheduler() {
+	s := NewAlarmScheduler()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	log.Println("⏰ Alarm scheduler started")
+	s.run(context.Background(), time.Now())
+	for now := range ticker.C {
+		s.run(context.Background(), now)
+	}
+}
+
+// run memproses setiap menit sejak menit terakhir yang tercatat sampai menit sekarang,
+// sehingga tick yang terlambat atau restart tidak melewatkan reminder.
+func (s *AlarmScheduler) run(ctx context.Context, now time.Time) {
+	current := now.Truncate(time.Minute)
+	from := current
+
+	if last, err := s.rdb.Get(ctx, alarmLastMinuteKey).Int64(); err == nil {
+		lastMinute := time.Unix(last, 0)
+		if !lastMinute.Before(current) {
+			return
+		}
+		from = lastMinute.Add(time.Minute)
+		if current.Sub(from) > alarmMaxCatchUp {
+			from = current.Add(-alarmMaxCatchUp)
+		}
+	}
+
+	for minute := from; !minute.After(current); minute = minute.Add(time.Minute) {
+		s.dispatch(ctx, minute)
+	}
+
+	_ = s.rdb.Set(ctx, alarmLastMinuteKey, current.Unix(), 24*time.Hour).Err()
+}
+
+func (s *AlarmScheduler) dispatch(ctx context.Context, now time.Time) {
+	locJakarta, _ := time.LoadLocation("Asia/Jakarta")
+	nowJakarta := now.In(locJakarta)
+	clock := nowJakarta.Format("15:04")
+
+	// hanya sesi aktif yang dikirimi reminder
+	sessions, err := s.sessionRepo.FindActive(ctx)
+	if err != nil {
+		log.Printf("[alarm-scheduler] failed to get active sessions: %v", err)
+		return
+	}
+
+	for _, session := range sessions {
+		timeType := session.Code
+		alarms, err := s.alarmRepo.FindDueAlarms(ctx, timeType, clock)
+		if err != nil {
+			log.Printf("[alarm-scheduler] failed to get due alarms %s %s: %v", timeType, clock, err)
+			continue
+		}
+
+		for _, alarm := range alarms {
+			// Siswa yang sudah absen sikat gigi untuk sesi ini tidak perlu diingatkan lagi
+			exists, err := s.studentRepo.CheckTootBrushExists(ctx, alarm.UserID, timeType, nowJakarta)
+			if err == nil && exists {
+				continue
+			}
+
+			// Kunci dedup agar satu alarm hanya terkirim sekali per sesi per hari,
+			// walaupun ada lebih dari satu instance service yang berjalan.
+			sentKey := fmt.Sprintf("alarm_sent:%s:%s:%s", alarm.UserID, timeType, nowJakarta.Format("2006-01-02"))
+			ok, err := s.rdb.SetNX(ctx, sentKey, "sent", 24*time.Hour).Result()
+			if err != nil || !ok {
+				continue
+			}
+
+			pay := payload.AlarmReminderPayload{
+				AlarmID:     alarm.ID,
+				UserID:      alarm.UserID,
+				TimeType:    timeType,
+				Clock:       clock,
+				ScheduledAt: nowJakarta.Format(time.RFC3339),
+			}
+			if err := rabbitmq.PublishToQueue("", rabbitmq.SendAlarmReminderQueueName, pay); err != nil {
+				log.Printf("[alarm-scheduler] failed to publish reminder for %s: %v", alarm.UserID, err)
+				_ = s.rdb.Del(ctx, sentKey).Err()
+			}
+		}
+	}
+}
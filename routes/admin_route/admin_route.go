@@ -3,9 +3,18 @@ package adminroute
 import (
 	datasources "giat-cerika-service/internal/dataSources"
 	adminhandler "giat-cerika-service/internal/handlers/admin_handler"
+	loginguardhandler "giat-cerika-service/internal/handlers/login_guard_handler"
 	"giat-cerika-service/internal/middlewares"
 	adminrepo "giat-cerika-service/internal/repositories/admin_repo"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	loginlockoutrepo "giat-cerika-service/internal/repositories/login_lockout_repo"
+	rolerepo "giat-cerika-service/internal/repositories/role_repo"
+	usersessionrepo "giat-cerika-service/internal/repositories/user_session_repo"
 	adminservice "giat-cerika-service/internal/services/admin_service"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -13,15 +22,32 @@ import (
 )
 
 func AdminRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client, cld *datasources.CloudinaryService) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	adminRepo := adminrepo.NewAdminRepositoryImpl(db)
-	adminService := adminservice.NewAdminServiceImpl(adminRepo, rdb, *cld)
+	userSessionRepo := usersessionrepo.NewUserSessionRepositoryImpl(db)
+	userSessionService := usersessionservice.NewUserSessionServiceImpl(userSessionRepo, rdb)
+	inviteRepo := adminrepo.NewAdminInviteRepositoryImpl(db)
+	roleRepo := rolerepo.NewRoleRepositoryImpl(db)
+	loginLockoutRepo := loginlockoutrepo.NewLoginLockoutRepositoryImpl(db)
+	loginGuardService := loginguardservice.NewLoginGuardServiceImpl(loginLockoutRepo, auditService, rdb)
+	loginGuardHandler := loginguardhandler.NewLoginGuardHandler(loginGuardService)
+	adminService := adminservice.NewAdminServiceImpl(adminRepo, inviteRepo, userSessionService, loginGuardService, rdb, *cld)
 	adminHandler := adminhandler.NewAdminHandler(adminService)
+	inviteService := adminservice.NewAdminInviteServiceImpl(inviteRepo, roleRepo, auditService)
+	inviteHandler := adminhandler.NewAdminInviteHandler(inviteService)
 
 	publicGroup := e.Group("")
+	// Registrasi wajib memakai invite_token, kecuali untuk admin pertama (bootstrap).
 	publicGroup.POST("/register", adminHandler.RegisterAdmin)
 	publicGroup.POST("/login", adminHandler.LoginAdmin)
 
-	protectedGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware("admin"))
-	protectedGroup.GET("/me", adminHandler.GetProfileAdmin)
-	protectedGroup.POST("/logout", adminHandler.LogoutAdmin)
+	protectedGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	protectedGroup.GET("/me", adminHandler.GetProfileAdmin, middlewares.RequirePermission(permission.DashboardView))
+	protectedGroup.POST("/logout", adminHandler.LogoutAdmin, middlewares.RequirePermission(permission.DashboardView))
+	protectedGroup.POST("/invite", inviteHandler.CreateInvite, middlewares.RequirePermission(permission.AdminInvite))
+	protectedGroup.GET("/invite", inviteHandler.GetAllInvites, middlewares.RequirePermission(permission.AdminInvite))
+	protectedGroup.DELETE("/invite/:inviteId", inviteHandler.RevokeInvite, middlewares.RequirePermission(permission.AdminInvite))
+	protectedGroup.GET("/login-lockout", loginGuardHandler.GetLockouts, middlewares.RequirePermission(permission.AccountUnlock))
+	protectedGroup.POST("/login-lockout/unlock", loginGuardHandler.Unlock, middlewares.RequirePermission(permission.AccountUnlock))
 }
@@ -0,0 +1,29 @@
+package apikeyroute
+
+import (
+	apikeyhandler "giat-cerika-service/internal/handlers/api_key_handler"
+	"giat-cerika-service/internal/middlewares"
+	apikeyrepo "giat-cerika-service/internal/repositories/api_key_repo"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	apikeyservice "giat-cerika-service/internal/services/api_key_service"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func APIKeyRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	apiKeyRepo := apikeyrepo.NewAPIKeyRepositoryImpl(db)
+	apiKeyService := apikeyservice.NewAPIKeyServiceImpl(apiKeyRepo, auditService, rdb)
+	apiKeyHandler := apikeyhandler.NewAPIKeyHandler(apiKeyService)
+
+	// Pengelolaan API key hanya lewat akun admin (JWT), bukan lewat API key lain.
+	apiKeyGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.APIKeyManage))
+	apiKeyGroup.POST("", apiKeyHandler.CreateAPIKey)
+	apiKeyGroup.GET("", apiKeyHandler.GetAllAPIKeys)
+	apiKeyGroup.DELETE("/:apiKeyId", apiKeyHandler.RevokeAPIKey)
+}
@@ -0,0 +1,22 @@
+package auditroute
+
+import (
+	audithandler "giat-cerika-service/internal/handlers/audit_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func AuditRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	auditHandler := audithandler.NewAuditHandler(auditService)
+
+	auditGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.AuditRead))
+	auditGroup.GET("", auditHandler.SearchAuditLog)
+}
@@ -3,9 +3,11 @@ package classroute
 import (
 	classhandler "giat-cerika-service/internal/handlers/class_handler"
 	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	classservice "giat-cerika-service/internal/services/class_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -13,16 +15,21 @@ import (
 )
 
 func ClassRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	classRepo := classrepo.NewClassRepositoryImpl(db)
-	classService := classservice.NewClassServiceImpl(classRepo, rdb)
+	classService := classservice.NewClassServiceImpl(classRepo, auditService, rdb)
 	classHandler := classhandler.NewClassHandler(classService)
 
 	e.GET("/all/public", classHandler.GetAllPublic)
 
-	classGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	classGroup.POST("/create", classHandler.CreateClass)
-	classGroup.GET("/all", classHandler.GetAllClass)
-	classGroup.GET("/:classId", classHandler.GetByIdClass)
-	classGroup.PUT("/:classId/edit", classHandler.UpdateClass)
-	classGroup.DELETE("/:classId/delete", classHandler.DeleteClass)
+	classGroup := e.Group("", middlewares.JWTOrAPIKeyMiddleware(rdb))
+	classGroup.POST("/create", classHandler.CreateClass, middlewares.RequirePermission(permission.ClassWrite))
+	classGroup.GET("/all", classHandler.GetAllClass, middlewares.RequirePermission(permission.ClassRead))
+	classGroup.GET("/tooth-brush-compliance", classHandler.GetToothBrushCompliance, middlewares.RequirePermission(permission.ClassRead))
+	classGroup.GET("/:classId", classHandler.GetByIdClass, middlewares.RequirePermission(permission.ClassRead))
+	classGroup.PUT("/:classId/edit", classHandler.UpdateClass, middlewares.RequirePermission(permission.ClassWrite))
+	classGroup.DELETE("/:classId/delete", classHandler.DeleteClass, middlewares.RequirePermission(permission.ClassWrite))
+	classGroup.GET("/:classId/teachers", classHandler.GetClassTeachers, middlewares.RequirePermission(permission.ClassRead))
+	classGroup.PUT("/:classId/teachers", classHandler.UpdateClassTeachers, middlewares.RequirePermission(permission.ClassWrite))
 }
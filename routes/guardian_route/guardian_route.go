@@ -0,0 +1,66 @@
+package guardianroute
+
+import (
+	datasources "giat-cerika-service/internal/dataSources"
+	guardianhandler "giat-cerika-service/internal/handlers/guardian_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	guardianrepo "giat-cerika-service/internal/repositories/guardian_repo"
+	loginlockoutrepo "giat-cerika-service/internal/repositories/login_lockout_repo"
+	predictionrepo "giat-cerika-service/internal/repositories/prediction_repo"
+	quizhistoryrepo "giat-cerika-service/internal/repositories/quiz_history_repo"
+	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	usersessionrepo "giat-cerika-service/internal/repositories/user_session_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	guardianservice "giat-cerika-service/internal/services/guardian_service"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
+	predictionservice "giat-cerika-service/internal/services/prediction_service"
+	quizhistoryservice "giat-cerika-service/internal/services/quiz_history_service"
+	studentservice "giat-cerika-service/internal/services/student_service"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func GuardianRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client, cld *datasources.CloudinaryService) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	guardianRepo := guardianrepo.NewGuardianRepositoryImpl(db)
+	studentRepo := studentrepo.NewStudentRepositoryImpl(db)
+	classRepo := classrepo.NewClassRepositoryImpl(db)
+	sessionRepo := toothbrushsessionrepo.NewToothBrushSessionRepositoryImpl(db)
+	userSessionRepo := usersessionrepo.NewUserSessionRepositoryImpl(db)
+	userSessionService := usersessionservice.NewUserSessionServiceImpl(userSessionRepo, rdb)
+	loginLockoutRepo := loginlockoutrepo.NewLoginLockoutRepositoryImpl(db)
+	loginGuardService := loginguardservice.NewLoginGuardServiceImpl(loginLockoutRepo, auditService, rdb)
+	guardianService := guardianservice.NewGuardianServiceImpl(guardianRepo, studentRepo, userSessionService, loginGuardService, auditService, rdb)
+	studentService := studentservice.NewStudentServiceImpl(studentRepo, classRepo, sessionRepo, userSessionService, loginGuardService, auditService, rdb, *cld)
+	quizHistoryRepo := quizhistoryrepo.NewQuizHistoryRepositoryImpl(db)
+	quizRepo := quizrepo.NewQuizRepositoryImpl(db)
+	quizHistoryService := quizhistoryservice.NewQuizHistoryServiceImpl(quizHistoryRepo, studentRepo, quizRepo, rdb)
+	predictRepo := predictionrepo.NewPredictionRepositoryImpl(db)
+	predictionService := predictionservice.NewPredictionServiceImpl(predictRepo, studentRepo, auditService, rdb)
+	guardianHandler := guardianhandler.NewGuardianHandler(guardianService, studentService, quizHistoryService, predictionService)
+
+	e.POST("/register", guardianHandler.RegisterGuardian)
+	e.POST("/login", guardianHandler.LoginGuardian)
+
+	guardianGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.GuardianAccess))
+	guardianGroup.GET("/me", guardianHandler.GetProfileGuardian)
+	guardianGroup.POST("/logout", guardianHandler.Logout)
+	guardianGroup.GET("/students", guardianHandler.GetLinkedStudents)
+	guardianGroup.POST("/students/link", guardianHandler.LinkStudent)
+	guardianGroup.DELETE("/students/:studentId", guardianHandler.UnlinkStudent)
+	guardianGroup.GET("/students/:studentId/tooth-brush", guardianHandler.GetStudentToothBrushHistory)
+	guardianGroup.GET("/students/:studentId/quiz-history", guardianHandler.GetStudentQuizHistory)
+	guardianGroup.GET("/students/:studentId/predictions", guardianHandler.GetStudentPredictions)
+
+	adminGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	adminGroup.POST("/link-code/:studentId", guardianHandler.IssueLinkCode, middlewares.RequirePermission(permission.StudentWrite))
+}
@@ -5,9 +5,11 @@ import (
 	materialhandler "giat-cerika-service/internal/handlers/material_handler"
 	"giat-cerika-service/internal/middlewares"
 	adminrepo "giat-cerika-service/internal/repositories/admin_repo"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	materialrepo "giat-cerika-service/internal/repositories/material_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	materialservice "giat-cerika-service/internal/services/material_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -15,19 +17,21 @@ import (
 )
 
 func MaterialRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client, cld *datasources.CloudinaryService) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	materialRepo := materialrepo.NewMaterialRepositoryImpl(db)
 	adminRepo := adminrepo.NewAdminRepositoryImpl(db)
-	materialService := materialservice.NewMaterialServiceImpl(materialRepo, adminRepo, rdb, *cld)
+	materialService := materialservice.NewMaterialServiceImpl(materialRepo, adminRepo, auditService, rdb, *cld)
 	materialHandler := materialhandler.NewMaterialHandler(materialService)
 
 	e.GET("/all/latest", materialHandler.GetAllLatestMateriaL)
 	e.GET("/all/public", materialHandler.GetAllPublicMaterial)
 	e.GET("/:materialId/public", materialHandler.GetByIdPublicMaterial)
 
-	materialGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	materialGroup.POST("/create", materialHandler.CreateMaterial)
-	materialGroup.GET("/all", materialHandler.GetAllMaterial)
-	materialGroup.GET("/:materialId", materialHandler.GetByIdMaterial)
-	materialGroup.PUT("/:materialId/edit", materialHandler.UpdateMaterial)
-	materialGroup.DELETE("/:materialId/delete", materialHandler.DeleteMaterial)
+	materialGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	materialGroup.POST("/create", materialHandler.CreateMaterial, middlewares.RequirePermission(permission.MaterialWrite))
+	materialGroup.GET("/all", materialHandler.GetAllMaterial, middlewares.RequirePermission(permission.MaterialRead))
+	materialGroup.GET("/:materialId", materialHandler.GetByIdMaterial, middlewares.RequirePermission(permission.MaterialRead))
+	materialGroup.PUT("/:materialId/edit", materialHandler.UpdateMaterial, middlewares.RequirePermission(permission.MaterialWrite))
+	materialGroup.DELETE("/:materialId/delete", materialHandler.DeleteMaterial, middlewares.RequirePermission(permission.MaterialWrite))
 }
@@ -0,0 +1,30 @@
+package permissionroute
+
+import (
+	permissionhandler "giat-cerika-service/internal/handlers/permission_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	permissionrepo "giat-cerika-service/internal/repositories/permission_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	permissionservice "giat-cerika-service/internal/services/permission_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func PermissionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	permissionRepo := permissionrepo.NewPermissionRepositoryImpl(db)
+	permissionService := permissionservice.NewPermissionServiceImpl(permissionRepo, auditService, rdb)
+	permissionHandler := permissionhandler.NewPermissionHandler(permissionService)
+
+	permissionGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.RoleManage))
+	permissionGroup.POST("/create", permissionHandler.CreatePermission)
+	permissionGroup.GET("/all", permissionHandler.GetAllPermission)
+	permissionGroup.GET("/:permissionId", permissionHandler.GetByIdPermission)
+	permissionGroup.PUT("/:permissionId/edit", permissionHandler.UpdatePermission)
+	permissionGroup.DELETE("/:permissionId/delete", permissionHandler.DeletePermission)
+}
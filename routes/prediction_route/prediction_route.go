@@ -3,10 +3,12 @@ package predictionroute
 import (
 	predictionhandler "giat-cerika-service/internal/handlers/prediction_handler"
 	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	predictionrepo "giat-cerika-service/internal/repositories/prediction_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	predictionservice "giat-cerika-service/internal/services/prediction_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -14,18 +16,20 @@ import (
 )
 
 func PredictionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	predictRepo := predictionrepo.NewPredictionRepositoryImpl(db)
 	studentRepo := studentrepo.NewStudentRepositoryImpl(db)
-	predicService := predictionservice.NewPredictionServiceImpl(predictRepo, studentRepo, rdb)
+	predicService := predictionservice.NewPredictionServiceImpl(predictRepo, studentRepo, auditService, rdb)
 	predictHandler := predictionhandler.NewPredictionHandler(predicService)
 
-	predictGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	predictGroup.POST("/save", predictHandler.CreatePrediction)
-	predictGroup.GET("/all", predictHandler.GetAllPredictions)
-	predictGroup.DELETE("/:predictionId/delete", predictHandler.DeletePrediction)
+	predictGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	predictGroup.POST("/save", predictHandler.CreatePrediction, middlewares.RequirePermission(permission.PredictionWrite))
+	predictGroup.GET("/all", predictHandler.GetAllPredictions, middlewares.RequirePermission(permission.PredictionRead))
+	predictGroup.DELETE("/:predictionId/delete", predictHandler.DeletePrediction, middlewares.RequirePermission(permission.PredictionWrite))
 
-	predictGroup.POST("/send-prediction", predictHandler.SendPredictToStudent)
+	predictGroup.POST("/send-prediction", predictHandler.SendPredictToStudent, middlewares.RequirePermission(permission.PredictionWrite))
 
-	predictStudent := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("STUDENT")))
-	predictStudent.GET("/my-prediction", predictHandler.GetPredictByStudent)
+	predictStudent := e.Group("", middlewares.JWTMiddleware(rdb))
+	predictStudent.GET("/my-prediction", predictHandler.GetPredictByStudent, middlewares.RequirePermission(permission.PredictionReadOwn))
 }
@@ -5,10 +5,12 @@ import (
 	questionhandler "giat-cerika-service/internal/handlers/question_handler"
 	"giat-cerika-service/internal/middlewares"
 	answerrepo "giat-cerika-service/internal/repositories/answer_repo"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	questionrepo "giat-cerika-service/internal/repositories/question_repo"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	questionservice "giat-cerika-service/internal/services/question_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -16,16 +18,18 @@ import (
 )
 
 func QuestionRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client, cld *datasources.CloudinaryService) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	questionRepo := questionrepo.NewQuestionRepositoryImpl(db)
 	quizRepo := quizrepo.NewQuizRepositoryImpl(db)
 	answerRepo := answerrepo.NewAnswerRepositoryImpl(db)
-	questionService := questionservice.NewQuestionServiceImpl(questionRepo, quizRepo, answerRepo, rdb, *cld)
+	questionService := questionservice.NewQuestionServiceImpl(questionRepo, quizRepo, answerRepo, auditService, rdb, *cld)
 	questionHandler := questionhandler.NewQuestionHandler(questionService)
 
-	questionGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	questionGroup.POST("/create", questionHandler.CreateQuestion)
-	questionGroup.GET("/all/:quizId", questionHandler.GetAllQuestion)
-	questionGroup.GET("/:questionId", questionHandler.GetByIdQuestion)
-	questionGroup.PUT("/:questionId/edit", questionHandler.UpdateQuestion)
-	questionGroup.DELETE("/:questionId/delete", questionHandler.DeleteQuestion)
+	questionGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	questionGroup.POST("/create", questionHandler.CreateQuestion, middlewares.RequirePermission(permission.QuizWrite))
+	questionGroup.GET("/all/:quizId", questionHandler.GetAllQuestion, middlewares.RequirePermission(permission.QuizRead))
+	questionGroup.GET("/:questionId", questionHandler.GetByIdQuestion, middlewares.RequirePermission(permission.QuizRead))
+	questionGroup.PUT("/:questionId/edit", questionHandler.UpdateQuestion, middlewares.RequirePermission(permission.QuizWrite))
+	questionGroup.DELETE("/:questionId/delete", questionHandler.DeleteQuestion, middlewares.RequirePermission(permission.QuizWrite))
 }
@@ -0,0 +1,44 @@
+package questionnaireroute
+
+import (
+	questionnairehandler "giat-cerika-service/internal/handlers/questionnaire_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	questionnairerepo "giat-cerika-service/internal/repositories/questionnaire_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	questionnaireservice "giat-cerika-service/internal/services/questionnaire_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func QuestionnaireRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	questionnaireRepo := questionnairerepo.NewQuestionnaireRepositoryImpl(db)
+	studentRepo := studentrepo.NewStudentRepositoryImpl(db)
+	classRepo := classrepo.NewClassRepositoryImpl(db)
+	questionnaireService := questionnaireservice.NewQuestionnaireServiceImpl(questionnaireRepo, studentRepo, classRepo, auditService, rdb)
+	questionnaireHandler := questionnairehandler.NewQuestionnaireHandler(questionnaireService)
+
+	questionnaireStudent := e.Group("", middlewares.JWTMiddleware(rdb))
+	questionnaireStudent.GET("/all-available", questionnaireHandler.GetAllAvailable, middlewares.RequirePermission(permission.QuestionnaireRespond))
+	questionnaireStudent.GET("/available/:questionnaireId", questionnaireHandler.GetAvailableById, middlewares.RequirePermission(permission.QuestionnaireRespond))
+	questionnaireStudent.POST("/available/:questionnaireId/submit", questionnaireHandler.SubmitQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireRespond))
+
+	questionnaireGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	questionnaireGroup.POST("/create", questionnaireHandler.CreateQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.GET("/all", questionnaireHandler.GetAllQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireRead))
+	questionnaireGroup.GET("/:questionnaireId", questionnaireHandler.GetQuestionnaireById, middlewares.RequirePermission(permission.QuestionnaireRead))
+	questionnaireGroup.PUT("/:questionnaireId/edit", questionnaireHandler.UpdateQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.DELETE("/:questionnaireId/delete", questionnaireHandler.DeleteQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.PUT("/:questionnaireId/update-status", questionnaireHandler.UpdateStatusQuestionnaire, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.POST("/:questionnaireId/item/create", questionnaireHandler.CreateItem, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.PUT("/:questionnaireId/item/:itemId/edit", questionnaireHandler.UpdateItem, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.DELETE("/:questionnaireId/item/:itemId/delete", questionnaireHandler.DeleteItem, middlewares.RequirePermission(permission.QuestionnaireWrite))
+	questionnaireGroup.GET("/:questionnaireId/result-by-class", questionnaireHandler.GetResultByClass, middlewares.RequirePermission(permission.QuestionnaireResultRead))
+}
@@ -7,7 +7,7 @@ import (
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
 	quizhistoryservice "giat-cerika-service/internal/services/quiz_history_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -21,11 +21,13 @@ func QuizHistoryRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
 	quizHistoryService := quizhistoryservice.NewQuizHistoryServiceImpl(quizHistoryRepo, studentRepo, quizRepo, rdb)
 	quizHistoryHandler := quizhistoryhandler.NewQuizHistoryHandler(quizHistoryService)
 
-	qhGruop := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("STUDENT")))
-	qhGruop.GET("/my-history", quizHistoryHandler.GetHistoryQuizStudent)
-	qhGruop.GET("/question-history/:quizHistoryId", quizHistoryHandler.GetAllQuestionHistory)
+	qhGruop := e.Group("", middlewares.JWTMiddleware(rdb))
+	qhGruop.GET("/my-history", quizHistoryHandler.GetHistoryQuizStudent, middlewares.RequirePermission(permission.QuizTake))
+	qhGruop.GET("/question-history/:quizHistoryId", quizHistoryHandler.GetAllQuestionHistory, middlewares.RequirePermission(permission.QuizTake))
 
-	qhAdmin := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	qhAdmin.GET("/all-student-history", quizHistoryHandler.GetHistoryQuizByQuizID)
+	qhAdmin := e.Group("", middlewares.JWTOrAPIKeyMiddleware(rdb))
+	qhAdmin.GET("/all-student-history", quizHistoryHandler.GetHistoryQuizByQuizID, middlewares.RequirePermission(permission.QuizResultRead))
+	qhAdmin.GET("/item-analysis/:quizId", quizHistoryHandler.GetItemAnalysis, middlewares.RequirePermission(permission.QuizResultRead))
+	qhAdmin.GET("/export", quizHistoryHandler.ExportQuizHistory, middlewares.RequirePermission(permission.QuizResultRead))
 
 }
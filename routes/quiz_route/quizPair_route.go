@@ -0,0 +1,36 @@
+package quizroute
+
+import (
+	quizhandler "giat-cerika-service/internal/handlers/quiz_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	quizhistoryrepo "giat-cerika-service/internal/repositories/quiz_history_repo"
+	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	quizservice "giat-cerika-service/internal/services/quiz_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func QuizPairRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	pairRepo := quizrepo.NewQuizPairRepositoryImpl(db)
+	quizRepo := quizrepo.NewQuizRepositoryImpl(db)
+	quizHistoryRepo := quizhistoryrepo.NewQuizHistoryRepositoryImpl(db)
+	studentRepo := studentrepo.NewStudentRepositoryImpl(db)
+	pairService := quizservice.NewQuizPairServiceImpl(pairRepo, quizRepo, quizHistoryRepo, studentRepo, auditService, rdb)
+	pairHandler := quizhandler.NewQuizPairHandler(pairService)
+
+	pairGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	pairGroup.POST("/create", pairHandler.CreateQuizPair, middlewares.RequirePermission(permission.QuizWrite))
+	pairGroup.GET("/all", pairHandler.GetAllQuizPair, middlewares.RequirePermission(permission.QuizRead))
+	pairGroup.GET("/:pairId", pairHandler.GetQuizPairByID, middlewares.RequirePermission(permission.QuizRead))
+	pairGroup.PUT("/:pairId/edit", pairHandler.UpdateQuizPair, middlewares.RequirePermission(permission.QuizWrite))
+	pairGroup.DELETE("/:pairId/delete", pairHandler.DeleteQuizPair, middlewares.RequirePermission(permission.QuizWrite))
+	pairGroup.GET("/:pairId/learning-gain", pairHandler.GetLearningGain, middlewares.RequirePermission(permission.QuizResultRead))
+}
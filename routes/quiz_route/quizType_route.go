@@ -3,9 +3,11 @@ package quizroute
 import (
 	quizhandler "giat-cerika-service/internal/handlers/quiz_handler"
 	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	quizservice "giat-cerika-service/internal/services/quiz_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -13,14 +15,16 @@ import (
 )
 
 func QuizTypeRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	qtRepo := quizrepo.NewQuizTypeRepositoryImpl(db)
-	qtService := quizservice.NewQuizTypeServiceImpl(qtRepo, rdb)
+	qtService := quizservice.NewQuizTypeServiceImpl(qtRepo, auditService, rdb)
 	qtHandler := quizhandler.NewQuizTypeHandler(qtService)
 
-	qtGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	qtGroup.POST("/create", qtHandler.CreateQuizType)
-	qtGroup.GET("/all", qtHandler.GetAllQuizType)
-	qtGroup.GET("/:quizTypeid", qtHandler.GetQuizTypeByID)
-	qtGroup.PUT("/:quizTypeid/edit", qtHandler.UpdateQuizType)
-	qtGroup.DELETE("/:quizTypeid/delete", qtHandler.DeleteQuizType)
+	qtGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	qtGroup.POST("/create", qtHandler.CreateQuizType, middlewares.RequirePermission(permission.QuizWrite))
+	qtGroup.GET("/all", qtHandler.GetAllQuizType, middlewares.RequirePermission(permission.QuizRead))
+	qtGroup.GET("/:quizTypeid", qtHandler.GetQuizTypeByID, middlewares.RequirePermission(permission.QuizRead))
+	qtGroup.PUT("/:quizTypeid/edit", qtHandler.UpdateQuizType, middlewares.RequirePermission(permission.QuizWrite))
+	qtGroup.DELETE("/:quizTypeid/delete", qtHandler.DeleteQuizType, middlewares.RequirePermission(permission.QuizWrite))
 }
@@ -3,9 +3,11 @@ package quizroute
 import (
 	quizhandler "giat-cerika-service/internal/handlers/quiz_handler"
 	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	quizrepo "giat-cerika-service/internal/repositories/quiz_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	quizservice "giat-cerika-service/internal/services/quiz_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -13,22 +15,27 @@ import (
 )
 
 func QuizRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	quizRepo := quizrepo.NewQuizRepositoryImpl(db)
 	qtRepo := quizrepo.NewQuizTypeRepositoryImpl(db)
-	quizService := quizservice.NewQuizServiceImpl(quizRepo, qtRepo, rdb)
+	quizService := quizservice.NewQuizServiceImpl(quizRepo, qtRepo, auditService, rdb)
 	quizHandler := quizhandler.NewQuizHandler(quizService)
 
-	quizGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	quizGroup.POST("/create", quizHandler.CreateQuiz)
-	quizGroup.GET("/all", quizHandler.GetQuizAll)
-	quizGroup.GET("/:quizId", quizHandler.GetQuizByID)
-	quizGroup.PUT("/:quizId/edit", quizHandler.UpdateQuiz)
-	quizGroup.DELETE("/:quizId/delete", quizHandler.DeleteQuiz)
-	quizGroup.PUT("/:quizId/update-status", quizHandler.UpdateStatusQuiz)
-	quizGroup.PUT("/:quizId/update-question-order-mode", quizHandler.UpdateQuestionOrderMode)
+	quizGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	quizGroup.POST("/create", quizHandler.CreateQuiz, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.GET("/all", quizHandler.GetQuizAll, middlewares.RequirePermission(permission.QuizRead))
+	quizGroup.GET("/:quizId", quizHandler.GetQuizByID, middlewares.RequirePermission(permission.QuizRead))
+	quizGroup.PUT("/:quizId/edit", quizHandler.UpdateQuiz, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.DELETE("/:quizId/delete", quizHandler.DeleteQuiz, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.PUT("/:quizId/update-status", quizHandler.UpdateStatusQuiz, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.PUT("/:quizId/update-question-order-mode", quizHandler.UpdateQuestionOrderMode, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.PUT("/:quizId/update-correct-answer-visibility", quizHandler.UpdateCorrectAnswerVisibility, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.PUT("/:quizId/update-shuffle-answers", quizHandler.UpdateShuffleAnswers, middlewares.RequirePermission(permission.QuizWrite))
+	quizGroup.PUT("/:quizId/update-question-pool", quizHandler.UpdateQuestionPool, middlewares.RequirePermission(permission.QuizWrite))
 
-	quizStudent := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("STUDENT")))
-	quizStudent.GET("/all-available", quizHandler.GetAllQuizAvailable)
-	quizStudent.GET("/available/:quizId", quizHandler.GetQuizAvailableById)
+	quizStudent := e.Group("", middlewares.JWTMiddleware(rdb))
+	quizStudent.GET("/all-available", quizHandler.GetAllQuizAvailable, middlewares.RequirePermission(permission.QuizTake))
+	quizStudent.GET("/available/:quizId", quizHandler.GetQuizAvailableById, middlewares.RequirePermission(permission.QuizTake))
 	
 }
@@ -7,7 +7,7 @@ import (
 	quizsessionrepo "giat-cerika-service/internal/repositories/quiz_session_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
 	quizsessionservice "giat-cerika-service/internal/services/quiz_session_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -21,13 +21,13 @@ func QuizSessionRoute(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
 	qsService := quizsessionservice.NewQuizSessionServiceImpl(qsRepo, quizRepo, studentRepo, rdb)
 	qsHandler := quizsessionhandler.NewQuizSessionHandler(qsService)
 
-	qsGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("STUDENT")))
-	qsGroup.POST("/assign-code-quiz/:quizId", qsHandler.AssignCodeQuiz)
-	qsGroup.PUT("/start-quiz/:quizSessionId", qsHandler.StartedQuiz)
-	qsGroup.GET("/quiz-duration/:quizSessionId", qsHandler.GetDuration)
-	qsGroup.POST("/quiz-submit/:quizSessionId", qsHandler.SubmitQuizSession)
-	qsGroup.GET("/quiz-question/:quizSessionId", qsHandler.GetQuizQuestionByOrderMode)
+	qsGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	qsGroup.POST("/assign-code-quiz/:quizId", qsHandler.AssignCodeQuiz, middlewares.RequirePermission(permission.QuizTake))
+	qsGroup.PUT("/start-quiz/:quizSessionId", qsHandler.StartedQuiz, middlewares.RequirePermission(permission.QuizTake))
+	qsGroup.GET("/quiz-duration/:quizSessionId", qsHandler.GetDuration, middlewares.RequirePermission(permission.QuizTake))
+	qsGroup.POST("/quiz-submit/:quizSessionId", qsHandler.SubmitQuizSession, middlewares.RequirePermission(permission.QuizTake))
+	qsGroup.GET("/quiz-question/:quizSessionId", qsHandler.GetQuizQuestionByOrderMode, middlewares.RequirePermission(permission.QuizTake))
 
-	qsAdmin := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	qsAdmin.GET("/all-student", qsHandler.GetQuizSessionStudent)
+	qsAdmin := e.Group("", middlewares.JWTMiddleware(rdb))
+	qsAdmin.GET("/all-student", qsHandler.GetQuizSessionStudent, middlewares.RequirePermission(permission.QuizResultRead))
 }
@@ -1,9 +1,16 @@
 package roleroute
 
 import (
+	permissionhandler "giat-cerika-service/internal/handlers/permission_handler"
 	rolehandler "giat-cerika-service/internal/handlers/role_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	permissionrepo "giat-cerika-service/internal/repositories/permission_repo"
 	rolerepo "giat-cerika-service/internal/repositories/role_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	permissionservice "giat-cerika-service/internal/services/permission_service"
 	roleservice "giat-cerika-service/internal/services/role_service"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -11,13 +18,22 @@ import (
 )
 
 func RoleRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	roleRepo := rolerepo.NewRoleRepositoryImpl(db)
-	roleService := roleservice.NewRoleServiceImpl(roleRepo, rdb)
+	roleService := roleservice.NewRoleServiceImpl(roleRepo, auditService, rdb)
 	roleHandler := rolehandler.NewRoleHandler(roleService)
 
-	e.POST("/create", roleHandler.CreateRole)
-	e.GET("/all", roleHandler.GetAllRole)
-	e.GET("/:roleId", roleHandler.GetByIdRole)
-	e.PUT("/:roleId/edit", roleHandler.UpdateRole)
-	e.DELETE("/:roleId/delete", roleHandler.DeleteRole)
+	permissionRepo := permissionrepo.NewPermissionRepositoryImpl(db)
+	permissionService := permissionservice.NewPermissionServiceImpl(permissionRepo, auditService, rdb)
+	permissionHandler := permissionhandler.NewPermissionHandler(permissionService)
+
+	roleGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.RoleManage))
+	roleGroup.POST("/create", roleHandler.CreateRole)
+	roleGroup.GET("/all", roleHandler.GetAllRole)
+	roleGroup.GET("/:roleId", roleHandler.GetByIdRole)
+	roleGroup.PUT("/:roleId/edit", roleHandler.UpdateRole)
+	roleGroup.DELETE("/:roleId/delete", roleHandler.DeleteRole)
+	roleGroup.GET("/:roleId/permissions", permissionHandler.GetRolePermissions)
+	roleGroup.PUT("/:roleId/permissions", permissionHandler.UpdateRolePermissions)
 }
@@ -3,15 +3,22 @@ package routes
 import (
 	datasources "giat-cerika-service/internal/dataSources"
 	adminroute "giat-cerika-service/routes/admin_route"
+	apikeyroute "giat-cerika-service/routes/api_key_route"
+	auditroute "giat-cerika-service/routes/audit_route"
 	classroute "giat-cerika-service/routes/class_route"
+	guardianroute "giat-cerika-service/routes/guardian_route"
 	materialroute "giat-cerika-service/routes/material_route"
+	permissionroute "giat-cerika-service/routes/permission_route"
 	predictionroute "giat-cerika-service/routes/prediction_route"
 	questionroute "giat-cerika-service/routes/question_route"
+	questionnaireroute "giat-cerika-service/routes/questionnaire_route"
 	quizhistoryroute "giat-cerika-service/routes/quiz_history_route"
 	quizroute "giat-cerika-service/routes/quiz_route"
 	quizsessionroute "giat-cerika-service/routes/quiz_session_route"
 	roleroute "giat-cerika-service/routes/role_route"
+	sessionroute "giat-cerika-service/routes/session_route"
 	studentroute "giat-cerika-service/routes/student_route"
+	toothbrushsessionroute "giat-cerika-service/routes/toothbrush_session_route"
 	videoroute "giat-cerika-service/routes/video_route"
 
 	"github.com/labstack/echo/v4"
@@ -22,6 +29,7 @@ import (
 func Routes(e *echo.Echo, db *gorm.DB, rdb *redis.Client, cldSvc *datasources.CloudinaryService) {
 	v1 := e.Group("/api/v1")
 	roleroute.RoleRoutes(v1.Group("/role"), db, rdb)
+	permissionroute.PermissionRoutes(v1.Group("/permission"), db, rdb)
 	adminroute.AdminRoutes(v1.Group("/admin"), db, rdb, cldSvc)
 	classroute.ClassRoutes(v1.Group("/class"), db, rdb)
 	studentroute.StudentRoutes(v1.Group("/student"), db, rdb, cldSvc)
@@ -29,8 +37,15 @@ func Routes(e *echo.Echo, db *gorm.DB, rdb *redis.Client, cldSvc *datasources.Cl
 	materialroute.MaterialRoute(v1.Group("/material"), db, rdb, cldSvc)
 	quizroute.QuizTypeRoute(v1.Group("/quizType"), db, rdb)
 	quizroute.QuizRoute(v1.Group("/quiz"), db, rdb)
+	quizroute.QuizPairRoute(v1.Group("/quizPair"), db, rdb)
 	questionroute.QuestionRoute(v1.Group("/question"), db, rdb, cldSvc)
 	quizsessionroute.QuizSessionRoute(v1.Group("/quiz-session"), db, rdb)
 	quizhistoryroute.QuizHistoryRoute(v1.Group("/quiz-history"), db, rdb)
 	predictionroute.PredictionRoutes(v1.Group("/prediction"), db, rdb)
+	toothbrushsessionroute.ToothBrushSessionRoutes(v1.Group("/tooth-brush-session"), db, rdb)
+	questionnaireroute.QuestionnaireRoutes(v1.Group("/questionnaire"), db, rdb)
+	sessionroute.SessionRoutes(v1.Group("/session"), db, rdb)
+	auditroute.AuditRoutes(v1.Group("/audit-log"), db, rdb)
+	guardianroute.GuardianRoutes(v1.Group("/guardian"), db, rdb, cldSvc)
+	apikeyroute.APIKeyRoutes(v1.Group("/api-key"), db, rdb)
 }
@@ -0,0 +1,25 @@
+package sessionroute
+
+import (
+	usersessionhandler "giat-cerika-service/internal/handlers/user_session_handler"
+	"giat-cerika-service/internal/middlewares"
+	usersessionrepo "giat-cerika-service/internal/repositories/user_session_repo"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SessionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	sessionRepo := usersessionrepo.NewUserSessionRepositoryImpl(db)
+	sessionService := usersessionservice.NewUserSessionServiceImpl(sessionRepo, rdb)
+	sessionHandler := usersessionhandler.NewUserSessionHandler(sessionService)
+
+	e.POST("/refresh", sessionHandler.RefreshToken)
+
+	sessionGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	sessionGroup.GET("", sessionHandler.GetMySessions)
+	sessionGroup.DELETE("", sessionHandler.RevokeAllSessions)
+	sessionGroup.DELETE("/:sessionId", sessionHandler.RevokeSession)
+}
@@ -2,12 +2,22 @@ package studentroute
 
 import (
 	datasources "giat-cerika-service/internal/dataSources"
+	alarmhandler "giat-cerika-service/internal/handlers/alarm_handler"
 	studenthandler "giat-cerika-service/internal/handlers/student_handler"
 	"giat-cerika-service/internal/middlewares"
+	alarmrepo "giat-cerika-service/internal/repositories/alarm_repo"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	loginlockoutrepo "giat-cerika-service/internal/repositories/login_lockout_repo"
 	studentrepo "giat-cerika-service/internal/repositories/student_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	usersessionrepo "giat-cerika-service/internal/repositories/user_session_repo"
+	alarmservice "giat-cerika-service/internal/services/alarm_service"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	loginguardservice "giat-cerika-service/internal/services/login_guard_service"
 	studentservice "giat-cerika-service/internal/services/student_service"
-	"strings"
+	usersessionservice "giat-cerika-service/internal/services/user_session_service"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -15,25 +25,48 @@ import (
 )
 
 func StudentRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client, cld *datasources.CloudinaryService) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	studentRepo := studentrepo.NewStudentRepositoryImpl(db)
 	classRepo := classrepo.NewClassRepositoryImpl(db)
-	studentService := studentservice.NewStudentServiceImpl(studentRepo, classRepo, rdb, *cld)
+	sessionRepo := toothbrushsessionrepo.NewToothBrushSessionRepositoryImpl(db)
+	userSessionRepo := usersessionrepo.NewUserSessionRepositoryImpl(db)
+	userSessionService := usersessionservice.NewUserSessionServiceImpl(userSessionRepo, rdb)
+	loginLockoutRepo := loginlockoutrepo.NewLoginLockoutRepositoryImpl(db)
+	loginGuardService := loginguardservice.NewLoginGuardServiceImpl(loginLockoutRepo, auditService, rdb)
+	studentService := studentservice.NewStudentServiceImpl(studentRepo, classRepo, sessionRepo, userSessionService, loginGuardService, auditService, rdb, *cld)
 	studentHandler := studenthandler.NewStudentHandler(studentService)
+	alarmRepo := alarmrepo.NewAlarmRepositoryImpl(db)
+	alarmService := alarmservice.NewAlarmServiceImpl(alarmRepo, rdb)
+	alarmHandler := alarmhandler.NewAlarmHandler(alarmService)
 
 	e.POST("/register", studentHandler.RegisterStudent)
 	e.POST("/login", studentHandler.LoginStudent)
 	e.POST("/check-nisn-and-dateofbirth", studentHandler.CheckNisnAndDateOfBirthStudent)
+	e.POST("/verify-reset-code", studentHandler.VerifyResetCode)
 	e.PUT("/update-new-password", studentHandler.UpdateNewPasswordStudent)
 
-	studentGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware("student"))
-	studentGroup.GET("/me", studentHandler.GetProfileStudent, middlewares.JWTMiddleware(rdb))
-	studentGroup.POST("/logout", studentHandler.Logout, middlewares.JWTMiddleware(rdb))
+	studentGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.StudentProfile))
+	studentGroup.GET("/me", studentHandler.GetProfileStudent)
+	studentGroup.POST("/logout", studentHandler.Logout)
 	studentGroup.PUT("/update-profile", studentHandler.UpdateProfileStudent)
 	studentGroup.PUT("/edit-photo", studentHandler.EditPhotoStudent)
-	studentGroup.POST("/tooth-brush", studentHandler.CreateToothBrush)
-	studentGroup.GET("/history-tooth-brush", studentHandler.GetHistoryToothBrush)
 
-	studentGroups := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	studentGroups.GET("/all", studentHandler.GetStudentAll)
+	toothBrushGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RequirePermission(permission.ToothBrushLog))
+	toothBrushGroup.POST("/tooth-brush", studentHandler.CreateToothBrush)
+	toothBrushGroup.GET("/history-tooth-brush", studentHandler.GetHistoryToothBrush)
+	toothBrushGroup.GET("/tooth-brush-progress", studentHandler.GetToothBrushProgress)
+	toothBrushGroup.POST("/alarm", alarmHandler.CreateAlarm)
+	toothBrushGroup.GET("/alarm", alarmHandler.GetMyAlarm)
+	toothBrushGroup.PUT("/alarm", alarmHandler.UpdateAlarm)
+
+	studentGroups := e.Group("", middlewares.JWTOrAPIKeyMiddleware(rdb))
+	studentGroups.GET("/all", studentHandler.GetStudentAll, middlewares.RequirePermission(permission.StudentRead))
+	studentGroups.POST("/import", studentHandler.ImportStudents, middlewares.RequirePermission(permission.StudentWrite))
+	studentGroups.PUT("/:studentId/status", studentHandler.UpdateStudentStatus, middlewares.RequirePermission(permission.StudentWrite))
+	studentGroups.POST("/:studentId/reset-password", studentHandler.ResetPasswordByAdmin, middlewares.RequirePermission(permission.StudentWrite))
+	studentGroups.GET("/:studentId/tooth-brush-progress", studentHandler.GetToothBrushProgressByStudent, middlewares.RequirePermission(permission.StudentRead))
+	studentGroups.GET("/tooth-brush/review", studentHandler.GetToothBrushForReview, middlewares.RequirePermission(permission.ToothBrushReview))
+	studentGroups.PUT("/tooth-brush/:logId/review", studentHandler.ReviewToothBrush, middlewares.RequirePermission(permission.ToothBrushReview))
 
 }
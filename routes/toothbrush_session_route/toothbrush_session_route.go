@@ -0,0 +1,34 @@
+package toothbrushsessionroute
+
+import (
+	toothbrushsessionhandler "giat-cerika-service/internal/handlers/toothbrush_session_handler"
+	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
+	classrepo "giat-cerika-service/internal/repositories/class_repo"
+	toothbrushsessionrepo "giat-cerika-service/internal/repositories/toothbrush_session_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
+	toothbrushsessionservice "giat-cerika-service/internal/services/toothbrush_session_service"
+	"giat-cerika-service/pkg/constant/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func ToothBrushSessionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
+	sessionRepo := toothbrushsessionrepo.NewToothBrushSessionRepositoryImpl(db)
+	classRepo := classrepo.NewClassRepositoryImpl(db)
+	sessionService := toothbrushsessionservice.NewToothBrushSessionServiceImpl(sessionRepo, classRepo, auditService, rdb)
+	sessionHandler := toothbrushsessionhandler.NewToothBrushSessionHandler(sessionService)
+
+	sessionGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	sessionGroup.POST("/create", sessionHandler.CreateSession, middlewares.RequirePermission(permission.ToothBrushSessionWrite))
+	sessionGroup.GET("/all", sessionHandler.GetAllSession, middlewares.RequirePermission(permission.ToothBrushSessionRead))
+	sessionGroup.GET("/:sessionId", sessionHandler.GetByIdSession, middlewares.RequirePermission(permission.ToothBrushSessionRead))
+	sessionGroup.PUT("/:sessionId/edit", sessionHandler.UpdateSession, middlewares.RequirePermission(permission.ToothBrushSessionWrite))
+	sessionGroup.DELETE("/:sessionId/delete", sessionHandler.DeleteSession, middlewares.RequirePermission(permission.ToothBrushSessionWrite))
+	sessionGroup.PUT("/:sessionId/class-override", sessionHandler.UpsertClassOverride, middlewares.RequirePermission(permission.ToothBrushSessionWrite))
+	sessionGroup.DELETE("/:sessionId/class-override/:classId", sessionHandler.DeleteClassOverride, middlewares.RequirePermission(permission.ToothBrushSessionWrite))
+}
@@ -3,9 +3,11 @@ package videoroute
 import (
 	videohandler "giat-cerika-service/internal/handlers/video_handler"
 	"giat-cerika-service/internal/middlewares"
+	auditrepo "giat-cerika-service/internal/repositories/audit_repo"
 	videorepo "giat-cerika-service/internal/repositories/video_repo"
+	auditservice "giat-cerika-service/internal/services/audit_service"
 	videoservice "giat-cerika-service/internal/services/video_service"
-	"strings"
+	"giat-cerika-service/pkg/constant/permission"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -13,18 +15,20 @@ import (
 )
 
 func VideoRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	auditRepo := auditrepo.NewAuditRepositoryImpl(db)
+	auditService := auditservice.NewAuditServiceImpl(auditRepo)
 	videoRepo := videorepo.NewVideoRepositoryImpl(db)
-	videoService := videoservice.NewVideoServiceImpl(videoRepo, rdb)
+	videoService := videoservice.NewVideoServiceImpl(videoRepo, auditService, rdb)
 	videoHandler := videohandler.NewVideoHandler(videoService)
 
 	e.GET("/all/latest", videoHandler.GetAllLatestVideo)
 	e.GET("/all/public", videoHandler.GetAllPublicVideo)
 	e.GET("/:videoId/public", videoHandler.GetByIdPublicVideo)
 
-	videoGroup := e.Group("", middlewares.JWTMiddleware(rdb), middlewares.RoleMiddleware(strings.ToLower("ADMIN")))
-	videoGroup.POST("/create", videoHandler.CreateVideo)
-	videoGroup.GET("/all", videoHandler.GetAllVideo)
-	videoGroup.GET("/:videoId", videoHandler.GetByIdVideo)
-	videoGroup.PUT("/:videoId/edit", videoHandler.UpdateVideo)
-	videoGroup.DELETE("/:videoId/delete", videoHandler.DeleteVideo)
+	videoGroup := e.Group("", middlewares.JWTMiddleware(rdb))
+	videoGroup.POST("/create", videoHandler.CreateVideo, middlewares.RequirePermission(permission.VideoWrite))
+	videoGroup.GET("/all", videoHandler.GetAllVideo, middlewares.RequirePermission(permission.VideoRead))
+	videoGroup.GET("/:videoId", videoHandler.GetByIdVideo, middlewares.RequirePermission(permission.VideoRead))
+	videoGroup.PUT("/:videoId/edit", videoHandler.UpdateVideo, middlewares.RequirePermission(permission.VideoWrite))
+	videoGroup.DELETE("/:videoId/delete", videoHandler.DeleteVideo, middlewares.RequirePermission(permission.VideoWrite))
 }